@@ -0,0 +1,351 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/will-wright-eng/gh-nav/internal/api"
+	"github.com/will-wright-eng/gh-nav/internal/git"
+	"github.com/will-wright-eng/gh-nav/internal/testing/fakegh"
+	"github.com/will-wright-eng/gh-nav/internal/ui"
+	"github.com/will-wright-eng/gh-nav/pkg/config"
+)
+
+// Exit codes, following gh's conventions
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+	exitAuth  = 4
+)
+
+// commandTimeout bounds the API calls of non-interactive commands
+const commandTimeout = 2 * time.Minute
+
+// errUsage marks errors caused by invalid invocation
+var errUsage = errors.New("usage error")
+
+// errNoToken is returned when no GitHub credentials could be found
+var errNoToken = errors.New("no GitHub token available")
+
+const usageText = `Usage: gh-nav [flags] [command]
+
+Browse GitHub pull requests in a terminal dashboard.
+
+Commands:
+  (none)    Start the interactive dashboard
+  prs       Print pull requests for one or more repositories
+  repos     Print repositories you have access to
+  doctor    Check authentication and API connectivity
+
+Flags:
+`
+
+// globalOptions are accepted before or after the command name
+type globalOptions struct {
+	repo       string
+	owner      string
+	configPath string
+	host       string
+	noCache    bool
+	offline    bool
+	debugLog   string
+	record     string
+	replay     string
+	demo       bool
+	version    bool
+}
+
+// register adds the global flags to a flag set
+func (o *globalOptions) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.repo, "repo", o.repo, "Select a repository using the `[HOST/]OWNER/REPO` format")
+	fs.StringVar(&o.repo, "R", o.repo, "Shorthand for --repo")
+	fs.StringVar(&o.owner, "owner", o.owner, "Start at the repository list of an `owner` (organization or user)")
+	fs.StringVar(&o.configPath, "config", o.configPath, "Path to a YAML config `file`")
+	fs.StringVar(&o.host, "host", o.host, "GitHub `hostname` (for GitHub Enterprise Server)")
+	fs.BoolVar(&o.noCache, "no-cache", o.noCache, "Bypass the API response cache")
+	fs.BoolVar(&o.offline, "offline", o.offline, "Serve everything from the response cache and queue changes without contacting GitHub")
+	fs.StringVar(&o.debugLog, "debug-log", o.debugLog, "Write debug logging to `file`")
+	fs.StringVar(&o.record, "record", o.record, "Record API traffic, without credentials, to fixture `file`")
+	fs.StringVar(&o.replay, "replay", o.replay, "Answer API requests from a fixture `file` recorded with --record")
+	fs.BoolVar(&o.demo, "demo", o.demo, "Run against built-in sample data instead of GitHub")
+	fs.BoolVar(&o.version, "version", o.version, "Print the version and exit")
+}
+
+// runFunc executes a subcommand once flags are parsed and config is loaded.
+// args are the positional arguments after the flags.
+type runFunc func(ctx context.Context, cfg *config.Config, opts *globalOptions, args []string) error
+
+// command is a non-interactive subcommand. setup registers its flags and returns
+// the function that runs it. Commands without positional arguments set noArgs.
+type command struct {
+	name   string
+	setup  func(fs *flag.FlagSet) runFunc
+	noArgs bool
+}
+
+var commands = []command{
+	{name: "prs", setup: prsCommand},
+	{name: "repos", setup: reposCommand, noArgs: true},
+	{name: "doctor", setup: doctorCommand, noArgs: true},
+}
+
+// run parses arguments, dispatches to the TUI or a subcommand and returns the exit code
+func run(args []string) int {
+	opts := &globalOptions{}
+	fs := flag.NewFlagSet("gh-nav", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), usageText)
+		fs.PrintDefaults()
+	}
+	opts.register(fs)
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	var runCmd runFunc
+	var cmdArgs []string
+	if rest := fs.Args(); len(rest) > 0 {
+		var cmd *command
+		for i := range commands {
+			if commands[i].name == rest[0] {
+				cmd = &commands[i]
+			}
+		}
+		if cmd == nil {
+			fmt.Fprintf(os.Stderr, "unknown command %q\n\n", rest[0])
+			fs.Usage()
+			return exitUsage
+		}
+
+		// Subcommands accept the global flags too, e.g. `gh-nav prs --repo o/r`
+		cmdFlags := flag.NewFlagSet("gh-nav "+cmd.name, flag.ContinueOnError)
+		cmdFlags.SetOutput(os.Stderr)
+		opts.register(cmdFlags)
+		runCmd = cmd.setup(cmdFlags)
+		if err := cmdFlags.Parse(rest[1:]); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return exitOK
+			}
+			return exitUsage
+		}
+		if cmd.noArgs && cmdFlags.NArg() > 0 {
+			fmt.Fprintf(os.Stderr, "unexpected arguments: %v\n", cmdFlags.Args())
+			return exitUsage
+		}
+		cmdArgs = cmdFlags.Args()
+	}
+
+	if opts.version {
+		fmt.Printf("gh-nav %s\n", version)
+		return exitOK
+	}
+
+	cfg, err := loadConfig(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		return exitError
+	}
+
+	if err := applyRepoOption(cfg, opts); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid --repo: %v\n", err)
+		return exitUsage
+	}
+
+	if opts.record != "" && opts.replay != "" {
+		fmt.Fprintln(os.Stderr, "--record and --replay can't be used together")
+		return exitUsage
+	}
+	// Offline mode is the response cache, which these all turn off
+	if opts.offline && (!cfg.Cache.Enabled || opts.record != "" || opts.replay != "" || opts.demo) {
+		fmt.Fprintln(os.Stderr, "--offline needs the response cache; it can't be combined with --no-cache, --record, --replay or --demo")
+		return exitUsage
+	}
+	if opts.replay != "" {
+		if err := api.CheckFixture(opts.replay); err != nil {
+			fmt.Fprintf(os.Stderr, "invalid --replay: %v\n", err)
+			return exitUsage
+		}
+		// Recordings carry no credentials, so any token will do
+		if cfg.GitHub.Token == "" {
+			cfg.GitHub.Token = "replay"
+			cfg.GitHub.TokenSource = "--replay"
+		}
+	}
+
+	if opts.demo {
+		server := fakegh.NewServer(fakegh.Demo())
+		defer server.Close()
+		useDemo(cfg, server.URL)
+	}
+
+	closeLog, err := setupLogging(cfg, runCmd == nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open debug log: %v\n", err)
+		return exitError
+	}
+	defer closeLog()
+
+	if runCmd == nil {
+		err = runTUI(cfg, opts)
+	} else {
+		err = runCmd(context.Background(), cfg, opts, cmdArgs)
+	}
+
+	return exitCode(err)
+}
+
+// loadConfig loads the config file and applies flag overrides
+func loadConfig(opts *globalOptions) (*config.Config, error) {
+	cfg, err := config.LoadFile(opts.configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.host != "" {
+		cfg.SetHost(opts.host)
+	}
+	if opts.noCache {
+		cfg.Cache.Enabled = false
+	}
+	cfg.Cache.Offline = opts.offline
+	if opts.debugLog != "" {
+		cfg.Debug.LogFile = opts.debugLog
+	}
+	cfg.Debug.RecordFile = opts.record
+	cfg.Debug.ReplayFile = opts.replay
+	return cfg, nil
+}
+
+// useDemo points the configuration at a fake GitHub serving sample data. The
+// cache and saved state stay off so demo data never mixes with real data.
+func useDemo(cfg *config.Config, url string) {
+	cfg.GitHub.BaseURL = url + "/"
+	cfg.GitHub.Token = "demo"
+	cfg.GitHub.TokenSource = "--demo"
+	cfg.Cache.Enabled = false
+	cfg.State.Dir = ""
+}
+
+// applyRepoOption resolves --repo (or GH_REPO) with gh's semantics: a HOST/ prefix
+// or repository URL switches the API host, and the option becomes OWNER/REPO
+func applyRepoOption(cfg *config.Config, opts *globalOptions) error {
+	spec := opts.repo
+	if spec == "" {
+		spec = os.Getenv("GH_REPO")
+	}
+	if spec == "" {
+		return nil
+	}
+
+	remote, err := git.ParseRepository(spec, cfg.GitHub.Host)
+	if err != nil {
+		return err
+	}
+	cfg.SetHost(remote.Host)
+	opts.repo = remote.FullName()
+	return nil
+}
+
+// setupLogging routes the standard logger to the debug log, or silences it in the
+// TUI where stray output would corrupt the screen
+func setupLogging(cfg *config.Config, interactive bool) (func(), error) {
+	if cfg.Debug.LogFile != "" {
+		f, err := tea.LogToFile(cfg.Debug.LogFile, "gh-nav")
+		if err != nil {
+			return func() {}, err
+		}
+		return func() { f.Close() }, nil
+	}
+	if interactive {
+		log.SetOutput(io.Discard)
+	}
+	return func() {}, nil
+}
+
+// exitCode maps a command error to a process exit code, reporting it on stderr
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+
+	fmt.Fprintln(os.Stderr, err)
+	switch {
+	case errors.Is(err, errUsage):
+		return exitUsage
+	case errors.Is(err, errNoToken), api.IsAuthError(err):
+		fmt.Fprintln(os.Stderr, "Try authenticating with: gh auth login")
+		return exitAuth
+	}
+	return exitError
+}
+
+// runTUI starts the interactive dashboard
+func runTUI(cfg *config.Config, opts *globalOptions) error {
+	// When launched inside a clone, jump straight to that repository's PRs
+	repo := opts.repo
+	if repo == "" && opts.owner == "" && !opts.demo {
+		if remote, ok := detectRepository(cfg); ok {
+			if opts.host == "" {
+				cfg.SetHost(remote.Host)
+			}
+			repo = remote.FullName()
+		}
+	}
+
+	// The client is created once the host is settled and shared by the whole session
+	app := ui.NewApp(cfg, api.NewClient(cfg))
+	switch {
+	case repo != "":
+		app.StartInRepository(repo)
+	case opts.owner != "":
+		app.StartInOwner(opts.owner)
+	}
+
+	p := tea.NewProgram(app, tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("error running app: %w", err)
+	}
+	return nil
+}
+
+// resolveRepo returns the --repo value or the repository of the current clone
+func resolveRepo(cfg *config.Config, opts *globalOptions) (string, error) {
+	if opts.repo != "" {
+		return opts.repo, nil
+	}
+	if remote, ok := detectRepository(cfg); ok {
+		if opts.host == "" {
+			cfg.SetHost(remote.Host)
+		}
+		return remote.FullName(), nil
+	}
+	return "", fmt.Errorf("%w: --repo is required outside a git clone", errUsage)
+}
+
+// detectRepository resolves the GitHub repository of the current working
+// directory, from remotes on github.com, GH_HOST, a host gh is logged in to or
+// the configured host
+func detectRepository(cfg *config.Config) (git.Remote, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	hosts := append(config.KnownHosts(), cfg.GitHub.Host)
+	remote, err := git.NewClient("").CurrentRepository(ctx, hosts)
+	if err != nil {
+		return git.Remote{}, false
+	}
+	return remote, true
+}
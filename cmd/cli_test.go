@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/will-wright-eng/gh-nav/pkg/config"
+)
+
+func TestRunUsageErrors(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected int
+	}{
+		{[]string{"--help"}, exitOK},
+		{[]string{"prs", "--help"}, exitOK},
+		{[]string{"--bogus-flag"}, exitUsage},
+		{[]string{"unknown-command"}, exitUsage},
+		{[]string{"--repo", "missing-slash", "prs"}, exitUsage},
+		{[]string{"repos", "extra-arg"}, exitUsage},
+		{[]string{"--offline", "--no-cache", "repos"}, exitUsage},
+		{[]string{"--config", "/nonexistent/config.yml", "repos"}, exitError},
+	}
+
+	for _, test := range tests {
+		if code := run(test.args); code != test.expected {
+			t.Errorf("run(%v) = %d, expected %d", test.args, code, test.expected)
+		}
+	}
+}
+
+func TestRepositoryArgs(t *testing.T) {
+	t.Setenv("GH_ENTERPRISE_TOKEN", "enterprise-token")
+
+	// A host in the arguments switches the API host, rather than listing a
+	// same-named repository on github.com
+	cfg := &config.Config{GitHub: config.GitHubConfig{Host: config.DefaultHost}}
+	repos, err := repositoryArgs(cfg, &globalOptions{}, []string{"ghe.example.com/team/service", "ghe.example.com/team/web"})
+	if err != nil {
+		t.Fatalf("repositoryArgs returned error: %v", err)
+	}
+	if len(repos) != 2 || repos[0] != "team/service" || cfg.GitHub.Host != "ghe.example.com" {
+		t.Errorf("Expected team/service on ghe.example.com, got %v on %s", repos, cfg.GitHub.Host)
+	}
+
+	// Arguments on different hosts, or against an explicit --host, are usage errors
+	cfg = &config.Config{GitHub: config.GitHubConfig{Host: config.DefaultHost}}
+	if _, err := repositoryArgs(cfg, &globalOptions{}, []string{"org/repo", "ghe.example.com/team/service"}); !errors.Is(err, errUsage) {
+		t.Errorf("Expected a usage error for mixed hosts, got %v", err)
+	}
+	if _, err := repositoryArgs(cfg, &globalOptions{host: "github.com"}, []string{"ghe.example.com/team/service"}); !errors.Is(err, errUsage) {
+		t.Errorf("Expected a usage error for a host other than --host, got %v", err)
+	}
+	if cfg.GitHub.Host != config.DefaultHost {
+		t.Errorf("Expected the host left alone after a usage error, got %s", cfg.GitHub.Host)
+	}
+}
+
+func TestApplyRepoOption(t *testing.T) {
+	t.Setenv("GH_REPO", "")
+	t.Setenv("GH_ENTERPRISE_TOKEN", "enterprise-token")
+
+	cfg := &config.Config{GitHub: config.GitHubConfig{Host: config.DefaultHost}}
+	opts := &globalOptions{repo: "ghe.example.com/team/service"}
+
+	if err := applyRepoOption(cfg, opts); err != nil {
+		t.Fatalf("applyRepoOption returned error: %v", err)
+	}
+	if opts.repo != "team/service" {
+		t.Errorf("Expected repo team/service, got %s", opts.repo)
+	}
+	if cfg.GitHub.Host != "ghe.example.com" || cfg.GitHub.Token != "enterprise-token" {
+		t.Errorf("Expected enterprise host and token, got %s %s", cfg.GitHub.Host, cfg.GitHub.Token)
+	}
+
+	// GH_REPO is the default when --repo isn't given
+	t.Setenv("GH_REPO", "cli/cli")
+	cfg = &config.Config{GitHub: config.GitHubConfig{Host: config.DefaultHost}}
+	opts = &globalOptions{}
+	if err := applyRepoOption(cfg, opts); err != nil || opts.repo != "cli/cli" {
+		t.Errorf("Expected GH_REPO to apply, got %q (%v)", opts.repo, err)
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/will-wright-eng/gh-nav/internal/api"
+	"github.com/will-wright-eng/gh-nav/pkg/config"
+)
+
+// doctorCommand checks the token and API connectivity and summarizes what the
+// dashboard will be able to see
+func doctorCommand(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, cfg *config.Config, opts *globalOptions, args []string) error {
+		fmt.Printf("Host:     %s\n", cfg.GitHub.Host)
+		fmt.Printf("API:      %s\n", cfg.GitHub.BaseURL)
+
+		if cfg.GitHub.Token == "" {
+			return fmt.Errorf("%w; set GITHUB_TOKEN or configure gh", errNoToken)
+		}
+		fmt.Printf("Token:    %s (from %s)\n", config.MaskToken(cfg.GitHub.Token), cfg.GitHub.TokenSource)
+
+		ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+		defer cancel()
+
+		client := api.NewClient(cfg)
+
+		login, err := client.GetAuthenticatedUser(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("User:     %s\n", login)
+
+		if limits, err := client.GetRateLimits(ctx); err == nil && limits.Core != nil {
+			fmt.Printf("Rate:     %d/%d remaining (resets %s)\n",
+				limits.Core.Remaining, limits.Core.Limit, limits.Core.Reset.Format("15:04:05"))
+		}
+
+		fmt.Println("\nFetching repositories...")
+		repos, err := client.GetUserRepositories(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Found %d repositories:\n", len(repos))
+
+		// Group repositories by organization/user
+		repoGroups := make(map[string][]string)
+		for _, repo := range repos {
+			parts := strings.Split(repo, "/")
+			if len(parts) == 2 {
+				repoGroups[parts[0]] = append(repoGroups[parts[0]], repo)
+			}
+		}
+
+		owners := make([]string, 0, len(repoGroups))
+		for owner := range repoGroups {
+			owners = append(owners, owner)
+		}
+		sort.Strings(owners)
+
+		for _, owner := range owners {
+			ownerRepos := repoGroups[owner]
+			fmt.Printf("\n%s (%d repos):\n", owner, len(ownerRepos))
+			for i, repo := range ownerRepos {
+				if i < 5 { // Show first 5 repos per org
+					fmt.Printf("  - %s\n", repo)
+				} else {
+					fmt.Printf("  ... and %d more\n", len(ownerRepos)-5)
+					break
+				}
+			}
+		}
+		return nil
+	}
+}
@@ -1,29 +1,12 @@
 package main
 
 import (
-	"fmt"
-	"log"
 	"os"
-
-	tea "github.com/charmbracelet/bubbletea"
-	"github.com/will-wright-eng/gh-nav/internal/ui"
-	"github.com/will-wright-eng/gh-nav/pkg/config"
 )
 
-func main() {
-	// Load configuration
-	cfg, err := config.Load()
-	if err != nil {
-		log.Fatalf("Failed to load config: %v", err)
-	}
-
-	// Initialize the UI
-	app := ui.NewApp(cfg)
+// version is set at build time by script/build.sh
+var version = "dev"
 
-	// Run the TUI
-	p := tea.NewProgram(app, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
-		fmt.Printf("Error running app: %v\n", err)
-		os.Exit(1)
-	}
+func main() {
+	os.Exit(run(os.Args[1:]))
 }
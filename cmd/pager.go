@@ -0,0 +1,58 @@
+package main
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/will-wright-eng/gh-nav/pkg/config"
+	"golang.org/x/term"
+)
+
+// pager is the destination for command output: the configured pager when stdout is
+// a terminal (like gh does), stdout otherwise
+type pager struct {
+	io.Writer
+	cmd  *exec.Cmd
+	pipe io.WriteCloser
+}
+
+// startPager starts the configured pager, falling back to stdout
+func startPager(cfg *config.Config) *pager {
+	command := cfg.External.Pager
+	if command == "" || command == "cat" || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return &pager{Writer: os.Stdout}
+	}
+
+	fields := strings.Fields(command)
+	cmd := exec.Command(fields[0], fields[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	if os.Getenv("LESS") == "" {
+		cmd.Env = append(cmd.Env, "LESS=FRX")
+	}
+	if os.Getenv("LV") == "" {
+		cmd.Env = append(cmd.Env, "LV=-c")
+	}
+
+	pipe, err := cmd.StdinPipe()
+	if err != nil {
+		return &pager{Writer: os.Stdout}
+	}
+	if err := cmd.Start(); err != nil {
+		return &pager{Writer: os.Stdout}
+	}
+
+	return &pager{Writer: pipe, cmd: cmd, pipe: pipe}
+}
+
+// Close flushes output to the pager and waits for the user to quit it
+func (p *pager) Close() {
+	if p.cmd == nil {
+		return
+	}
+	p.pipe.Close()
+	p.cmd.Wait()
+}
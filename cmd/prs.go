@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/will-wright-eng/gh-nav/internal/api"
+	"github.com/will-wright-eng/gh-nav/internal/git"
+	"github.com/will-wright-eng/gh-nav/internal/models"
+	"github.com/will-wright-eng/gh-nav/internal/output"
+	"github.com/will-wright-eng/gh-nav/pkg/config"
+)
+
+// maxConcurrentRepos bounds parallel PR fetches across repositories
+const maxConcurrentRepos = 4
+
+// prsCommand prints the open pull requests of one or more repositories.
+// Repositories come from positional arguments, --owner (all of an owner's
+// repositories), --repo, or the current clone.
+func prsCommand(fs *flag.FlagSet) runFunc {
+	opts := output.Options{}
+	asJSON := fs.Bool("json", false, "Output JSON (same as --format json)")
+	fields := fs.String("fields", "", "Comma-separated `fields` to output")
+	fs.StringVar(&opts.Format, "format", output.FormatTable, "Output `format`: table, json or csv")
+	fs.StringVar(&opts.JQ, "jq", "", "Filter JSON output using a jq `expression`")
+	fs.StringVar(&opts.Template, "template", "", "Format JSON output using a Go `template`")
+
+	return func(ctx context.Context, cfg *config.Config, globals *globalOptions, args []string) error {
+		if *asJSON {
+			opts.Format = output.FormatJSON
+		}
+		if *fields != "" {
+			opts.Fields = strings.Split(*fields, ",")
+		}
+		if err := opts.Validate(output.FieldNames(models.PullRequest{})); err != nil {
+			return fmt.Errorf("%w: %v", errUsage, err)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+		defer cancel()
+
+		// Positional repositories may name another host, so they're resolved
+		// before the client is created for it
+		repos, err := repositoryArgs(cfg, globals, args)
+		if err != nil {
+			return err
+		}
+		client := api.NewClient(cfg)
+		if len(repos) == 0 {
+			if repos, err = prsRepositories(ctx, client, cfg, globals); err != nil {
+				return err
+			}
+		}
+
+		prs, fetchErr := fetchPullRequests(ctx, client, repos)
+
+		defaults := []string{"number", "title", "author", "review_status", "updated_at"}
+		if len(repos) > 1 {
+			defaults = append([]string{"repo_name"}, defaults...)
+		}
+
+		out := startPager(cfg)
+		defer out.Close()
+
+		if err := output.Write(out, prs, defaults, opts); err != nil {
+			return err
+		}
+		return fetchErr
+	}
+}
+
+// repositoryArgs parses positional [HOST/]OWNER/REPO arguments. A host they
+// name switches the API host, as with --repo; arguments on different hosts, or
+// on another host than an explicit --host, are a usage error.
+func repositoryArgs(cfg *config.Config, globals *globalOptions, args []string) ([]string, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+	repos := make([]string, 0, len(args))
+	host := ""
+	for _, arg := range args {
+		remote, err := git.ParseRepository(arg, cfg.GitHub.Host)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errUsage, err)
+		}
+		if host != "" && !strings.EqualFold(remote.Host, host) {
+			return nil, fmt.Errorf("%w: repositories on different hosts (%s and %s) can't be listed together", errUsage, host, remote.Host)
+		}
+		host = remote.Host
+		repos = append(repos, remote.FullName())
+	}
+	if !strings.EqualFold(host, cfg.GitHub.Host) {
+		if globals.host != "" {
+			return nil, fmt.Errorf("%w: %s is on %s, not --host %s", errUsage, args[0], host, cfg.GitHub.Host)
+		}
+		cfg.SetHost(host)
+	}
+	return repos, nil
+}
+
+// prsRepositories determines which repositories the prs command covers when
+// none are named: --owner's, --repo or the current clone's
+func prsRepositories(ctx context.Context, client api.GitHub, cfg *config.Config, globals *globalOptions) ([]string, error) {
+	if globals.owner != "" && globals.repo == "" {
+		return ownerRepositories(ctx, client, globals.owner)
+	}
+
+	repo, err := resolveRepo(cfg, globals)
+	if err != nil {
+		return nil, err
+	}
+	return []string{repo}, nil
+}
+
+// fetchPullRequests fetches PRs for each repository concurrently, preserving the
+// repository order. Failures are reported per repository and joined.
+func fetchPullRequests(ctx context.Context, client api.GitHub, repos []string) ([]*models.PullRequest, error) {
+	results := make([][]*models.PullRequest, len(repos))
+	errs := make([]error, len(repos))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentRepos)
+	for i, repo := range repos {
+		wg.Add(1)
+		go func(i int, repo string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			owner, name, _ := strings.Cut(repo, "/")
+			githubPRs, err := client.GetPullRequests(ctx, owner, name)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			results[i] = models.FromGitHubPRs(githubPRs, repo)
+		}(i, repo)
+	}
+	wg.Wait()
+
+	prs := []*models.PullRequest{}
+	for _, result := range results {
+		prs = append(prs, result...)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		if len(repos) > 1 {
+			fmt.Fprintln(os.Stderr, "Some repositories could not be fetched:")
+		}
+		return prs, err
+	}
+	return prs, nil
+}
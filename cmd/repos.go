@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/will-wright-eng/gh-nav/internal/api"
+	"github.com/will-wright-eng/gh-nav/pkg/config"
+)
+
+// reposCommand prints the repositories of the user and their organizations
+func reposCommand(fs *flag.FlagSet) runFunc {
+	asJSON := fs.Bool("json", false, "Output JSON")
+
+	return func(ctx context.Context, cfg *config.Config, opts *globalOptions, args []string) error {
+		ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+		defer cancel()
+
+		// --owner lists one organization's or user's repositories instead
+		client := api.NewClient(cfg)
+		var repos []string
+		var err error
+		if opts.owner != "" {
+			repos, err = ownerRepositories(ctx, client, opts.owner)
+		} else {
+			repos, err = client.GetUserRepositories(ctx)
+		}
+		if err != nil {
+			return err
+		}
+
+		out := startPager(cfg)
+		defer out.Close()
+
+		if *asJSON {
+			if repos == nil {
+				repos = []string{}
+			}
+			return writeJSON(out, repos)
+		}
+
+		for _, repo := range repos {
+			fmt.Fprintln(out, repo)
+		}
+		return nil
+	}
+}
+
+// ownerRepositories lists the repositories of one owner, whether or not the
+// user belongs to it: their own (including ones they collaborate on), an
+// organization's, or another user's
+func ownerRepositories(ctx context.Context, client api.GitHub, owner string) ([]string, error) {
+	viewer, err := client.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(owner, viewer) {
+		return client.OwnerRepositories(viewer, true).All(ctx)
+	}
+	repos, err := client.OwnerRepositories(owner, false).All(ctx)
+	if api.IsNotFound(err) {
+		// Not an organization, so a user
+		return client.OwnerRepositories(owner, true).All(ctx)
+	}
+	return repos, err
+}
+
+// writeJSON prints v as indented JSON
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/will-wright-eng/gh-nav/internal/api"
+	"github.com/will-wright-eng/gh-nav/internal/testing/fakegh"
+	"github.com/will-wright-eng/gh-nav/pkg/config"
+)
+
+func TestOwnerRepositories(t *testing.T) {
+	now := time.Now()
+	server := fakegh.NewServer(&fakegh.Data{
+		User: "octocat",
+		Orgs: []string{"acme"},
+		Repos: []*fakegh.Repo{
+			{Owner: "octocat", Name: "dotfiles", UpdatedAt: now},
+			{Owner: "acme", Name: "api", UpdatedAt: now},
+			{Owner: "outsider", Name: "tool", UpdatedAt: now},
+		},
+	})
+	t.Cleanup(server.Close)
+	client := api.NewClient(&config.Config{GitHub: config.GitHubConfig{Token: "test-token", BaseURL: server.URL + "/"}})
+	ctx := context.Background()
+
+	tests := map[string]string{
+		"octocat":  "octocat/dotfiles",
+		"acme":     "acme/api",
+		"outsider": "outsider/tool", // an owner the user doesn't belong to
+	}
+	for owner, expected := range tests {
+		repos, err := ownerRepositories(ctx, client, owner)
+		if err != nil {
+			t.Fatalf("ownerRepositories(%s) failed: %v", owner, err)
+		}
+		if len(repos) != 1 || repos[0] != expected {
+			t.Errorf("Expected only %s for %s, got %v", expected, owner, repos)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v58/github"
+	"github.com/will-wright-eng/gh-nav/internal/models"
+)
+
+// GetChecks fetches the check runs and commit statuses for a commit, returning
+// them as a single list (check runs first, in API order)
+func (c *Client) GetChecks(ctx context.Context, owner, repo, ref string) ([]*models.Check, error) {
+	var checks []*models.Check
+
+	runOpt := &github.ListCheckRunsOptions{
+		Filter:      github.String("latest"),
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		result, resp, err := c.client.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, runOpt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list check runs for %s/%s@%s: %w", owner, repo, shortSHA(ref), err)
+		}
+
+		for _, run := range result.CheckRuns {
+			checks = append(checks, models.FromGitHubCheckRun(run))
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		runOpt.Page = resp.NextPage
+	}
+
+	statusOpt := &github.ListOptions{PerPage: 100}
+	for {
+		combined, resp, err := c.client.Repositories.GetCombinedStatus(ctx, owner, repo, ref, statusOpt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get combined status for %s/%s@%s: %w", owner, repo, shortSHA(ref), err)
+		}
+
+		for _, status := range combined.Statuses {
+			checks = append(checks, models.FromGitHubStatus(status))
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		statusOpt.Page = resp.NextPage
+	}
+
+	return checks, nil
+}
+
+// shortSHA abbreviates a commit SHA for messages
+func shortSHA(ref string) string {
+	if len(ref) == 40 {
+		return ref[:7]
+	}
+	return ref
+}
@@ -3,104 +3,189 @@ package api
 import (
 	"context"
 	"fmt"
+	"log"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/google/go-github/v58/github"
+	"github.com/will-wright-eng/gh-nav/internal/models"
 	"github.com/will-wright-eng/gh-nav/pkg/config"
 )
 
-// Client wraps the GitHub API client
+// defaultBaseURL is the public GitHub API
+const defaultBaseURL = "https://api.github.com/"
+
+// GitHub is the set of GitHub operations gh-nav uses. *Client implements it;
+// tests substitute fakes.
+type GitHub interface {
+	GetAuthenticatedUser(ctx context.Context) (string, error)
+	GetRateLimits(ctx context.Context) (*github.RateLimits, error)
+	GetOwners(ctx context.Context) (string, []string, error)
+	GetUserRepositories(ctx context.Context) ([]string, error)
+	OwnerRepositories(owner string, isUser bool) *Pager[string]
+
+	GetPullRequests(ctx context.Context, owner, repo string) ([]*github.PullRequest, error)
+	GetPullRequest(ctx context.Context, owner, repo string, number int) (*github.PullRequest, error)
+	PullRequests(owner, repo, state string) *Pager[*models.PullRequest]
+	GetPullRequestFiles(ctx context.Context, owner, repo string, number int) ([]*models.FileChange, error)
+
+	GetChecks(ctx context.Context, owner, repo, ref string) ([]*models.Check, error)
+	GetJobLog(ctx context.Context, owner, repo string, jobID int64) (*models.JobLog, error)
+	RerunFailedJobs(ctx context.Context, owner, repo string, jobID int64) error
+
+	GetThreads(ctx context.Context, owner, repo string, number int, prID int64) ([]*models.Thread, error)
+	GetActivity(ctx context.Context, owner, repo string, numbers []int) (map[int]*models.Activity, error)
+	CreateComment(ctx context.Context, owner, repo string, number int, body string) error
+	ReplyToReviewComment(ctx context.Context, owner, repo string, number int, commentID int64, body string) error
+	SubmitReview(ctx context.Context, owner, repo string, number int, event, body string) error
+
+	GetFieldOptions(ctx context.Context, owner, repo, field string) ([]string, error)
+	UpdateField(ctx context.Context, owner, repo string, number int, field string, add, remove []string) error
+
+	GetMergeInfo(ctx context.Context, owner, repo string, number int) (*models.MergeInfo, error)
+	MergePullRequest(ctx context.Context, owner, repo string, number int, headSHA string, req models.MergeRequest) error
+	EnableAutoMerge(ctx context.Context, nodeID string, req models.MergeRequest) error
+	DeleteBranch(ctx context.Context, owner, repo, branch string) error
+
+	ClosePullRequest(ctx context.Context, owner, repo string, number int) error
+	ReopenPullRequest(ctx context.Context, owner, repo string, number int) error
+	SetDraft(ctx context.Context, nodeID string, draft bool) error
+
+	// Offline reports whether GitHub is unreachable (or --offline is set), so
+	// reads come from the cache and writes fail with ErrOffline
+	Offline() bool
+}
+
+var _ GitHub = (*Client)(nil)
+
+// Client wraps the GitHub API client. A Client is safe for concurrent use;
+// create one and share it, so connections are reused.
 type Client struct {
-	client *github.Client
-	config *config.Config
+	client   *github.Client
+	download *http.Client // unauthenticated client for pre-signed download URLs
+	config   *config.Config
+	conn     *connectivity
 }
 
-// NewClient creates a new GitHub API client
+// NewClient creates a new GitHub API client. Requests go through the
+// transport chain built by newTransport, and are recorded to or replayed from
+// a fixture when the debug configuration asks for it.
 func NewClient(cfg *config.Config) *Client {
-	httpClient := &http.Client{
-		Timeout: 30 * time.Second,
+	// Point at GitHub Enterprise Server (or any non-default API) when configured
+	apiURL, err := parseBaseURL(cfg.GitHub.BaseURL)
+	if err != nil {
+		apiURL, _ = url.Parse(defaultBaseURL)
 	}
 
-	client := github.NewClient(httpClient)
-	if cfg.GitHub.Token != "" {
-		client = client.WithAuthToken(cfg.GitHub.Token)
+	// Recording and replaying happen below authentication, so they see the
+	// token and strip it, and cover log downloads as well
+	sanitize := sanitizer{apiHost: apiURL.Host, token: cfg.GitHub.Token}
+	var base http.RoundTripper = http.DefaultTransport
+	switch {
+	case cfg.Debug.ReplayFile != "":
+		base = newReplayTransport(cfg.Debug.ReplayFile, sanitize)
+	case cfg.Debug.RecordFile != "":
+		base = &recordTransport{next: base, path: cfg.Debug.RecordFile, sanitize: sanitize}
+	}
+	if cfg.Debug.LogFile != "" {
+		base = &loggingTransport{next: base}
 	}
 
+	conn := &connectivity{forced: cfg.Cache.Offline}
+	client := github.NewClient(&http.Client{
+		Timeout:   30 * time.Second,
+		Transport: newTransport(cfg, apiURL.Host, conn, base),
+	})
+	client.BaseURL = apiURL
+
 	return &Client{
-		client: client,
-		config: cfg,
+		client:   client,
+		download: &http.Client{Timeout: 30 * time.Second, Transport: base},
+		config:   cfg,
+		conn:     conn,
 	}
 }
 
-// GetUserRepositories fetches repositories for the authenticated user and their organizations
-func (c *Client) GetUserRepositories(ctx context.Context) ([]string, error) {
-	// Get the authenticated user first
+// Offline implements GitHub
+func (c *Client) Offline() bool {
+	return c.config.Cache.Enabled && c.conn.isOffline()
+}
+
+// parseBaseURL parses an API base URL, ensuring the trailing slash go-github requires
+func parseBaseURL(raw string) (*url.URL, error) {
+	if raw == "" {
+		return nil, fmt.Errorf("empty base URL")
+	}
+	if !strings.HasSuffix(raw, "/") {
+		raw += "/"
+	}
+	return url.Parse(raw)
+}
+
+// GetAuthenticatedUser returns the login of the user the token belongs to
+func (c *Client) GetAuthenticatedUser(ctx context.Context) (string, error) {
 	user, _, err := c.client.Users.Get(ctx, "")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get authenticated user: %w", err)
+		return "", fmt.Errorf("failed to get authenticated user: %w", err)
 	}
+	return user.GetLogin(), nil
+}
 
-	var allRepos []*github.Repository
+// GetRateLimits fetches the current API rate limit status
+func (c *Client) GetRateLimits(ctx context.Context) (*github.RateLimits, error) {
+	limits, _, err := c.client.RateLimit.Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rate limits: %w", err)
+	}
+	return limits, nil
+}
 
-	// 1. Fetch user's personal repositories
-	userRepos, err := c.getRepositoriesForUser(ctx, *user.Login)
+// GetOwners returns the authenticated user's login and the organizations they belong to
+func (c *Client) GetOwners(ctx context.Context) (string, []string, error) {
+	user, _, err := c.client.Users.Get(ctx, "")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user repositories: %w", err)
+		return "", nil, fmt.Errorf("failed to get authenticated user: %w", err)
 	}
-	allRepos = append(allRepos, userRepos...)
 
-	// 2. Fetch user's organizations
 	orgs, err := c.getUserOrganizations(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user organizations: %w", err)
+		return "", nil, fmt.Errorf("failed to get user organizations: %w", err)
 	}
 
-	// 3. Fetch repositories from each organization
+	names := make([]string, 0, len(orgs))
 	for _, org := range orgs {
-		orgRepos, err := c.getRepositoriesForOrganization(ctx, *org.Login)
-		if err != nil {
-			// Log error but continue with other orgs
-			fmt.Printf("Warning: failed to get repos for org %s: %v\n", *org.Login, err)
-			continue
-		}
-		allRepos = append(allRepos, orgRepos...)
+		names = append(names, org.GetLogin())
 	}
+	return user.GetLogin(), names, nil
+}
 
-	// Convert to string slice
-	var repoNames []string
-	for _, repo := range allRepos {
-		repoNames = append(repoNames, *repo.FullName)
+// GetUserRepositories fetches repositories for the authenticated user and their organizations
+func (c *Client) GetUserRepositories(ctx context.Context) ([]string, error) {
+	user, orgs, err := c.GetOwners(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	return repoNames, nil
-}
-
-// getRepositoriesForUser fetches repositories for a specific user
-func (c *Client) getRepositoriesForUser(ctx context.Context, username string) ([]*github.Repository, error) {
-	opt := &github.RepositoryListByUserOptions{
-		Type:        "all", // all, owner, public, private, member
-		Sort:        "updated",
-		Direction:   "desc",
-		ListOptions: github.ListOptions{PerPage: 100},
+	// 1. Fetch user's personal repositories
+	repoNames, err := c.OwnerRepositories(user, true).All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user repositories: %w", err)
 	}
 
-	var allRepos []*github.Repository
-	for {
-		repos, resp, err := c.client.Repositories.ListByUser(ctx, username, opt)
+	// 2. Fetch repositories from each organization
+	for _, org := range orgs {
+		orgRepos, err := c.OwnerRepositories(org, false).All(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("failed to list repositories for user %s: %w", username, err)
-		}
-
-		allRepos = append(allRepos, repos...)
-
-		if resp.NextPage == 0 {
-			break
+			// Log error but continue with other orgs
+			log.Printf("Warning: failed to get repos for org %s: %v", org, err)
+			continue
 		}
-		opt.Page = resp.NextPage
+		repoNames = append(repoNames, orgRepos...)
 	}
 
-	return allRepos, nil
+	return repoNames, nil
 }
 
 // getUserOrganizations fetches organizations the user belongs to
@@ -125,33 +210,6 @@ func (c *Client) getUserOrganizations(ctx context.Context) ([]*github.Organizati
 	return allOrgs, nil
 }
 
-// getRepositoriesForOrganization fetches repositories for a specific organization
-func (c *Client) getRepositoriesForOrganization(ctx context.Context, orgName string) ([]*github.Repository, error) {
-	opt := &github.RepositoryListByOrgOptions{
-		Type:        "all", // all, public, private, forks, sources, member
-		Sort:        "updated",
-		Direction:   "desc",
-		ListOptions: github.ListOptions{PerPage: 100},
-	}
-
-	var allRepos []*github.Repository
-	for {
-		repos, resp, err := c.client.Repositories.ListByOrg(ctx, orgName, opt)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list repositories for org %s: %w", orgName, err)
-		}
-
-		allRepos = append(allRepos, repos...)
-
-		if resp.NextPage == 0 {
-			break
-		}
-		opt.Page = resp.NextPage
-	}
-
-	return allRepos, nil
-}
-
 // GetRepository fetches a specific repository
 func (c *Client) GetRepository(ctx context.Context, owner, repo string) (*github.Repository, error) {
 	repository, _, err := c.client.Repositories.Get(ctx, owner, repo)
@@ -161,29 +219,55 @@ func (c *Client) GetRepository(ctx context.Context, owner, repo string) (*github
 	return repository, nil
 }
 
-// GetPullRequests fetches pull requests for a specific repository
+// GetPullRequests fetches all open pull requests for a specific repository
 func (c *Client) GetPullRequests(ctx context.Context, owner, repo string) ([]*github.PullRequest, error) {
+	return NewPager(func(ctx context.Context, page int) ([]*github.PullRequest, int, error) {
+		return c.GetPullRequestsPage(ctx, owner, repo, models.PRStateOpen, page)
+	}).All(ctx)
+}
+
+// GetPullRequestsPage fetches one page of a repository's pull requests in a
+// list state (open, closed, merged or all), most recently updated first, and
+// returns the next page number, or 0 after the last page. The API can't tell
+// merged from closed, so both are listed as closed and split by merged_at; a
+// page may therefore hold fewer PRs than requested, or none.
+func (c *Client) GetPullRequestsPage(ctx context.Context, owner, repo, state string, page int) ([]*github.PullRequest, int, error) {
+	apiState := state
+	if state == models.PRStateMerged {
+		apiState = models.PRStateClosed
+	}
 	opt := &github.PullRequestListOptions{
-		State:       "open", // open, closed, all
+		State:       apiState,
 		Sort:        "updated",
 		Direction:   "desc",
-		ListOptions: github.ListOptions{PerPage: 100},
+		ListOptions: github.ListOptions{PerPage: 100, Page: page},
 	}
 
-	var allPRs []*github.PullRequest
-	for {
-		prs, resp, err := c.client.PullRequests.List(ctx, owner, repo, opt)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list pull requests for %s/%s: %w", owner, repo, err)
-		}
-
-		allPRs = append(allPRs, prs...)
+	prs, resp, err := c.client.PullRequests.List(ctx, owner, repo, opt)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list %s pull requests for %s/%s: %w", state, owner, repo, err)
+	}
 
-		if resp.NextPage == 0 {
-			break
+	if state == models.PRStateClosed || state == models.PRStateMerged {
+		wantMerged := state == models.PRStateMerged
+		filtered := prs[:0]
+		for _, pr := range prs {
+			if (pr.MergedAt != nil) == wantMerged {
+				filtered = append(filtered, pr)
+			}
 		}
-		opt.Page = resp.NextPage
+		prs = filtered
 	}
 
-	return allPRs, nil
+	return prs, resp.NextPage, nil
+}
+
+// GetPullRequest fetches a single pull request, which carries fields the list
+// endpoint leaves out (e.g. merged_by)
+func (c *Client) GetPullRequest(ctx context.Context, owner, repo string, number int) (*github.PullRequest, error) {
+	pr, _, err := c.client.PullRequests.Get(ctx, owner, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request %s/%s#%d: %w", owner, repo, number, err)
+	}
+	return pr, nil
 }
@@ -2,9 +2,12 @@ package api
 
 import (
 	"context"
+	"net/http"
 	"testing"
 	"time"
 
+	"github.com/will-wright-eng/gh-nav/internal/models"
+	"github.com/will-wright-eng/gh-nav/internal/testing/fakegh"
 	"github.com/will-wright-eng/gh-nav/pkg/config"
 )
 
@@ -85,3 +88,161 @@ func TestGetPullRequests(t *testing.T) {
 		t.Fatal("Client should not be nil")
 	}
 }
+
+func TestNewClientEnterpriseBaseURL(t *testing.T) {
+	cfg := &config.Config{
+		GitHub: config.GitHubConfig{
+			Host:    "ghe.example.com",
+			BaseURL: "https://ghe.example.com/api/v3",
+		},
+	}
+
+	client := NewClient(cfg)
+	if got := client.client.BaseURL.String(); got != "https://ghe.example.com/api/v3/" {
+		t.Errorf("Expected enterprise base URL, got %s", got)
+	}
+}
+
+func TestGraphQLURL(t *testing.T) {
+	tests := []struct {
+		baseURL  string
+		expected string
+	}{
+		{"https://api.github.com", "https://api.github.com/graphql"},
+		{"https://ghe.example.com/api/v3", "https://ghe.example.com/api/graphql"},
+	}
+
+	for _, tt := range tests {
+		client := NewClient(&config.Config{GitHub: config.GitHubConfig{BaseURL: tt.baseURL}})
+		if got := client.graphQLURL(); got != tt.expected {
+			t.Errorf("graphQLURL() for %s = %s, expected %s", tt.baseURL, got, tt.expected)
+		}
+	}
+}
+
+// newFakeClient returns a client talking to a fake GitHub seeded with the demo data
+func newFakeClient(t *testing.T) (*Client, *fakegh.Server) {
+	t.Helper()
+	server := fakegh.NewServer(fakegh.Demo())
+	t.Cleanup(server.Close)
+	cfg := &config.Config{GitHub: config.GitHubConfig{Token: "test-token", BaseURL: server.URL + "/"}}
+	return NewClient(cfg), server
+}
+
+func TestClientPagesAgainstFakeGitHub(t *testing.T) {
+	client, server := newFakeClient(t)
+	server.SetMaxPerPage(10)
+	ctx := context.Background()
+
+	pager := client.PullRequests("widgets-inc", "widgets", "open")
+	first, err := pager.Next(ctx)
+	if err != nil {
+		t.Fatalf("Next failed: %v", err)
+	}
+	if len(first) != 10 || pager.Done() {
+		t.Fatalf("Expected a first page of 10 with more to come, got %d (done %v)", len(first), pager.Done())
+	}
+	rest, err := pager.All(ctx)
+	if err != nil {
+		t.Fatalf("All failed: %v", err)
+	}
+	if len(first)+len(rest) != 24 {
+		t.Errorf("Expected 24 pull requests in total, got %d", len(first)+len(rest))
+	}
+
+	merged, err := client.PullRequests("acme", "api", "merged").All(ctx)
+	if err != nil {
+		t.Fatalf("Listing merged pull requests failed: %v", err)
+	}
+	if len(merged) != 1 || merged[0].Number != 131 {
+		t.Errorf("Expected only #131 to be merged, got %v", merged)
+	}
+
+	repos, err := client.OwnerRepositories("acme", false).All(ctx)
+	if err != nil {
+		t.Fatalf("Listing repositories failed: %v", err)
+	}
+	if len(repos) != 2 {
+		t.Errorf("Expected 2 acme repositories, got %v", repos)
+	}
+}
+
+func TestClientDetailsAgainstFakeGitHub(t *testing.T) {
+	client, _ := newFakeClient(t)
+	ctx := context.Background()
+
+	checks, err := client.GetChecks(ctx, "acme", "api", "rate-limiter")
+	if err != nil {
+		t.Fatalf("GetChecks failed: %v", err)
+	}
+	var failed *models.Check
+	for _, c := range checks {
+		if c.Conclusion == "failure" {
+			failed = c
+		}
+	}
+	if failed == nil {
+		t.Fatalf("Expected a failing check, got %v", checks)
+	}
+
+	log, err := client.GetJobLog(ctx, "acme", "api", failed.ID)
+	if err != nil {
+		t.Fatalf("GetJobLog failed: %v", err)
+	}
+	if len(log.Lines) == 0 {
+		t.Error("Expected the job log to have lines")
+	}
+
+	pr, err := client.GetPullRequest(ctx, "acme", "api", 142)
+	if err != nil {
+		t.Fatalf("GetPullRequest failed: %v", err)
+	}
+	threads, err := client.GetThreads(ctx, "acme", "api", 142, pr.GetID())
+	if err != nil {
+		t.Fatalf("GetThreads failed: %v", err)
+	}
+	var replied bool
+	for _, thread := range threads {
+		if thread.FilePath == "internal/limiter/limiter.go" && len(thread.Comments) == 2 && !thread.Resolved {
+			replied = true
+		}
+	}
+	if !replied {
+		t.Error("Expected an unresolved review thread with a reply on limiter.go")
+	}
+
+	activity, err := client.GetActivity(ctx, "acme", "api", []int{142, 139})
+	if err != nil {
+		t.Fatalf("GetActivity failed: %v", err)
+	}
+	if a := activity[142]; a == nil || a.UnresolvedThreads != 1 || a.Comments != 3 {
+		t.Errorf("Expected #142 to have 1 unresolved thread and 3 comments, got %+v", a)
+	}
+	if a := activity[139]; a == nil || a.UnresolvedThreads != 0 {
+		t.Errorf("Expected #139 to have no unresolved threads, got %+v", a)
+	}
+}
+
+func TestClientErrorsAgainstFakeGitHub(t *testing.T) {
+	client, server := newFakeClient(t)
+	ctx := context.Background()
+
+	server.Inject(fakegh.Fault{Path: "/repos/acme/api/pulls/139", Status: http.StatusServiceUnavailable, Times: 1})
+	if _, err := client.GetPullRequest(ctx, "acme", "api", 139); err != nil {
+		t.Errorf("Expected a transient 503 to be retried, got %v", err)
+	}
+
+	server.SetRateLimit(5000, 0, time.Now().Add(time.Hour))
+	if _, err := client.GetPullRequest(ctx, "acme", "api", 139); err == nil {
+		t.Error("Expected an exhausted rate limit to fail the request")
+	}
+
+	// go-github remembers the exhausted limit, so check auth with a fresh client
+	server.SetRateLimit(5000, 5000, time.Now().Add(time.Hour))
+	server.RequireToken("another-token")
+	client = NewClient(&config.Config{GitHub: config.GitHubConfig{Token: "test-token", BaseURL: server.URL + "/"}})
+	_, err := client.GetPullRequest(ctx, "acme", "api", 139)
+	if !IsAuthError(err) {
+		t.Errorf("Expected an auth error, got %v", err)
+	}
+}
@@ -0,0 +1,263 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v58/github"
+	"github.com/will-wright-eng/gh-nav/internal/models"
+)
+
+// GetIssueComments fetches the top-level conversation comments of a pull request
+func (c *Client) GetIssueComments(ctx context.Context, owner, repo string, number int, prID int64) ([]*models.Comment, error) {
+	opt := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+
+	var comments []*models.Comment
+	for {
+		page, resp, err := c.client.Issues.ListComments(ctx, owner, repo, number, opt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list comments for %s/%s#%d: %w", owner, repo, number, err)
+		}
+
+		for _, comment := range page {
+			comments = append(comments, models.FromGitHubIssueComment(comment, prID))
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	return comments, nil
+}
+
+// GetReviewComments fetches the inline review comments of a pull request
+func (c *Client) GetReviewComments(ctx context.Context, owner, repo string, number int, prID int64) ([]*models.Comment, error) {
+	opt := &github.PullRequestListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+
+	var comments []*models.Comment
+	for {
+		page, resp, err := c.client.PullRequests.ListComments(ctx, owner, repo, number, opt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list review comments for %s/%s#%d: %w", owner, repo, number, err)
+		}
+
+		for _, comment := range page {
+			comments = append(comments, models.FromGitHubReviewComment(comment, prID))
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	return comments, nil
+}
+
+// GetReviews fetches submitted reviews that have a body or a verdict, as comments
+func (c *Client) GetReviews(ctx context.Context, owner, repo string, number int, prID int64) ([]*models.Comment, error) {
+	opt := &github.ListOptions{PerPage: 100}
+
+	var comments []*models.Comment
+	for {
+		page, resp, err := c.client.PullRequests.ListReviews(ctx, owner, repo, number, opt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list reviews for %s/%s#%d: %w", owner, repo, number, err)
+		}
+
+		for _, review := range page {
+			if comment := models.FromGitHubReview(review, prID); comment != nil {
+				comments = append(comments, comment)
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	return comments, nil
+}
+
+// reviewThreadsQuery lists a PR's review threads with the database ID of each
+// thread's first comment, which links them to the REST review comments
+const reviewThreadsQuery = `query($owner: String!, $repo: String!, $number: Int!, $cursor: String) {
+  repository(owner: $owner, name: $repo) {
+    pullRequest(number: $number) {
+      reviewThreads(first: 100, after: $cursor) {
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          id
+          isResolved
+          isOutdated
+          line
+          startLine
+          comments(first: 1) { nodes { databaseId } }
+        }
+      }
+    }
+  }
+}`
+
+// GetReviewThreadStates fetches the resolution state of a PR's review threads,
+// keyed by the ID of each thread's first comment
+func (c *Client) GetReviewThreadStates(ctx context.Context, owner, repo string, number int) (map[int64]models.ThreadState, error) {
+	var result struct {
+		Repository struct {
+			PullRequest struct {
+				ReviewThreads struct {
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []struct {
+						ID         string `json:"id"`
+						IsResolved bool   `json:"isResolved"`
+						IsOutdated bool   `json:"isOutdated"`
+						Line       int    `json:"line"`
+						StartLine  int    `json:"startLine"`
+						Comments   struct {
+							Nodes []struct {
+								DatabaseID int64 `json:"databaseId"`
+							} `json:"nodes"`
+						} `json:"comments"`
+					} `json:"nodes"`
+				} `json:"reviewThreads"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	}
+
+	states := make(map[int64]models.ThreadState)
+	variables := map[string]interface{}{"owner": owner, "repo": repo, "number": number}
+	for {
+		if err := c.graphQL(ctx, reviewThreadsQuery, variables, &result); err != nil {
+			return nil, fmt.Errorf("failed to get review threads for %s/%s#%d: %w", owner, repo, number, err)
+		}
+
+		threads := result.Repository.PullRequest.ReviewThreads
+		for _, node := range threads.Nodes {
+			if len(node.Comments.Nodes) == 0 {
+				continue
+			}
+			states[node.Comments.Nodes[0].DatabaseID] = models.ThreadState{
+				NodeID:    node.ID,
+				Resolved:  node.IsResolved,
+				Outdated:  node.IsOutdated,
+				StartLine: node.StartLine,
+				Line:      node.Line,
+			}
+		}
+
+		if !threads.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = threads.PageInfo.EndCursor
+	}
+
+	return states, nil
+}
+
+// GetThreads fetches all comments and reviews of a pull request and groups them
+// into threads with their resolution state
+func (c *Client) GetThreads(ctx context.Context, owner, repo string, number int, prID int64) ([]*models.Thread, error) {
+	issueComments, err := c.GetIssueComments(ctx, owner, repo, number, prID)
+	if err != nil {
+		return nil, err
+	}
+	reviewComments, err := c.GetReviewComments(ctx, owner, repo, number, prID)
+	if err != nil {
+		return nil, err
+	}
+	reviews, err := c.GetReviews(ctx, owner, repo, number, prID)
+	if err != nil {
+		return nil, err
+	}
+	states, err := c.GetReviewThreadStates(ctx, owner, repo, number)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := append(append(issueComments, reviewComments...), reviews...)
+	return models.GroupThreads(comments, states), nil
+}
+
+// GetActivity returns the unresolved review threads and comment count of
+// several pull requests in one GraphQL call, keyed by PR number
+func (c *Client) GetActivity(ctx context.Context, owner, repo string, numbers []int) (map[int]*models.Activity, error) {
+	if len(numbers) == 0 {
+		return map[int]*models.Activity{}, nil
+	}
+
+	// One aliased pullRequest field per PR
+	var fields strings.Builder
+	for _, number := range numbers {
+		fmt.Fprintf(&fields, "pr%d: pullRequest(number: %d) { comments { totalCount } reviewThreads(first: 100) { nodes { isResolved comments { totalCount } } } }\n", number, number)
+	}
+	query := fmt.Sprintf("query($owner: String!, $repo: String!) { repository(owner: $owner, name: $repo) { %s } }", fields.String())
+
+	type count struct {
+		TotalCount int `json:"totalCount"`
+	}
+	var result struct {
+		Repository map[string]struct {
+			Comments      count `json:"comments"`
+			ReviewThreads struct {
+				Nodes []struct {
+					IsResolved bool  `json:"isResolved"`
+					Comments   count `json:"comments"`
+				} `json:"nodes"`
+			} `json:"reviewThreads"`
+		} `json:"repository"`
+	}
+	if err := c.graphQL(ctx, query, map[string]interface{}{"owner": owner, "repo": repo}, &result); err != nil {
+		return nil, fmt.Errorf("failed to load activity for %s/%s: %w", owner, repo, err)
+	}
+
+	activity := make(map[int]*models.Activity, len(numbers))
+	for _, number := range numbers {
+		pr := result.Repository[fmt.Sprintf("pr%d", number)]
+		a := &models.Activity{Comments: pr.Comments.TotalCount}
+		for _, thread := range pr.ReviewThreads.Nodes {
+			a.Comments += thread.Comments.TotalCount
+			if !thread.IsResolved {
+				a.UnresolvedThreads++
+			}
+		}
+		activity[number] = a
+	}
+	return activity, nil
+}
+
+// CreateComment posts a top-level comment on a pull request
+func (c *Client) CreateComment(ctx context.Context, owner, repo string, number int, body string) error {
+	_, _, err := c.client.Issues.CreateComment(ctx, owner, repo, number, &github.IssueComment{Body: github.String(body)})
+	if err != nil {
+		return fmt.Errorf("failed to comment on %s/%s#%d: %w", owner, repo, number, err)
+	}
+	return nil
+}
+
+// ReplyToReviewComment posts a reply in the review thread started by commentID
+func (c *Client) ReplyToReviewComment(ctx context.Context, owner, repo string, number int, commentID int64, body string) error {
+	_, _, err := c.client.PullRequests.CreateCommentInReplyTo(ctx, owner, repo, number, body, commentID)
+	if err != nil {
+		return fmt.Errorf("failed to reply on %s/%s#%d: %w", owner, repo, number, err)
+	}
+	return nil
+}
+
+// SubmitReview submits a review with an event (COMMENT, APPROVE or REQUEST_CHANGES)
+func (c *Client) SubmitReview(ctx context.Context, owner, repo string, number int, event, body string) error {
+	review := &github.PullRequestReviewRequest{Event: github.String(event)}
+	if body != "" {
+		review.Body = github.String(body)
+	}
+	if _, _, err := c.client.PullRequests.CreateReview(ctx, owner, repo, number, review); err != nil {
+		return fmt.Errorf("failed to submit review on %s/%s#%d: %w", owner, repo, number, err)
+	}
+	return nil
+}
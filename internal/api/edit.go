@@ -0,0 +1,183 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v58/github"
+	"github.com/will-wright-eng/gh-nav/internal/models"
+)
+
+// GetLabels lists the labels defined in a repository
+func (c *Client) GetLabels(ctx context.Context, owner, repo string) ([]string, error) {
+	opt := &github.ListOptions{PerPage: 100}
+
+	var labels []string
+	for {
+		page, resp, err := c.client.Issues.ListLabels(ctx, owner, repo, opt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list labels for %s/%s: %w", owner, repo, err)
+		}
+
+		for _, label := range page {
+			labels = append(labels, label.GetName())
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	return labels, nil
+}
+
+// GetAssignableUsers lists the users that can be assigned to issues and pull
+// requests in a repository
+func (c *Client) GetAssignableUsers(ctx context.Context, owner, repo string) ([]string, error) {
+	opt := &github.ListOptions{PerPage: 100}
+
+	var users []string
+	for {
+		page, resp, err := c.client.Issues.ListAssignees(ctx, owner, repo, opt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list assignable users for %s/%s: %w", owner, repo, err)
+		}
+
+		for _, user := range page {
+			users = append(users, user.GetLogin())
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	return users, nil
+}
+
+// GetTeams lists the teams with access to a repository as org/team-slug. Listing
+// teams needs more access than reading the repository, so callers may treat a
+// failure as "no teams".
+func (c *Client) GetTeams(ctx context.Context, owner, repo string) ([]string, error) {
+	opt := &github.ListOptions{PerPage: 100}
+
+	var teams []string
+	for {
+		page, resp, err := c.client.Repositories.ListTeams(ctx, owner, repo, opt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list teams for %s/%s: %w", owner, repo, err)
+		}
+
+		for _, team := range page {
+			teams = append(teams, owner+"/"+team.GetSlug())
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	return teams, nil
+}
+
+// GetFieldOptions lists the values an editable pull request field can take.
+// Reviewers are the assignable users plus the repository's teams, if visible.
+func (c *Client) GetFieldOptions(ctx context.Context, owner, repo, field string) ([]string, error) {
+	switch field {
+	case models.EditLabels:
+		return c.GetLabels(ctx, owner, repo)
+	case models.EditAssignees:
+		return c.GetAssignableUsers(ctx, owner, repo)
+	case models.EditReviewers:
+		users, err := c.GetAssignableUsers(ctx, owner, repo)
+		if err != nil {
+			return nil, err
+		}
+		if teams, err := c.GetTeams(ctx, owner, repo); err == nil {
+			users = append(users, teams...)
+		}
+		return users, nil
+	}
+	return nil, fmt.Errorf("unknown field %q", field)
+}
+
+// UpdateField adds and removes values of a pull request's labels, assignees or
+// requested reviewers
+func (c *Client) UpdateField(ctx context.Context, owner, repo string, number int, field string, add, remove []string) error {
+	var err error
+	switch field {
+	case models.EditLabels:
+		err = c.updateLabels(ctx, owner, repo, number, add, remove)
+	case models.EditAssignees:
+		err = c.updateAssignees(ctx, owner, repo, number, add, remove)
+	case models.EditReviewers:
+		err = c.updateReviewers(ctx, owner, repo, number, add, remove)
+	default:
+		err = fmt.Errorf("unknown field %q", field)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update %s of %s/%s#%d: %w", field, owner, repo, number, err)
+	}
+	return nil
+}
+
+// updateLabels adds labels in one call and removes them one at a time, as the API requires
+func (c *Client) updateLabels(ctx context.Context, owner, repo string, number int, add, remove []string) error {
+	if len(add) > 0 {
+		if _, _, err := c.client.Issues.AddLabelsToIssue(ctx, owner, repo, number, add); err != nil {
+			return err
+		}
+	}
+	for _, label := range remove {
+		if _, err := c.client.Issues.RemoveLabelForIssue(ctx, owner, repo, number, label); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) updateAssignees(ctx context.Context, owner, repo string, number int, add, remove []string) error {
+	if len(add) > 0 {
+		if _, _, err := c.client.Issues.AddAssignees(ctx, owner, repo, number, add); err != nil {
+			return err
+		}
+	}
+	if len(remove) > 0 {
+		if _, _, err := c.client.Issues.RemoveAssignees(ctx, owner, repo, number, remove); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) updateReviewers(ctx context.Context, owner, repo string, number int, add, remove []string) error {
+	if len(add) > 0 {
+		if _, _, err := c.client.PullRequests.RequestReviewers(ctx, owner, repo, number, reviewersRequest(add)); err != nil {
+			return err
+		}
+	}
+	if len(remove) > 0 {
+		if _, err := c.client.PullRequests.RemoveReviewers(ctx, owner, repo, number, reviewersRequest(remove)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reviewersRequest splits reviewers into user logins and team slugs
+func reviewersRequest(reviewers []string) github.ReviewersRequest {
+	var req github.ReviewersRequest
+	for _, reviewer := range reviewers {
+		if models.IsTeamReviewer(reviewer) {
+			_, slug, _ := strings.Cut(reviewer, "/")
+			req.TeamReviewers = append(req.TeamReviewers, slug)
+		} else {
+			req.Reviewers = append(req.Reviewers, reviewer)
+		}
+	}
+	return req
+}
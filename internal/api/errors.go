@@ -0,0 +1,28 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/go-github/v58/github"
+)
+
+// IsAuthError reports whether err is GitHub rejecting the credentials
+func IsAuthError(err error) bool {
+	return statusCode(err) == http.StatusUnauthorized
+}
+
+// IsNotFound reports whether err is a 404 from GitHub (which it also returns for
+// private resources the token can't see)
+func IsNotFound(err error) bool {
+	return statusCode(err) == http.StatusNotFound
+}
+
+// statusCode extracts the HTTP status from a go-github error, or 0
+func statusCode(err error) int {
+	var errResp *github.ErrorResponse
+	if errors.As(err, &errResp) && errResp.Response != nil {
+		return errResp.Response.StatusCode
+	}
+	return 0
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v58/github"
+	"github.com/will-wright-eng/gh-nav/internal/models"
+)
+
+// GetPullRequestFiles fetches the files changed by a pull request with their patches.
+// GitHub returns at most 3000 files.
+func (c *Client) GetPullRequestFiles(ctx context.Context, owner, repo string, number int) ([]*models.FileChange, error) {
+	opt := &github.ListOptions{PerPage: 100}
+
+	var files []*models.FileChange
+	for {
+		page, resp, err := c.client.PullRequests.ListFiles(ctx, owner, repo, number, opt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list files for %s/%s#%d: %w", owner, repo, number, err)
+		}
+
+		for _, file := range page {
+			files = append(files, models.FromGitHubCommitFile(file))
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	return files, nil
+}
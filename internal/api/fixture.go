@@ -0,0 +1,246 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// redacted replaces the token wherever it appears in a recording
+const redacted = "REDACTED"
+
+// errNotRecorded is returned when a replayed fixture has no response for a request
+var errNotRecorded = errors.New("no recorded response")
+
+// fixture is a recording of API traffic, as written by --record and read by --replay
+type fixture struct {
+	Interactions []*interaction `json:"interactions"`
+}
+
+// interaction is one recorded request and its response. URLs on the API host
+// are stored without the host, so a recording replays against another server
+// with the same layout (e.g. a fake GitHub on a different port).
+type interaction struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+
+	Status         int         `json:"status"`
+	ResponseHeader http.Header `json:"response_header"`
+	ResponseBody   string      `json:"response_body"`
+}
+
+// key identifies the request an interaction answers
+func (i *interaction) key() string {
+	return i.Method + " " + i.URL + "\n" + i.Body
+}
+
+// sanitizer strips credentials from recorded traffic: the token anywhere it
+// appears, and the query of URLs on other hosts, which for downloads is a
+// signature granting access
+type sanitizer struct {
+	apiHost string
+	token   string
+}
+
+func (s sanitizer) url(u *url.URL) string {
+	if u.Host == "" || u.Host == s.apiHost {
+		return (&url.URL{Path: u.Path, RawQuery: u.RawQuery}).String()
+	}
+	return (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path}).String()
+}
+
+func (s sanitizer) text(text string) string {
+	if s.token == "" {
+		return text
+	}
+	return strings.ReplaceAll(text, s.token, redacted)
+}
+
+func (s sanitizer) header(h http.Header) http.Header {
+	out := make(http.Header, len(h))
+	for name, values := range h {
+		if name == "Set-Cookie" {
+			continue
+		}
+		for _, v := range values {
+			out.Add(name, s.text(v))
+		}
+	}
+	if location, err := url.Parse(h.Get("Location")); err == nil && location.String() != "" {
+		out.Set("Location", s.url(location))
+	}
+	return out
+}
+
+// request records the parts of req a replay matches on. The body is read and
+// put back so the request can still be sent.
+func (s sanitizer) request(req *http.Request) (*http.Request, *interaction, error) {
+	rec := &interaction{Method: req.Method, URL: s.url(req.URL)}
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, rec, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	rec.Body = s.text(string(body))
+	return req, rec, nil
+}
+
+// recordTransport writes every request and response that passes through it to
+// a fixture file, sanitized. The file is rewritten after each response, so it's
+// complete however the program exits.
+type recordTransport struct {
+	next     http.RoundTripper
+	path     string
+	sanitize sanitizer
+
+	mu      sync.Mutex
+	fixture fixture
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req, rec, err := t.sanitize.request(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	rec.Status = resp.StatusCode
+	rec.ResponseHeader = t.sanitize.header(resp.Header)
+	rec.ResponseBody = t.sanitize.text(string(body))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.fixture.Interactions = append(t.fixture.Interactions, rec)
+	if err := writeFixture(t.path, &t.fixture); err != nil {
+		return nil, fmt.Errorf("failed to record %s %s: %w", req.Method, req.URL.Path, err)
+	}
+	return resp, nil
+}
+
+// writeFixture writes a fixture through a temporary file, so a crash never
+// leaves half a recording
+func writeFixture(path string, f *fixture) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".fixture-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// replayTransport answers requests from a fixture without touching the network.
+// Repeated requests get the recorded responses in order, then the last one
+// again; a request that was never recorded fails with errNotRecorded.
+type replayTransport struct {
+	sanitize sanitizer
+	loadErr  error // every request fails with it when the fixture can't be read
+
+	mu        sync.Mutex
+	responses map[string][]*interaction
+}
+
+// newReplayTransport loads the fixture at path
+func newReplayTransport(path string, sanitize sanitizer) *replayTransport {
+	t := &replayTransport{sanitize: sanitize, responses: make(map[string][]*interaction)}
+	f, err := readFixture(path)
+	if err != nil {
+		t.loadErr = err
+		return t
+	}
+	for _, rec := range f.Interactions {
+		t.responses[rec.key()] = append(t.responses[rec.key()], rec)
+	}
+	return t
+}
+
+// readFixture reads a fixture written by a recordTransport
+func readFixture(path string) (*fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("invalid fixture %s: %w", path, err)
+	}
+	return &f, nil
+}
+
+// CheckFixture reports whether path holds a fixture that can be replayed
+func CheckFixture(path string) error {
+	_, err := readFixture(path)
+	return err
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	_, rec, err := t.sanitize.request(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.loadErr != nil {
+		return nil, fmt.Errorf("%w for %s %s: %v", errNotRecorded, rec.Method, rec.URL, t.loadErr)
+	}
+
+	t.mu.Lock()
+	queue := t.responses[rec.key()]
+	if len(queue) > 1 {
+		t.responses[rec.key()] = queue[1:]
+	}
+	t.mu.Unlock()
+	if len(queue) == 0 {
+		return nil, fmt.Errorf("%w for %s %s", errNotRecorded, rec.Method, rec.URL)
+	}
+
+	recorded := queue[0]
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		StatusCode:    recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.ResponseHeader.Clone(),
+		Body:          io.NopCloser(strings.NewReader(recorded.ResponseBody)),
+		ContentLength: int64(len(recorded.ResponseBody)),
+		Request:       req,
+	}, nil
+}
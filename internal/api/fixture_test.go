@@ -0,0 +1,98 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/will-wright-eng/gh-nav/internal/testing/fakegh"
+	"github.com/will-wright-eng/gh-nav/pkg/config"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	server := fakegh.NewServer(fakegh.Demo())
+	defer server.Close()
+	server.RequireToken("secret-token")
+	path := filepath.Join(t.TempDir(), "fixture.json")
+
+	recorder := NewClient(&config.Config{
+		GitHub: config.GitHubConfig{Token: "secret-token", BaseURL: server.URL + "/"},
+		Debug:  config.DebugConfig{RecordFile: path},
+	})
+	ctx := context.Background()
+	pr, err := recorder.GetPullRequest(ctx, "acme", "api", 142)
+	if err != nil {
+		t.Fatalf("GetPullRequest failed: %v", err)
+	}
+	threads, err := recorder.GetThreads(ctx, "acme", "api", 142, pr.GetID())
+	if err != nil {
+		t.Fatalf("GetThreads failed: %v", err)
+	}
+	server.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read the recording: %v", err)
+	}
+	if strings.Contains(string(data), "secret-token") {
+		t.Error("Expected the token to be stripped from the recording")
+	}
+
+	// Replays don't need the original host or token
+	replayer := NewClient(&config.Config{
+		GitHub: config.GitHubConfig{BaseURL: "http://127.0.0.1:1/"},
+		Debug:  config.DebugConfig{ReplayFile: path},
+	})
+	replayed, err := replayer.GetPullRequest(ctx, "acme", "api", 142)
+	if err != nil {
+		t.Fatalf("Replaying GetPullRequest failed: %v", err)
+	}
+	if replayed.GetTitle() != pr.GetTitle() {
+		t.Errorf("Expected title %q, got %q", pr.GetTitle(), replayed.GetTitle())
+	}
+	replayedThreads, err := replayer.GetThreads(ctx, "acme", "api", 142, pr.GetID())
+	if err != nil {
+		t.Fatalf("Replaying GetThreads failed: %v", err)
+	}
+	if len(replayedThreads) != len(threads) {
+		t.Errorf("Expected %d threads, got %d", len(threads), len(replayedThreads))
+	}
+
+	_, err = replayer.GetPullRequest(ctx, "acme", "api", 139)
+	if !errors.Is(err, errNotRecorded) {
+		t.Errorf("Expected a request that wasn't recorded to fail, got %v", err)
+	}
+}
+
+func TestSanitizer(t *testing.T) {
+	s := sanitizer{apiHost: "api.github.com", token: "ghp_secret"}
+
+	download, _ := url.Parse("https://blob.example.com/logs/1.txt?sig=abc&se=2024")
+	if got := s.url(download); got != "https://blob.example.com/logs/1.txt" {
+		t.Errorf("Expected the download signature to be dropped, got %s", got)
+	}
+	api, _ := url.Parse("https://api.github.com/repos/o/r/pulls?page=2")
+	if got := s.url(api); got != "/repos/o/r/pulls?page=2" {
+		t.Errorf("Expected an API URL to keep its path and query, got %s", got)
+	}
+
+	header := s.header(http.Header{
+		"Set-Cookie":     {"session=1"},
+		"Location":       {"https://blob.example.com/logs/1.txt?sig=abc"},
+		"X-Echoed-Token": {"token ghp_secret"},
+	})
+	if header.Get("Set-Cookie") != "" {
+		t.Error("Expected cookies to be dropped")
+	}
+	if got := header.Get("Location"); got != "https://blob.example.com/logs/1.txt" {
+		t.Errorf("Expected the redirect signature to be dropped, got %s", got)
+	}
+	if got := header.Get("X-Echoed-Token"); got != "token "+redacted {
+		t.Errorf("Expected the token to be redacted, got %s", got)
+	}
+}
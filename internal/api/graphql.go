@@ -0,0 +1,60 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// graphQLRequest is the body of a GraphQL API call
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// graphQLResponse is the envelope of a GraphQL API response
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// graphQLURL returns the GraphQL endpoint for the configured REST base URL:
+// https://api.github.com/graphql, or https://HOST/api/graphql on GHES
+func (c *Client) graphQLURL() string {
+	base := c.client.BaseURL.String()
+	if strings.HasSuffix(base, "/api/v3/") {
+		return strings.TrimSuffix(base, "v3/") + "graphql"
+	}
+	return base + "graphql"
+}
+
+// graphQL runs a GraphQL query or mutation through the REST client (so it shares
+// authentication and transports) and decodes the data into result
+func (c *Client) graphQL(ctx context.Context, query string, variables map[string]interface{}, result interface{}) error {
+	req, err := c.client.NewRequest("POST", c.graphQLURL(), graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return err
+	}
+
+	var resp graphQLResponse
+	if _, err := c.client.Do(ctx, req, &resp); err != nil {
+		return err
+	}
+
+	// GraphQL reports failures in the body with a 200 status
+	if len(resp.Errors) > 0 {
+		messages := make([]string, len(resp.Errors))
+		for i, e := range resp.Errors {
+			messages[i] = e.Message
+		}
+		return fmt.Errorf("GraphQL: %s", strings.Join(messages, "; "))
+	}
+
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(resp.Data, result)
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v58/github"
+)
+
+// ClosePullRequest closes a pull request without merging it
+func (c *Client) ClosePullRequest(ctx context.Context, owner, repo string, number int) error {
+	update := &github.PullRequest{State: github.String("closed")}
+	if _, _, err := c.client.PullRequests.Edit(ctx, owner, repo, number, update); err != nil {
+		return fmt.Errorf("failed to close %s/%s#%d: %w", owner, repo, number, err)
+	}
+	return nil
+}
+
+// ReopenPullRequest reopens a closed pull request
+func (c *Client) ReopenPullRequest(ctx context.Context, owner, repo string, number int) error {
+	update := &github.PullRequest{State: github.String("open")}
+	if _, _, err := c.client.PullRequests.Edit(ctx, owner, repo, number, update); err != nil {
+		return fmt.Errorf("failed to reopen %s/%s#%d: %w", owner, repo, number, err)
+	}
+	return nil
+}
+
+// convertToDraftMutation turns a pull request back into a draft; REST has no equivalent
+const convertToDraftMutation = `mutation($id: ID!) {
+  convertPullRequestToDraft(input: {pullRequestId: $id}) { clientMutationId }
+}`
+
+// markReadyMutation takes a pull request out of draft; REST has no equivalent
+const markReadyMutation = `mutation($id: ID!) {
+  markPullRequestReadyForReview(input: {pullRequestId: $id}) { clientMutationId }
+}`
+
+// SetDraft converts a pull request to a draft, or marks it ready for review.
+// nodeID is the pull request's GraphQL ID.
+func (c *Client) SetDraft(ctx context.Context, nodeID string, draft bool) error {
+	mutation, action := markReadyMutation, "mark ready for review"
+	if draft {
+		mutation, action = convertToDraftMutation, "convert to draft"
+	}
+	if err := c.graphQL(ctx, mutation, map[string]interface{}{"id": nodeID}, nil); err != nil {
+		return fmt.Errorf("failed to %s: %w", action, err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/will-wright-eng/gh-nav/internal/models"
+)
+
+// maxLogSize caps how much of a job log is read into memory
+const maxLogSize = 32 << 20
+
+// GetJobLog fetches an Actions job with its step results and downloads its log
+func (c *Client) GetJobLog(ctx context.Context, owner, repo string, jobID int64) (*models.JobLog, error) {
+	job, _, err := c.client.Actions.GetWorkflowJobByID(ctx, owner, repo, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job %d: %w", jobID, err)
+	}
+
+	// The logs endpoint redirects to a short-lived download URL
+	logURL, _, err := c.client.Actions.GetWorkflowJobLogs(ctx, owner, repo, jobID, 2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get log location for job %d: %w", jobID, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, logURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	// The download URL is pre-signed, so it's fetched without the API token
+	resp, err := c.download.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download log for job %d: %w", jobID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download log for job %d: %s", jobID, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxLogSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log for job %d: %w", jobID, err)
+	}
+
+	log := models.FromGitHubJob(job)
+	log.Lines = models.ParseJobLog(string(data))
+	return log, nil
+}
+
+// RerunFailedJobs re-runs the failed jobs of the workflow run the given job belongs to
+func (c *Client) RerunFailedJobs(ctx context.Context, owner, repo string, jobID int64) error {
+	job, _, err := c.client.Actions.GetWorkflowJobByID(ctx, owner, repo, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to get job %d: %w", jobID, err)
+	}
+
+	if _, err := c.client.Actions.RerunFailedJobsByID(ctx, owner, repo, job.GetRunID()); err != nil {
+		return fmt.Errorf("failed to re-run workflow run %d: %w", job.GetRunID(), err)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v58/github"
+	"github.com/will-wright-eng/gh-nav/internal/models"
+)
+
+// mergeInfoQuery fetches the repository's merge settings together with the
+// mergeability GitHub computed for the pull request
+const mergeInfoQuery = `query($owner: String!, $repo: String!, $number: Int!) {
+  repository(owner: $owner, name: $repo) {
+    mergeCommitAllowed
+    squashMergeAllowed
+    rebaseMergeAllowed
+    autoMergeAllowed
+    deleteBranchOnMerge
+    pullRequest(number: $number) {
+      id
+      title
+      body
+      state
+      isDraft
+      headRefOid
+      mergeable
+      mergeStateStatus
+      reviewDecision
+      autoMergeRequest { enabledAt }
+      commits(last: 1) { nodes { commit { statusCheckRollup { state } } } }
+    }
+  }
+}`
+
+// GetMergeInfo fetches what's needed to offer and validate a merge
+func (c *Client) GetMergeInfo(ctx context.Context, owner, repo string, number int) (*models.MergeInfo, error) {
+	var result struct {
+		Repository struct {
+			MergeCommitAllowed  bool `json:"mergeCommitAllowed"`
+			SquashMergeAllowed  bool `json:"squashMergeAllowed"`
+			RebaseMergeAllowed  bool `json:"rebaseMergeAllowed"`
+			AutoMergeAllowed    bool `json:"autoMergeAllowed"`
+			DeleteBranchOnMerge bool `json:"deleteBranchOnMerge"`
+			PullRequest         struct {
+				ID               string  `json:"id"`
+				Title            string  `json:"title"`
+				Body             string  `json:"body"`
+				State            string  `json:"state"`
+				IsDraft          bool    `json:"isDraft"`
+				HeadRefOid       string  `json:"headRefOid"`
+				Mergeable        string  `json:"mergeable"`
+				MergeStateStatus string  `json:"mergeStateStatus"`
+				ReviewDecision   *string `json:"reviewDecision"`
+				AutoMergeRequest *struct {
+					EnabledAt string `json:"enabledAt"`
+				} `json:"autoMergeRequest"`
+				Commits struct {
+					Nodes []struct {
+						Commit struct {
+							StatusCheckRollup *struct {
+								State string `json:"state"`
+							} `json:"statusCheckRollup"`
+						} `json:"commit"`
+					} `json:"nodes"`
+				} `json:"commits"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	}
+
+	variables := map[string]interface{}{"owner": owner, "repo": repo, "number": number}
+	if err := c.graphQL(ctx, mergeInfoQuery, variables, &result); err != nil {
+		return nil, fmt.Errorf("failed to get merge status of %s/%s#%d: %w", owner, repo, number, err)
+	}
+
+	r := result.Repository
+	pr := r.PullRequest
+	info := &models.MergeInfo{
+		NodeID:              pr.ID,
+		Title:               pr.Title,
+		Body:                pr.Body,
+		HeadSHA:             pr.HeadRefOid,
+		State:               pr.State,
+		IsDraft:             pr.IsDraft,
+		AutoMergeAllowed:    r.AutoMergeAllowed,
+		AutoMergeEnabled:    pr.AutoMergeRequest != nil,
+		DeleteBranchOnMerge: r.DeleteBranchOnMerge,
+		Mergeable:           pr.Mergeable,
+		MergeStateStatus:    pr.MergeStateStatus,
+	}
+	if r.MergeCommitAllowed {
+		info.Methods = append(info.Methods, models.MergeMethodMerge)
+	}
+	if r.SquashMergeAllowed {
+		info.Methods = append(info.Methods, models.MergeMethodSquash)
+	}
+	if r.RebaseMergeAllowed {
+		info.Methods = append(info.Methods, models.MergeMethodRebase)
+	}
+	if pr.ReviewDecision != nil {
+		info.ReviewDecision = *pr.ReviewDecision
+	}
+	if nodes := pr.Commits.Nodes; len(nodes) > 0 && nodes[0].Commit.StatusCheckRollup != nil {
+		info.ChecksState = nodes[0].Commit.StatusCheckRollup.State
+	}
+	return info, nil
+}
+
+// MergePullRequest merges a pull request now. headSHA guards against merging
+// commits pushed after the user looked at the PR.
+func (c *Client) MergePullRequest(ctx context.Context, owner, repo string, number int, headSHA string, req models.MergeRequest) error {
+	opts := &github.PullRequestOptions{
+		MergeMethod: req.Method,
+		SHA:         headSHA,
+	}
+	message := ""
+	if req.Method != models.MergeMethodRebase {
+		opts.CommitTitle = req.Title
+		message = req.Body
+	}
+
+	if _, _, err := c.client.PullRequests.Merge(ctx, owner, repo, number, message, opts); err != nil {
+		return fmt.Errorf("failed to merge %s/%s#%d: %w", owner, repo, number, err)
+	}
+	return nil
+}
+
+// enableAutoMergeMutation turns on auto-merge for a pull request
+const enableAutoMergeMutation = `mutation($id: ID!, $method: PullRequestMergeMethod!, $headline: String, $body: String) {
+  enablePullRequestAutoMerge(input: {pullRequestId: $id, mergeMethod: $method, commitHeadline: $headline, commitBody: $body}) {
+    clientMutationId
+  }
+}`
+
+// EnableAutoMerge asks GitHub to merge a pull request once its requirements are met
+func (c *Client) EnableAutoMerge(ctx context.Context, nodeID string, req models.MergeRequest) error {
+	variables := map[string]interface{}{
+		"id":     nodeID,
+		"method": strings.ToUpper(req.Method),
+	}
+	if req.Method != models.MergeMethodRebase {
+		variables["headline"] = req.Title
+		variables["body"] = req.Body
+	}
+
+	if err := c.graphQL(ctx, enableAutoMergeMutation, variables, nil); err != nil {
+		return fmt.Errorf("failed to enable auto-merge: %w", err)
+	}
+	return nil
+}
+
+// DeleteBranch deletes a branch, e.g. a pull request's head after merging
+func (c *Client) DeleteBranch(ctx context.Context, owner, repo, branch string) error {
+	if _, err := c.client.Git.DeleteRef(ctx, owner, repo, "heads/"+branch); err != nil {
+		return fmt.Errorf("failed to delete branch %s: %w", branch, err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+// ProbeInterval is how often requests try the network again while offline.
+// Callers checking whether GitHub is back gain nothing by asking more often.
+const ProbeInterval = 30 * time.Second
+
+// ErrOffline is returned for requests that need the network while GitHub is
+// unreachable (or --offline is set) and have no cached response
+var ErrOffline = errors.New("offline")
+
+// IsOffline reports whether err is a request failing because GitHub is unreachable
+func IsOffline(err error) bool {
+	return errors.Is(err, ErrOffline)
+}
+
+// connectivity tracks whether GitHub is reachable. A nil connectivity is
+// always online.
+type connectivity struct {
+	mu        sync.Mutex
+	forced    bool      // --offline: never touch the network
+	offline   bool      // the last attempt to reach GitHub failed
+	lastProbe time.Time // when a request last tried the network while offline
+}
+
+// isOffline reports whether requests are being answered from the cache
+func (c *connectivity) isOffline() bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.forced || c.offline
+}
+
+// shouldTry reports whether a request should go to the network. While offline,
+// one request per ProbeInterval does, to notice when GitHub is back.
+func (c *connectivity) shouldTry(now time.Time) bool {
+	if c == nil {
+		return true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch {
+	case c.forced:
+		return false
+	case !c.offline:
+		return true
+	case now.Sub(c.lastProbe) >= ProbeInterval:
+		c.lastProbe = now
+		return true
+	}
+	return false
+}
+
+// observe records the outcome of a network attempt. Only failures to reach
+// GitHub count; a cancelled request says nothing about the network.
+func (c *connectivity) observe(ctx context.Context, err error, now time.Time) {
+	if c == nil || (err != nil && !isNetworkError(ctx, err)) {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.offline = err != nil
+	if c.offline {
+		c.lastProbe = now
+	}
+}
+
+// isNetworkError reports whether err means GitHub couldn't be reached
+func isNetworkError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// Staleness records the age of cached responses served in place of GitHub's
+// while offline, for the requests made with its context
+type Staleness struct {
+	mu     sync.Mutex
+	oldest time.Time
+}
+
+type stalenessKey struct{}
+
+// WithStaleness returns a context whose requests report cached responses to
+// the returned Staleness
+func WithStaleness(ctx context.Context) (context.Context, *Staleness) {
+	s := &Staleness{}
+	return context.WithValue(ctx, stalenessKey{}, s), s
+}
+
+// Oldest returns when the oldest cached response served was stored, or the
+// zero time if every response came from GitHub
+func (s *Staleness) Oldest() time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.oldest
+}
+
+// record notes that a response stored at storedAt was served for a request
+// made with ctx
+func record(ctx context.Context, storedAt time.Time) {
+	s, _ := ctx.Value(stalenessKey{}).(*Staleness)
+	if s == nil || storedAt.IsZero() {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.oldest.IsZero() || storedAt.Before(s.oldest) {
+		s.oldest = storedAt
+	}
+}
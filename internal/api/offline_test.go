@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/will-wright-eng/gh-nav/internal/testing/fakegh"
+	"github.com/will-wright-eng/gh-nav/pkg/config"
+)
+
+func TestOfflineServesFromCache(t *testing.T) {
+	server := fakegh.NewServer(fakegh.Demo())
+	defer server.Close()
+	cfg := &config.Config{
+		GitHub: config.GitHubConfig{Token: "token", BaseURL: server.URL + "/"},
+		Cache:  config.CacheConfig{Enabled: true, Dir: t.TempDir()},
+	}
+
+	client := NewClient(cfg)
+	ctx := context.Background()
+	pr, err := client.GetPullRequest(ctx, "acme", "api", 142)
+	if err != nil {
+		t.Fatalf("GetPullRequest failed: %v", err)
+	}
+	if client.Offline() {
+		t.Error("Expected the client to be online")
+	}
+	server.Close()
+
+	ctx, age := WithStaleness(ctx)
+	cached, err := client.GetPullRequest(ctx, "acme", "api", 142)
+	if err != nil {
+		t.Fatalf("Expected the cached PR once GitHub is unreachable, got %v", err)
+	}
+	if cached.GetTitle() != pr.GetTitle() {
+		t.Errorf("Expected title %q, got %q", pr.GetTitle(), cached.GetTitle())
+	}
+	if !client.Offline() {
+		t.Error("Expected the client to notice it is offline")
+	}
+	if age.Oldest().IsZero() || time.Since(age.Oldest()) > time.Minute {
+		t.Errorf("Expected the age of the cached response, got %v", age.Oldest())
+	}
+
+	if _, err := client.GetPullRequest(ctx, "acme", "api", 139); !IsOffline(err) {
+		t.Errorf("Expected a PR that isn't cached to fail with ErrOffline, got %v", err)
+	}
+	if err := client.CreateComment(ctx, "acme", "api", 142, "hello"); !IsOffline(err) {
+		t.Errorf("Expected a write to fail with ErrOffline, got %v", err)
+	}
+}
+
+func TestForcedOfflineNeverTriesGitHub(t *testing.T) {
+	server := fakegh.NewServer(fakegh.Demo())
+	defer server.Close()
+	cfg := &config.Config{
+		GitHub: config.GitHubConfig{Token: "token", BaseURL: server.URL + "/"},
+		Cache:  config.CacheConfig{Enabled: true, Dir: t.TempDir()},
+	}
+	ctx := context.Background()
+	if _, err := NewClient(cfg).GetPullRequest(ctx, "acme", "api", 142); err != nil {
+		t.Fatalf("GetPullRequest failed: %v", err)
+	}
+
+	cfg.Cache.Offline = true
+	client := NewClient(cfg)
+	if !client.Offline() {
+		t.Error("Expected --offline to start offline")
+	}
+	ctx, age := WithStaleness(ctx)
+	if _, err := client.GetPullRequest(ctx, "acme", "api", 142); err != nil {
+		t.Errorf("Expected the cached PR, got %v", err)
+	}
+	if age.Oldest().IsZero() {
+		t.Error("Expected the response to be reported as cached")
+	}
+	if _, err := client.GetPullRequest(ctx, "acme", "api", 139); !IsOffline(err) {
+		t.Errorf("Expected ErrOffline although GitHub is reachable, got %v", err)
+	}
+}
+
+func TestConnectivityProbes(t *testing.T) {
+	var c connectivity
+	now := time.Unix(1000, 0)
+	c.offline, c.lastProbe = true, now
+
+	if c.shouldTry(now.Add(time.Second)) {
+		t.Error("Expected no request to reach the network right after going offline")
+	}
+	if !c.shouldTry(now.Add(ProbeInterval)) {
+		t.Error("Expected a probe once the interval has passed")
+	}
+	if c.shouldTry(now.Add(ProbeInterval + time.Second)) {
+		t.Error("Expected a single probe per interval")
+	}
+
+	c.observe(context.Background(), nil, now.Add(ProbeInterval))
+	if c.isOffline() || !c.shouldTry(now.Add(ProbeInterval+time.Second)) {
+		t.Error("Expected a successful probe to bring the client back online")
+	}
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v58/github"
+	"github.com/will-wright-eng/gh-nav/internal/models"
+)
+
+// Pager fetches a paginated listing one page at a time, so callers can show
+// the first page while later ones are still to come. A Pager is not safe for
+// concurrent use; fetch one page at a time.
+type Pager[T any] struct {
+	fetch func(ctx context.Context, page int) ([]T, int, error)
+	next  int // page to fetch next, 0 once the last page has been fetched
+}
+
+// NewPager creates a pager from a function that fetches a page by number and
+// returns the next page number, or 0 after the last page
+func NewPager[T any](fetch func(ctx context.Context, page int) ([]T, int, error)) *Pager[T] {
+	return &Pager[T]{fetch: fetch, next: 1}
+}
+
+// Next fetches the next page. A failed page can be retried by calling Next again.
+func (p *Pager[T]) Next(ctx context.Context) ([]T, error) {
+	if p.next == 0 {
+		return nil, nil
+	}
+	items, next, err := p.fetch(ctx, p.next)
+	if err != nil {
+		return nil, err
+	}
+	p.next = next
+	return items, nil
+}
+
+// Done reports whether every page has been fetched
+func (p *Pager[T]) Done() bool {
+	return p.next == 0
+}
+
+// All fetches the remaining pages and returns their items
+func (p *Pager[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+	for !p.Done() {
+		items, err := p.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+	}
+	return all, nil
+}
+
+// PullRequests pages through a repository's pull requests in a list state
+// (open, closed, merged or all), most recently updated first
+func (c *Client) PullRequests(owner, repo, state string) *Pager[*models.PullRequest] {
+	repoName := owner + "/" + repo
+	return NewPager(func(ctx context.Context, page int) ([]*models.PullRequest, int, error) {
+		prs, next, err := c.GetPullRequestsPage(ctx, owner, repo, state, page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return models.FromGitHubPRs(prs, repoName), next, nil
+	})
+}
+
+// OwnerRepositories pages through an owner's repositories as owner/repo
+// names, most recently updated first. The authenticated user's own listing
+// (isUser) includes repositories they collaborate on.
+func (c *Client) OwnerRepositories(owner string, isUser bool) *Pager[string] {
+	return NewPager(func(ctx context.Context, page int) ([]string, int, error) {
+		var (
+			repos []*github.Repository
+			resp  *github.Response
+			err   error
+		)
+		if isUser {
+			opt := &github.RepositoryListByUserOptions{
+				Type:        "all", // all, owner, public, private, member
+				Sort:        "updated",
+				Direction:   "desc",
+				ListOptions: github.ListOptions{PerPage: 100, Page: page},
+			}
+			repos, resp, err = c.client.Repositories.ListByUser(ctx, owner, opt)
+		} else {
+			opt := &github.RepositoryListByOrgOptions{
+				Type:        "all", // all, public, private, forks, sources, member
+				Sort:        "updated",
+				Direction:   "desc",
+				ListOptions: github.ListOptions{PerPage: 100, Page: page},
+			}
+			repos, resp, err = c.client.Repositories.ListByOrg(ctx, owner, opt)
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to list repositories for %s: %w", owner, err)
+		}
+
+		names := make([]string, 0, len(repos))
+		for _, repo := range repos {
+			names = append(names, repo.GetFullName())
+		}
+		return names, resp.NextPage, nil
+	})
+}
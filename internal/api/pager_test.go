@@ -0,0 +1,43 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestPager(t *testing.T) {
+	pages := map[int][]int{1: {1, 2}, 2: {3, 4}, 3: {5}}
+	fail := true
+	pager := NewPager(func(ctx context.Context, page int) ([]int, int, error) {
+		if page == 2 && fail {
+			fail = false
+			return nil, 0, errors.New("timeout")
+		}
+		next := page + 1
+		if page == 3 {
+			next = 0
+		}
+		return pages[page], next, nil
+	})
+
+	ctx := context.Background()
+	if items, err := pager.Next(ctx); err != nil || len(items) != 2 || pager.Done() {
+		t.Fatalf("Expected the first page, got %v %v", items, err)
+	}
+
+	// A failed page is fetched again on the next call
+	if _, err := pager.Next(ctx); err == nil {
+		t.Fatal("Expected the second page to fail")
+	}
+	rest, err := pager.All(ctx)
+	if err != nil || len(rest) != 3 || rest[0] != 3 {
+		t.Fatalf("Expected the remaining items, got %v %v", rest, err)
+	}
+	if !pager.Done() {
+		t.Error("Expected the pager to be done")
+	}
+	if items, err := pager.Next(ctx); items != nil || err != nil {
+		t.Errorf("Expected nothing after the last page, got %v %v", items, err)
+	}
+}
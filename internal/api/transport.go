@@ -0,0 +1,428 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/will-wright-eng/gh-nav/pkg/config"
+)
+
+// Retry and rate-limit settings of the transport chain
+const (
+	maxAttempts      = 3                      // tries of an idempotent request failing transiently
+	retryBackoff     = 500 * time.Millisecond // doubled after each failed attempt
+	maxRateLimitWait = time.Minute            // longest wait for a rate limit to lift before giving up
+)
+
+// newTransport builds the middleware chain API requests go through, outermost
+// first: authentication, the response cache, retries of transient failures and
+// waiting out rate limits, before next sends the request
+func newTransport(cfg *config.Config, apiHost string, conn *connectivity, next http.RoundTripper) http.RoundTripper {
+	t := next
+	t = &rateLimitTransport{next: t}
+	t = &retryTransport{next: t, backoff: retryBackoff}
+	// A cache would turn recorded responses into 304s and answer replayed
+	// requests before they reach the fixture
+	if cfg.Cache.Enabled && cfg.Debug.RecordFile == "" && cfg.Debug.ReplayFile == "" {
+		t = &cacheTransport{next: t, cache: newResponseCache(cfg.Cache.Dir), conn: conn}
+	}
+	if cfg.GitHub.Token != "" {
+		t = &authTransport{next: t, host: apiHost, token: cfg.GitHub.Token}
+	}
+	return t
+}
+
+// authTransport adds the token to requests for the API host. Other hosts (e.g.
+// redirects to storage) never see it.
+type authTransport struct {
+	next  http.RoundTripper
+	host  string
+	token string
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Host != t.host || req.Header.Get("Authorization") != "" {
+		return t.next.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return t.next.RoundTrip(req)
+}
+
+// loggingTransport logs each API request with its status and duration
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		log.Printf("api: %s %s failed after %s: %v", req.Method, req.URL.Path, time.Since(start), err)
+		return nil, err
+	}
+	log.Printf("api: %s %s %d (%s)", req.Method, req.URL.Path, resp.StatusCode, time.Since(start))
+	return resp, nil
+}
+
+// retryTransport retries idempotent requests that failed with a network error
+// or a 502, 503 or 504, backing off between attempts
+type retryTransport struct {
+	next    http.RoundTripper
+	backoff time.Duration
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return t.next.RoundTrip(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		if attempt == maxAttempts || !isTransient(req.Context(), resp, err) {
+			return resp, err
+		}
+		if resp != nil {
+			discard(resp)
+		}
+		if err := sleep(req.Context(), t.backoff<<(attempt-1)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// isTransient reports whether a failed attempt is worth repeating
+func isTransient(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil && !errors.Is(err, errNotRecorded)
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// rateLimitTransport waits out a rate limit GitHub answers with (a 403 or 429
+// with Retry-After, or with no requests remaining until the reset time) and
+// sends the request once more, if the wait is short enough. Longer limits are
+// returned as they are, for go-github to report.
+type rateLimitTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	wait, limited := rateLimitWait(resp, time.Now())
+	if !limited || wait > maxRateLimitWait {
+		return resp, nil
+	}
+	retry, ok := rewind(req)
+	if !ok {
+		return resp, nil
+	}
+	if deadline, ok := req.Context().Deadline(); ok && time.Now().Add(wait).After(deadline) {
+		return resp, nil
+	}
+
+	discard(resp)
+	if err := sleep(req.Context(), wait); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(retry)
+}
+
+// rateLimitWait reports whether resp is a rate limit and how long it lasts
+func rateLimitWait(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return time.Unix(reset, 0).Sub(now), true
+		}
+	}
+	return 0, false
+}
+
+// rewind returns a copy of req that can be sent again, if its body allows it
+func rewind(req *http.Request) (*http.Request, bool) {
+	retry := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return retry, true
+	}
+	if req.GetBody == nil {
+		return nil, false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	retry.Body = body
+	return retry, true
+}
+
+// sleep waits for d, or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// discard drains and closes a response that won't be returned, so its
+// connection can be reused
+func discard(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+}
+
+// cacheTransport revalidates GET responses with their ETag or Last-Modified
+// date. GitHub answers an unchanged resource with a 304, which doesn't count
+// against the rate limit, and the cached response is returned instead.
+//
+// The cache also keeps GitHub usable offline. GraphQL queries are stored too,
+// and when GitHub can't be reached (or conn is forced offline) reads are
+// answered from the cache and writes fail with ErrOffline.
+type cacheTransport struct {
+	next  http.RoundTripper
+	cache *responseCache
+	conn  *connectivity
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req, key, ok := t.key(req)
+	if !ok {
+		if !t.conn.shouldTry(time.Now()) {
+			return nil, fmt.Errorf("%w: %s %s needs GitHub", ErrOffline, req.Method, req.URL.Path)
+		}
+		resp, err := t.next.RoundTrip(req)
+		t.conn.observe(req.Context(), err, time.Now())
+		return resp, err
+	}
+
+	cached := t.cache.get(key)
+	if !t.conn.shouldTry(time.Now()) {
+		return t.offline(req, cached)
+	}
+	if cached != nil && req.Method == http.MethodGet {
+		req = req.Clone(req.Context())
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if modified := cached.Header.Get("Last-Modified"); modified != "" {
+			req.Header.Set("If-Modified-Since", modified)
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	t.conn.observe(req.Context(), err, time.Now())
+	if err != nil {
+		if isNetworkError(req.Context(), err) {
+			return t.offline(req, cached)
+		}
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		discard(resp)
+		// Other requests may hold the stored entry, so it's replaced rather
+		// than updated
+		confirmed := *cached
+		confirmed.StoredAt = time.Now()
+		t.cache.put(key, &confirmed)
+		return confirmed.response(req, resp.Header), nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+	// GET responses are only worth keeping if they can be revalidated; GraphQL
+	// responses never can, and are only kept for offline use
+	if req.Method == http.MethodGet && resp.Header.Get("ETag") == "" && resp.Header.Get("Last-Modified") == "" {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	t.cache.put(key, &cachedResponse{Header: resp.Header, Body: body, StoredAt: time.Now()})
+	return resp, nil
+}
+
+// key returns the cache key of a cacheable request: a GET of a whole resource,
+// or a GraphQL query (whose body is read, and restored on the returned request)
+func (t *cacheTransport) key(req *http.Request) (*http.Request, string, bool) {
+	switch {
+	case req.Method == http.MethodGet && req.Header.Get("Range") == "":
+		return req, cacheKey(req, nil), true
+	case req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/graphql") && req.GetBody != nil:
+		body, err := req.GetBody()
+		if err != nil {
+			return req, "", false
+		}
+		defer body.Close()
+		data, err := io.ReadAll(body)
+		if err != nil || !bytes.HasPrefix(data, []byte(`{"query":"query`)) {
+			return req, "", false
+		}
+		return req, cacheKey(req, data), true
+	}
+	return req, "", false
+}
+
+// offline answers a read from the cache while GitHub is unreachable, noting
+// the response's age for the request's context
+func (t *cacheTransport) offline(req *http.Request, cached *cachedResponse) (*http.Response, error) {
+	if cached == nil {
+		return nil, fmt.Errorf("%w: %s isn't cached", ErrOffline, req.URL.Path)
+	}
+	record(req.Context(), cached.StoredAt)
+	return cached.response(req, nil), nil
+}
+
+// cacheKey identifies a response by URL, media type and credentials, so users
+// sharing a cache directory never see each other's responses
+func cacheKey(req *http.Request, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s", req.URL.String(), req.Header.Get("Accept"), req.Header.Get("Authorization"))
+	if body != nil {
+		fmt.Fprintf(h, "\x00%s", body)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// cachedResponse is a stored 200 response
+type cachedResponse struct {
+	Header   http.Header `json:"header"`
+	Body     []byte      `json:"body"`
+	StoredAt time.Time   `json:"stored_at"` // when GitHub last confirmed it
+}
+
+// response rebuilds the cached response for req, taking the rate limit
+// headers from the fresh 304, if there is one
+func (c *cachedResponse) response(req *http.Request, fresh http.Header) *http.Response {
+	header := c.Header.Clone()
+	for _, name := range []string{"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "X-RateLimit-Used", "X-RateLimit-Resource"} {
+		if value := fresh.Get(name); value != "" {
+			header.Set(name, value)
+		}
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(c.Body)),
+		ContentLength: int64(len(c.Body)),
+		Request:       req,
+	}
+}
+
+// responseCache stores responses in a directory, or in memory when there is none
+type responseCache struct {
+	dir string
+
+	mu  sync.Mutex
+	mem map[string]*cachedResponse
+}
+
+// newResponseCache creates a cache storing responses under dir
+func newResponseCache(dir string) *responseCache {
+	if dir != "" {
+		dir = filepath.Join(dir, "http")
+	}
+	return &responseCache{dir: dir, mem: make(map[string]*cachedResponse)}
+}
+
+// get returns the stored response for key, or nil
+func (c *responseCache) get(key string) *cachedResponse {
+	if c.dir == "" {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return c.mem[key]
+	}
+
+	data, err := os.ReadFile(filepath.Join(c.dir, key))
+	if err != nil {
+		return nil
+	}
+	var cached cachedResponse
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil
+	}
+	return &cached
+}
+
+// put stores a response. Failing to write the cache only costs a refetch.
+func (c *responseCache) put(key string, cached *cachedResponse) {
+	if c.dir == "" {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.mem[key] = cached
+		return
+	}
+
+	if err := c.write(key, cached); err != nil {
+		log.Printf("api: failed to cache response: %v", err)
+	}
+}
+
+// write stores a response file. It's written to a temporary file first and
+// renamed, so a concurrent reader never sees half a response.
+func (c *responseCache) write(key string, cached *cachedResponse) error {
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(c.dir, key+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(c.dir, key))
+}
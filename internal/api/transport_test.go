@@ -0,0 +1,188 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/will-wright-eng/gh-nav/pkg/config"
+)
+
+func get(t *testing.T, rt http.RoundTripper, rawURL string) (*http.Response, string) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp, string(body)
+}
+
+func TestRetryTransport(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+
+	rt := &retryTransport{next: http.DefaultTransport, backoff: time.Millisecond}
+	if resp, body := get(t, rt, server.URL); resp.StatusCode != http.StatusOK || body != "ok" || calls != 3 {
+		t.Errorf("Expected success on the third attempt, got %d after %d calls", resp.StatusCode, calls)
+	}
+
+	// Writes aren't repeated
+	calls = 0
+	req, _ := http.NewRequest(http.MethodPost, server.URL, nil)
+	resp, err := rt.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusServiceUnavailable || calls != 1 {
+		t.Errorf("Expected a single POST attempt, got %d calls", calls)
+	}
+	resp.Body.Close()
+}
+
+func TestCacheTransport(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.Header().Set("X-RateLimit-Remaining", "4999")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("X-RateLimit-Remaining", "5000")
+		io.WriteString(w, "payload")
+	}))
+	defer server.Close()
+
+	for _, dir := range []string{"", t.TempDir()} {
+		calls = 0
+		rt := &cacheTransport{next: http.DefaultTransport, cache: newResponseCache(dir)}
+		get(t, rt, server.URL)
+		resp, body := get(t, rt, server.URL)
+		if calls != 2 || resp.StatusCode != http.StatusOK || body != "payload" {
+			t.Errorf("Expected the cached body after revalidation, got %d %q", resp.StatusCode, body)
+		}
+		if resp.Header.Get("X-RateLimit-Remaining") != "4999" {
+			t.Error("Expected the rate limit headers of the revalidation")
+		}
+	}
+}
+
+func TestCacheTransportConcurrentRevalidation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		io.WriteString(w, "payload")
+	}))
+	defer server.Close()
+
+	rt := &cacheTransport{next: http.DefaultTransport, cache: newResponseCache("")}
+	get(t, rt, server.URL)
+
+	// Every 304 refreshes the same stored response (run with -race)
+	var wg sync.WaitGroup
+	bodies := make(chan string, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+			resp, err := rt.RoundTrip(req)
+			if err != nil {
+				bodies <- err.Error()
+				return
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			bodies <- string(body)
+		}()
+	}
+	wg.Wait()
+	close(bodies)
+	for body := range bodies {
+		if body != "payload" {
+			t.Errorf("Expected the cached body, got %q", body)
+		}
+	}
+}
+
+func TestAuthTransportOnlyForAPIHost(t *testing.T) {
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+
+	rt := &authTransport{next: http.DefaultTransport, host: serverURL.Host, token: "secret"}
+	get(t, rt, server.URL)
+	if auth != "Bearer secret" {
+		t.Errorf("Expected the token on the API host, got %q", auth)
+	}
+
+	rt.host = "api.github.com"
+	get(t, rt, server.URL)
+	if auth != "" {
+		t.Errorf("Expected no token on another host, got %q", auth)
+	}
+}
+
+func TestRateLimitWait(t *testing.T) {
+	now := time.Unix(1000, 0)
+	tests := []struct {
+		status int
+		header map[string]string
+		want   time.Duration
+		ok     bool
+	}{
+		{http.StatusForbidden, map[string]string{"Retry-After": "5"}, 5 * time.Second, true},
+		{http.StatusForbidden, map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "1030"}, 30 * time.Second, true},
+		{http.StatusTooManyRequests, map[string]string{"Retry-After": "1"}, time.Second, true},
+		{http.StatusForbidden, nil, 0, false},
+		{http.StatusOK, map[string]string{"Retry-After": "5"}, 0, false},
+	}
+	for _, tt := range tests {
+		resp := &http.Response{StatusCode: tt.status, Header: http.Header{}}
+		for k, v := range tt.header {
+			resp.Header.Set(k, v)
+		}
+		if got, ok := rateLimitWait(resp, now); got != tt.want || ok != tt.ok {
+			t.Errorf("rateLimitWait(%d, %v) = %v, %v; want %v, %v", tt.status, tt.header, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestNewTransportChain(t *testing.T) {
+	cfg := &config.Config{GitHub: config.GitHubConfig{Token: "secret"}, Cache: config.CacheConfig{Enabled: true}}
+	auth, ok := newTransport(cfg, "api.github.com", nil, http.DefaultTransport).(*authTransport)
+	if !ok {
+		t.Fatal("Expected authentication outermost")
+	}
+	if _, ok := auth.next.(*cacheTransport); !ok {
+		t.Error("Expected the cache inside authentication")
+	}
+
+	cfg.Cache.Enabled = false
+	cfg.GitHub.Token = ""
+	if _, ok := newTransport(cfg, "api.github.com", nil, http.DefaultTransport).(*retryTransport); !ok {
+		t.Error("Expected retries outermost without a token or cache")
+	}
+}
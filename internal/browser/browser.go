@@ -0,0 +1,44 @@
+package browser
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Open opens url in a web browser. command is the user's configured browser
+// (GH_BROWSER, gh's browser setting or BROWSER); when empty the platform's
+// default opener is used.
+func Open(command, url string) error {
+	cmd, err := browserCommand(command, url)
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open browser: %w", err)
+	}
+	// Don't leave a zombie behind; the opener usually exits immediately
+	go cmd.Wait()
+	return nil
+}
+
+// browserCommand builds the command that opens url
+func browserCommand(command, url string) (*exec.Cmd, error) {
+	if command != "" {
+		fields := strings.Fields(command)
+		return exec.Command(fields[0], append(fields[1:], url)...), nil
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", url), nil
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", url), nil
+	default:
+		if _, err := exec.LookPath("xdg-open"); err != nil {
+			return nil, fmt.Errorf("no browser configured; set GH_BROWSER or BROWSER")
+		}
+		return exec.Command("xdg-open", url), nil
+	}
+}
@@ -0,0 +1,18 @@
+package browser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBrowserCommand(t *testing.T) {
+	cmd, err := browserCommand("firefox --new-tab", "https://github.com/cli/cli/pull/1")
+	if err != nil {
+		t.Fatalf("browserCommand returned error: %v", err)
+	}
+
+	expected := []string{"firefox", "--new-tab", "https://github.com/cli/cli/pull/1"}
+	if !reflect.DeepEqual(cmd.Args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, cmd.Args)
+	}
+}
@@ -4,19 +4,36 @@ import "time"
 
 // Navigation constants
 const (
-	KeyUp      = "up"
-	KeyDown    = "down"
-	KeyLeft    = "left"
-	KeyRight   = "right"
-	KeyEnter   = "enter"
-	KeyBack    = "backspace"
-	KeyBackAlt = "b"
-	KeyQuit    = "q"
-	KeyQuitAlt = "ctrl+c"
-	KeyDebug   = "d"
-	KeyReload  = "r"
-	KeyFirst   = "g"
-	KeyLast    = "G"
+	KeyUp       = "up"
+	KeyDown     = "down"
+	KeyLeft     = "left"
+	KeyRight    = "right"
+	KeyEnter    = "enter"
+	KeyBack     = "backspace"
+	KeyBackAlt  = "b"
+	KeyQuit     = "q"
+	KeyQuitAlt  = "ctrl+c"
+	KeyDebug    = "d"
+	KeyReload   = "r"
+	KeyFirst    = "g"
+	KeyLast     = "G"
+	KeyCheckout = "c"
+	KeyBrowser  = "o"
+	KeyRerun    = "R"
+	KeyThreads  = "t"
+	KeyFiles    = "f"
+	KeyComment  = "C"
+	KeyReview   = "v"
+	KeyMerge    = "m"
+	KeyLabels   = "L"
+	KeyAssign   = "A"
+	KeyReviewer = "W"
+	KeyBulk     = "x"
+	KeyDraft    = "D"
+	KeyClose    = "X"
+	KeyState    = "s"
+	KeyRead     = "u"
+	KeyReadAll  = "U"
 )
 
 // View mode constants
@@ -28,11 +45,15 @@ const (
 
 // UI constants
 const (
-	DefaultPageSize = 10
-	MaxTitleLength  = 60
-	DefaultMargin   = 2
-	RefreshInterval = time.Second
-	DefaultTimeout  = 30 * time.Second
+	SideBySideMinWidth = 160 // terminal width from which diffs default to side-by-side
+	DefaultPageSize    = 10
+	MaxTitleLength     = 60
+	DefaultMargin      = 2
+	RefreshInterval    = time.Second
+	DefaultTimeout     = 30 * time.Second
+	MaxOwnerLoads      = 4 // owners whose repositories load at the same time
+	DateFormat         = "2006-01-02"
+	DateTimeFormat     = "2006-01-02 15:04"
 )
 
 // Status constants
@@ -61,10 +82,28 @@ const (
 	IconDraft        = "🟡"
 	IconApproved     = "🟢"
 	IconChanges      = "🔴"
+	IconClosed       = "⚫"
+	IconMerged       = "🟣"
 	IconLoading      = "🔄"
 	IconError        = "❌"
 	IconSuccess      = "✅"
 	IconInfo         = "ℹ️"
+	IconThread       = "💬"
+	IconResolved     = "✔"
+	IconCollapsed    = "▸"
+	IconExpanded     = "▾"
+	IconChanged      = "●"
+	IconUnread       = "•"
+)
+
+// CI status icon constants
+const (
+	IconCISuccess = "✅"
+	IconCIFailure = "❌"
+	IconCIPending = "⏳"
+	IconCINeutral = "⚪"
+	IconCINone    = "➖"
+	IconCIUnknown = "  " // checks not loaded yet; keeps the column aligned
 )
 
 // Color constants
@@ -77,22 +116,57 @@ const (
 	ColorInfo      = "#00FFFF"
 	ColorMuted     = "#888888"
 	ColorText      = "#FAFAFA"
+
+	ColorDiffAdded   = "#3FB950"
+	ColorDiffRemoved = "#F85149"
+	ColorDiffHunk    = "#A371F7"
+	ColorLineNumber  = "#6E7681"
 )
 
 // Help text constants
 const (
+	HelpSeparator  = " • " // between the entries of a help line
 	HelpNavigation = "↑/↓: Navigate • ←/→: Page • g/G: First/Last • Enter: Select • b: Back • d: Debug • r: Reload • q: Quit"
+	HelpPRList     = "↑/↓: Navigate • ←/→: Page • g/G: First/Last • Enter: Details • s: Open/Closed/Merged/All • Space/V: Select/Range • x: Bulk actions • u/U: Read/All read • t: Threads • f: Files • C: Comment • v: Review • m: Merge • D: Draft/Ready • X: Close/Reopen • L/A/W: Labels/Assignees/Reviewers • c: Checkout • o: Open • b: Back • d: Debug • r: Reload • q: Quit"
+	HelpPRDetail   = "↑/↓: Select check • ←/→: Page • Enter: Logs • R: Re-run failed • t: Threads • f: Files • C: Comment • v: Review • m: Merge • D: Draft/Ready • X: Close/Reopen • L/A/W: Labels/Assignees/Reviewers • c: Checkout • o: Open • b: Back • d: Debug • q: Quit"
+	HelpFiles      = "↑/↓: Select file • ←/→: Page • Enter: View diff • o: Open • b: Back • d: Debug • q: Quit"
+	HelpDiff       = "↑/↓: Scroll • ←/→: Page • g/G: Top/Bottom • n/N: Next/Prev hunk • ]/[: Next/Prev file • s: Side-by-side • b: Back • q: Quit"
+	HelpThreads    = "↑/↓: Select thread • ←/→: Page • Enter/Space: Expand/Collapse • C: Comment/Reply • v: Review • o: Open • b: Back • d: Debug • q: Quit"
+	HelpLogView    = "↑/↓: Scroll • ←/→: Page • g/G: Top/Bottom • /: Search • n/N: Next/Prev match • e/E: Next/Prev error • R: Re-run failed • o: Open • b: Back • q: Quit"
+	HelpLogSearch  = "Type to search • Enter: Find • Esc: Cancel"
+	HelpCompose    = "Type your comment • ctrl+s: Send • ctrl+e: Open in $EDITOR • Esc: Cancel"
+	HelpClose      = "Optional closing comment • ctrl+s: Close • ctrl+e: Open in $EDITOR • Esc: Cancel"
+	HelpReview     = "Type your review • Tab: Change verdict • ctrl+s: Send • ctrl+e: Open in $EDITOR • Esc: Cancel"
+	HelpConfirm    = "y: Yes • n: No"
+	HelpMerge      = "Tab: Next field • ←/→/Space: Change method/toggle • ctrl+s: Merge • Esc: Cancel"
+	HelpMergeBack  = "b: Back • q: Quit"
+	HelpBulkMenu   = "↑/↓: Choose action • Enter: Select • Esc: Cancel"
+	HelpBulkInput  = "Type a value • Enter: Continue • Esc: Back"
+	HelpBulkRun    = "Esc: Stop after the current pull request"
+	HelpBulkDone   = "b: Back to the list • q: Quit"
+	HelpPicker     = "Type to filter • ↑/↓: Navigate • Space: Toggle • Enter: Apply • Esc: Clear filter/Cancel"
 	HelpLoading    = "Loading..."
 	HelpNoData     = "No data found"
 )
 
 // Error messages
 const (
-	ErrLoadingRepos   = "Error loading repositories"
-	ErrLoadingPRs     = "Error loading pull requests"
-	ErrInvalidToken   = "Invalid GitHub token"
-	ErrNetworkTimeout = "Network timeout"
-	ErrUnauthorized   = "Unauthorized access"
+	ErrLoadingRepos    = "Error loading repositories"
+	ErrLoadingPRs      = "Error loading pull requests"
+	ErrInvalidToken    = "Invalid GitHub token"
+	ErrNetworkTimeout  = "Network timeout"
+	ErrUnauthorized    = "Unauthorized access"
+	ErrNoPRSelected    = "No pull request selected"
+	ErrLoadingChecks   = "Error loading checks"
+	ErrLoadingLog      = "Error loading log"
+	ErrNoJobLog        = "Logs are only available for GitHub Actions jobs"
+	ErrNoCheckSelected = "No check selected"
+	ErrLoadingThreads  = "Error loading comments"
+	ErrLoadingFiles    = "Error loading changed files"
+	ErrLoadingMerge    = "Error loading merge status"
+	ErrLoadingOptions  = "Error loading choices"
+	ErrNothingSelected = "Select pull requests with Space or V first"
+	ErrSavingSeen      = "Error saving read pull requests"
 )
 
 // Success messages
@@ -100,4 +174,23 @@ const (
 	MsgReposLoaded   = "Repositories loaded successfully"
 	MsgPRsLoaded     = "Pull requests loaded successfully"
 	MsgDataRefreshed = "Data refreshed successfully"
+	MsgCheckingOut   = "Checking out pull request..."
+	MsgOpenedBrowser = "Opened in browser"
+	MsgLoadingLog    = "Downloading log..."
+	MsgRerunning     = "Requesting re-run of failed jobs..."
+	MsgRerunStarted  = "Re-run of failed jobs requested"
+	MsgPosting       = "Sending to GitHub..."
+	MsgCommentPosted = "Comment posted"
+	MsgReplyPosted   = "Reply posted"
+	MsgReviewPosted  = "Review submitted"
+	MsgMerging       = "Merging pull request..."
+	MsgClosing       = "Closing pull request..."
+	MsgMarkedRead    = "Marked #%d as read"
+	MsgMarkedUnread  = "Marked #%d as unread"
+	MsgMarkedAllRead = "Marked %d pull requests as read"
+	MsgQueued        = "Offline: queued %s (%d queued)"
+	MsgSendQueued    = "Back online: send %d queued changes to GitHub?"
+	MsgSendingQueued = "Sending %d queued changes..."
+	MsgSentQueued    = "Sent %d queued changes"
+	MsgKeptQueued    = "Kept %d queued changes; they'll be offered again when GitHub is next reachable"
 )
@@ -0,0 +1,49 @@
+package editor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Command builds the command that edits path. command is the user's configured
+// editor (GH_EDITOR, gh's editor setting, VISUAL or EDITOR); when empty a
+// platform default is used.
+func Command(command, path string) *exec.Cmd {
+	if command == "" {
+		command = "nano"
+		if runtime.GOOS == "windows" {
+			command = "notepad"
+		}
+	}
+	fields := strings.Fields(command)
+	return exec.Command(fields[0], append(fields[1:], path)...)
+}
+
+// WriteDraft saves text to a new temporary Markdown file for editing
+func WriteDraft(text string) (string, error) {
+	file, err := os.CreateTemp("", "gh-nav-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create draft file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(text); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write draft file: %w", err)
+	}
+	return file.Name(), nil
+}
+
+// ReadDraft reads an edited draft back and removes the file
+func ReadDraft(path string) (string, error) {
+	defer os.Remove(path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read draft file: %w", err)
+	}
+	return strings.TrimRight(string(data), "\n"), nil
+}
@@ -0,0 +1,34 @@
+package editor
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestCommand(t *testing.T) {
+	cmd := Command("code --wait", "/tmp/draft.md")
+
+	expected := []string{"code", "--wait", "/tmp/draft.md"}
+	if !reflect.DeepEqual(cmd.Args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, cmd.Args)
+	}
+}
+
+func TestDraftRoundTrip(t *testing.T) {
+	path, err := WriteDraft("Needs a test\n\n")
+	if err != nil {
+		t.Fatalf("WriteDraft returned error: %v", err)
+	}
+
+	text, err := ReadDraft(path)
+	if err != nil {
+		t.Fatalf("ReadDraft returned error: %v", err)
+	}
+	if text != "Needs a test" {
+		t.Errorf("Expected trailing newlines trimmed, got %q", text)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Expected the draft file to be removed")
+	}
+}
@@ -0,0 +1,204 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CheckoutOptions describes a pull request to check out locally
+type CheckoutOptions struct {
+	RepoName    string // base repository as owner/repo
+	Number      int    // pull request number
+	Branch      string // local branch name to create or update
+	WorktreeDir string // when set, check out into a dedicated worktree under this directory
+}
+
+// CheckoutResult describes where a pull request ended up
+type CheckoutResult struct {
+	Branch string
+	Path   string // worktree path, empty for in-place checkouts
+}
+
+// CheckoutPullRequest fetches refs/pull/<n>/head from the remote matching the base
+// repository and checks it out. Using the pull ref on the base repository means
+// PRs from forks work without adding the fork as a remote.
+func (c *Client) CheckoutPullRequest(ctx context.Context, opts CheckoutOptions) (*CheckoutResult, error) {
+	if !c.IsRepository(ctx) {
+		return nil, ErrNotRepository
+	}
+
+	remotes, err := c.Remotes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	remote, ok := FindRemote(remotes, opts.RepoName)
+	if !ok {
+		return nil, fmt.Errorf("no remote in this clone points at %s", opts.RepoName)
+	}
+
+	if opts.WorktreeDir == "" {
+		dirty, err := c.IsDirty(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if dirty {
+			return nil, ErrDirtyWorktree
+		}
+	}
+
+	ref := fmt.Sprintf("refs/pull/%d/head", opts.Number)
+	if _, err := c.run(ctx, "fetch", remote.Name, ref); err != nil {
+		return nil, err
+	}
+
+	// Resolve FETCH_HEAD now: it is per-worktree and invisible from linked worktrees
+	head, err := c.run(ctx, "rev-parse", "FETCH_HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.WorktreeDir != "" {
+		return c.checkoutWorktree(ctx, opts, head)
+	}
+
+	return c.checkoutInPlace(ctx, opts.Branch, head)
+}
+
+// checkoutInPlace switches the current work tree to the fetched pull request head
+func (c *Client) checkoutInPlace(ctx context.Context, branch, head string) (*CheckoutResult, error) {
+	if c.HasBranch(ctx, branch) {
+		// Check before switching, so a branch that can't be updated doesn't
+		// leave the user on its stale commits
+		if !c.canFastForward(ctx, branch, head) {
+			return nil, ErrDiverged
+		}
+		if _, err := c.run(ctx, "checkout", branch); err != nil {
+			return nil, err
+		}
+		if _, err := c.run(ctx, "merge", "--ff-only", head); err != nil {
+			return nil, err
+		}
+	} else if _, err := c.run(ctx, "checkout", "-b", branch, head); err != nil {
+		return nil, err
+	}
+
+	return &CheckoutResult{Branch: branch}, nil
+}
+
+// checkoutWorktree creates (or reuses) a dedicated worktree for the pull request
+func (c *Client) checkoutWorktree(ctx context.Context, opts CheckoutOptions, head string) (*CheckoutResult, error) {
+	dir, err := expandHome(opts.WorktreeDir)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := opts.RepoName
+	if i := strings.LastIndex(repo, "/"); i >= 0 {
+		repo = repo[i+1:]
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s-pr-%d", repo, opts.Number))
+
+	if _, err := os.Stat(path); err == nil {
+		// Existing worktree: fast-forward it to the freshly fetched head, once
+		// it's known to be this PR's worktree and not something else at the path
+		branch, ok, err := c.worktreeBranch(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("%s already exists and isn't a worktree of this repository", path)
+		}
+		if branch != opts.Branch {
+			return nil, fmt.Errorf("worktree %s has %s checked out, not %s", path, describeBranch(branch), opts.Branch)
+		}
+		worktree := NewClient(path)
+		if _, err := worktree.run(ctx, "merge", "--ff-only", head); err != nil {
+			return nil, err
+		}
+		return &CheckoutResult{Branch: opts.Branch, Path: path}, nil
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create worktree directory: %w", err)
+	}
+
+	if c.HasBranch(ctx, opts.Branch) {
+		if !c.canFastForward(ctx, opts.Branch, head) {
+			return nil, ErrDiverged
+		}
+		if _, err := c.run(ctx, "worktree", "add", path, opts.Branch); err != nil {
+			return nil, err
+		}
+		if _, err := NewClient(path).run(ctx, "merge", "--ff-only", head); err != nil {
+			return nil, err
+		}
+	} else if _, err := c.run(ctx, "worktree", "add", "-b", opts.Branch, path, head); err != nil {
+		return nil, err
+	}
+
+	return &CheckoutResult{Branch: opts.Branch, Path: path}, nil
+}
+
+// canFastForward reports whether a local branch can be fast-forwarded to head,
+// i.e. the branch's tip is an ancestor of it
+func (c *Client) canFastForward(ctx context.Context, branch, head string) bool {
+	_, err := c.run(ctx, "merge-base", "--is-ancestor", "refs/heads/"+branch, head)
+	return err == nil
+}
+
+// worktreeBranch looks path up among the repository's worktrees, returning the
+// branch it has checked out ("" when HEAD is detached) and whether it is one
+func (c *Client) worktreeBranch(ctx context.Context, path string) (string, bool, error) {
+	out, err := c.run(ctx, "worktree", "list", "--porcelain")
+	if err != nil {
+		return "", false, err
+	}
+	want := canonicalPath(path)
+	for _, entry := range strings.Split(out, "\n\n") {
+		var worktree, branch string
+		for _, line := range strings.Split(entry, "\n") {
+			if p, ok := strings.CutPrefix(line, "worktree "); ok {
+				worktree = p
+			} else if b, ok := strings.CutPrefix(line, "branch "); ok {
+				branch = strings.TrimPrefix(b, "refs/heads/")
+			}
+		}
+		if worktree != "" && canonicalPath(worktree) == want {
+			return branch, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// canonicalPath resolves symlinks (e.g. macOS's /var -> /private/var) so paths
+// git reports compare equal to the ones we build
+func canonicalPath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return filepath.Clean(path)
+}
+
+// describeBranch names a worktree's branch for errors
+func describeBranch(branch string) string {
+	if branch == "" {
+		return "a detached HEAD"
+	}
+	return branch
+}
+
+// expandHome resolves a leading ~ to the user's home directory
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
@@ -0,0 +1,205 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// setupClone creates a bare "GitHub" repository with a pull ref and a clone of it
+func setupClone(t *testing.T) (clone string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("Skipping test - git not installed")
+	}
+
+	root := t.TempDir()
+	origin := filepath.Join(root, "origin.git")
+	work := filepath.Join(root, "work")
+	clone = filepath.Join(root, "clone")
+
+	gitCmd := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	gitCmd(root, "init", "--bare", "-b", "main", origin)
+	gitCmd(root, "init", "-b", "main", work)
+	os.WriteFile(filepath.Join(work, "README"), []byte("base\n"), 0o644)
+	gitCmd(work, "add", ".")
+	gitCmd(work, "commit", "-m", "base")
+	gitCmd(work, "push", origin, "main")
+
+	// Simulate GitHub publishing the PR head under refs/pull/7/head
+	gitCmd(work, "checkout", "-b", "feature")
+	os.WriteFile(filepath.Join(work, "README"), []byte("feature\n"), 0o644)
+	gitCmd(work, "commit", "-am", "feature")
+	gitCmd(work, "push", origin, "feature:refs/pull/7/head")
+
+	gitCmd(root, "clone", origin, clone)
+	gitCmd(clone, "remote", "set-url", "origin", "https://github.com/org/project.git")
+	gitCmd(clone, "config", "url."+origin+".insteadOf", "https://github.com/org/project.git")
+
+	return clone
+}
+
+func TestCheckoutPullRequest(t *testing.T) {
+	clone := setupClone(t)
+	client := NewClient(clone)
+	ctx := context.Background()
+
+	result, err := client.CheckoutPullRequest(ctx, CheckoutOptions{
+		RepoName: "org/project",
+		Number:   7,
+		Branch:   "feature",
+	})
+	if err != nil {
+		t.Fatalf("CheckoutPullRequest failed: %v", err)
+	}
+	if result.Branch != "feature" || result.Path != "" {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+
+	branch, _ := client.CurrentBranch(ctx)
+	if branch != "feature" {
+		t.Errorf("Expected feature to be checked out, got %q", branch)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(clone, "README"))
+	if string(content) != "feature\n" {
+		t.Errorf("Expected PR content, got %q", content)
+	}
+}
+
+func TestCheckoutPullRequestDirty(t *testing.T) {
+	clone := setupClone(t)
+	client := NewClient(clone)
+
+	os.WriteFile(filepath.Join(clone, "README"), []byte("local edit\n"), 0o644)
+
+	_, err := client.CheckoutPullRequest(context.Background(), CheckoutOptions{
+		RepoName: "org/project",
+		Number:   7,
+		Branch:   "feature",
+	})
+	if !errors.Is(err, ErrDirtyWorktree) {
+		t.Errorf("Expected ErrDirtyWorktree, got %v", err)
+	}
+}
+
+func TestCheckoutPullRequestDiverged(t *testing.T) {
+	clone := setupClone(t)
+	client := NewClient(clone)
+	ctx := context.Background()
+
+	// A local "feature" branch with a commit the PR doesn't have
+	client.run(ctx, "checkout", "-b", "feature")
+	os.WriteFile(filepath.Join(clone, "README"), []byte("local\n"), 0o644)
+	client.run(ctx, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-qam", "local")
+	client.run(ctx, "checkout", "main")
+
+	_, err := client.CheckoutPullRequest(ctx, CheckoutOptions{
+		RepoName: "org/project",
+		Number:   7,
+		Branch:   "feature",
+	})
+	if !errors.Is(err, ErrDiverged) {
+		t.Errorf("Expected ErrDiverged, got %v", err)
+	}
+	if branch, _ := client.CurrentBranch(ctx); branch != "main" {
+		t.Errorf("Expected to stay on main, got %q", branch)
+	}
+}
+
+func TestCheckoutPullRequestWorktree(t *testing.T) {
+	clone := setupClone(t)
+	client := NewClient(clone)
+	worktrees := t.TempDir()
+
+	// A dirty main tree doesn't block worktree checkouts
+	os.WriteFile(filepath.Join(clone, "README"), []byte("local edit\n"), 0o644)
+
+	result, err := client.CheckoutPullRequest(context.Background(), CheckoutOptions{
+		RepoName:    "org/project",
+		Number:      7,
+		Branch:      "contrib/feature",
+		WorktreeDir: worktrees,
+	})
+	if err != nil {
+		t.Fatalf("CheckoutPullRequest failed: %v", err)
+	}
+
+	expected := filepath.Join(worktrees, "project-pr-7")
+	if result.Path != expected {
+		t.Errorf("Expected worktree at %s, got %s", expected, result.Path)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(expected, "README"))
+	if string(content) != "feature\n" {
+		t.Errorf("Expected PR content in worktree, got %q", content)
+	}
+}
+
+func TestCheckoutPullRequestWorktreeMismatch(t *testing.T) {
+	clone := setupClone(t)
+	client := NewClient(clone)
+	ctx := context.Background()
+	worktrees := t.TempDir()
+	path := filepath.Join(worktrees, "project-pr-7")
+	opts := CheckoutOptions{RepoName: "org/project", Number: 7, Branch: "feature", WorktreeDir: worktrees}
+
+	// A stray directory at the worktree path is left alone
+	os.MkdirAll(path, 0o755)
+	if _, err := client.CheckoutPullRequest(ctx, opts); err == nil {
+		t.Error("Expected an error for a directory that isn't a worktree")
+	}
+	os.RemoveAll(path)
+
+	// So is a worktree of this repository on another branch
+	if _, err := client.run(ctx, "worktree", "add", "-b", "other", path, "main"); err != nil {
+		t.Fatalf("Creating the worktree failed: %v", err)
+	}
+	if _, err := client.CheckoutPullRequest(ctx, opts); err == nil {
+		t.Error("Expected an error for a worktree on another branch")
+	}
+	if branch, _ := NewClient(path).CurrentBranch(ctx); branch != "other" {
+		t.Errorf("Expected the worktree to stay on other, got %q", branch)
+	}
+	content, _ := os.ReadFile(filepath.Join(path, "README"))
+	if string(content) != "base\n" {
+		t.Errorf("Expected nothing merged into the other worktree, got %q", content)
+	}
+
+	// Checking out into this PR's own worktree again fast-forwards it
+	os.RemoveAll(path)
+	client.run(ctx, "worktree", "prune")
+	if _, err := client.CheckoutPullRequest(ctx, opts); err != nil {
+		t.Fatalf("First checkout failed: %v", err)
+	}
+	if _, err := client.CheckoutPullRequest(ctx, opts); err != nil {
+		t.Errorf("Expected the PR's own worktree to be reused, got %v", err)
+	}
+}
+
+func TestCheckoutPullRequestUnknownRepo(t *testing.T) {
+	clone := setupClone(t)
+
+	_, err := NewClient(clone).CheckoutPullRequest(context.Background(), CheckoutOptions{
+		RepoName: "someone/else",
+		Number:   7,
+		Branch:   "feature",
+	})
+	if err == nil {
+		t.Error("Expected error when no remote matches the PR repository")
+	}
+}
@@ -0,0 +1,113 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ErrNotRepository is returned when the working directory is not inside a git clone
+var ErrNotRepository = errors.New("current directory is not a git repository")
+
+// ErrDirtyWorktree is returned when a checkout would clobber uncommitted changes
+var ErrDirtyWorktree = errors.New("working tree has uncommitted changes; commit or stash them first")
+
+// ErrDiverged is returned when a local branch has commits the pull request
+// doesn't, so it can't be fast-forwarded to it
+var ErrDiverged = errors.New("local branch has diverged from the pull request; rename or reset it first")
+
+// Client runs git commands against a local working directory
+type Client struct {
+	dir string
+}
+
+// NewClient creates a git client for dir (empty means the process working directory)
+func NewClient(dir string) *Client {
+	return &Client{dir: dir}
+}
+
+// run executes git with the given arguments and returns trimmed stdout
+func (c *Client) run(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = c.dir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], msg)
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// IsRepository reports whether the working directory is inside a git work tree
+func (c *Client) IsRepository(ctx context.Context) bool {
+	out, err := c.run(ctx, "rev-parse", "--is-inside-work-tree")
+	return err == nil && out == "true"
+}
+
+// IsDirty reports whether the work tree has staged or unstaged changes to tracked files
+func (c *Client) IsDirty(ctx context.Context) (bool, error) {
+	out, err := c.run(ctx, "status", "--porcelain", "--untracked-files=no")
+	if err != nil {
+		return false, err
+	}
+	return out != "", nil
+}
+
+// CurrentBranch returns the checked out branch name, or "" when HEAD is detached
+func (c *Client) CurrentBranch(ctx context.Context) (string, error) {
+	out, err := c.run(ctx, "symbolic-ref", "--quiet", "--short", "HEAD")
+	if err != nil {
+		return "", nil
+	}
+	return out, nil
+}
+
+// HasBranch reports whether a local branch exists
+func (c *Client) HasBranch(ctx context.Context, branch string) bool {
+	_, err := c.run(ctx, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+	return err == nil
+}
+
+// Remotes returns the configured remotes with their URLs as written in git config
+// (before any url.<base>.insteadOf rewriting, which `git remote -v` applies)
+func (c *Client) Remotes(ctx context.Context) ([]Remote, error) {
+	out, err := c.run(ctx, "config", "--get-regexp", `^remote\..*\.url$`)
+	if err != nil {
+		// git config exits 1 when nothing matches, i.e. no remotes
+		if c.IsRepository(ctx) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return parseRemotes(out), nil
+}
+
+// CurrentRepository resolves the GitHub repository of the clone in the working
+// directory from its remotes on hosts
+func (c *Client) CurrentRepository(ctx context.Context, hosts []string) (Remote, error) {
+	if !c.IsRepository(ctx) {
+		return Remote{}, ErrNotRepository
+	}
+
+	remotes, err := c.Remotes(ctx)
+	if err != nil {
+		return Remote{}, err
+	}
+
+	remote, ok := ResolveRepository(remotes, hosts)
+	if !ok {
+		return Remote{}, fmt.Errorf("no remote in this clone points at a GitHub repository")
+	}
+	return remote, nil
+}
@@ -0,0 +1,159 @@
+package git
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Remote represents a git remote pointing at a GitHub repository
+type Remote struct {
+	Name  string
+	URL   string
+	Host  string
+	Owner string
+	Repo  string
+}
+
+// FullName returns the owner/repo form of the remote's repository
+func (r Remote) FullName() string {
+	return r.Owner + "/" + r.Repo
+}
+
+// parseRemotes parses `git config --get-regexp remote.*.url` output
+// ("remote.<name>.url <url>" per line)
+func parseRemotes(output string) []Remote {
+	var remotes []Remote
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+
+		name := strings.TrimSuffix(strings.TrimPrefix(fields[0], "remote."), ".url")
+		remote := Remote{Name: name, URL: fields[1]}
+		if host, owner, repo, err := ParseRemoteURL(fields[1]); err == nil {
+			remote.Host = host
+			remote.Owner = owner
+			remote.Repo = repo
+		}
+		remotes = append(remotes, remote)
+	}
+	return remotes
+}
+
+// ParseRemoteURL extracts host, owner and repository name from a git remote URL.
+// It understands https://, ssh://, git:// and scp-like (git@host:owner/repo) forms.
+func ParseRemoteURL(rawURL string) (host, owner, repo string, err error) {
+	rawURL = strings.TrimSpace(rawURL)
+
+	var path string
+	if strings.Contains(rawURL, "://") {
+		u, parseErr := url.Parse(rawURL)
+		if parseErr != nil {
+			return "", "", "", fmt.Errorf("invalid remote URL %q: %w", rawURL, parseErr)
+		}
+		host = u.Hostname()
+		path = u.Path
+	} else if at := strings.Index(rawURL, ":"); at > 0 {
+		// scp-like syntax: [user@]host:owner/repo.git
+		host = rawURL[:at]
+		if i := strings.LastIndex(host, "@"); i >= 0 {
+			host = host[i+1:]
+		}
+		path = rawURL[at+1:]
+	} else {
+		return "", "", "", fmt.Errorf("unsupported remote URL %q", rawURL)
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 || parts[len(parts)-2] == "" || parts[len(parts)-1] == "" {
+		return "", "", "", fmt.Errorf("remote URL %q does not name an owner/repo", rawURL)
+	}
+
+	return normalizeHost(host), parts[len(parts)-2], parts[len(parts)-1], nil
+}
+
+// normalizeHost maps alternate GitHub SSH/web hostnames onto the canonical host
+func normalizeHost(host string) string {
+	host = strings.ToLower(host)
+	switch host {
+	case "ssh.github.com", "www.github.com":
+		return "github.com"
+	}
+	return host
+}
+
+// remotePreference orders remote names when guessing the base repository:
+// in a fork clone "upstream" is where the PRs live
+var remotePreference = []string{"upstream", "github", "origin"}
+
+// ResolveRepository picks the remote that most likely identifies the repository
+// whose pull requests the user wants to see. Only remotes on one of hosts (the
+// hosts known to serve GitHub) count; the rest, e.g. a mirror on another forge,
+// are skipped.
+func ResolveRepository(remotes []Remote, hosts []string) (Remote, bool) {
+	onGitHub := func(remote Remote) bool {
+		if remote.Owner == "" {
+			return false
+		}
+		for _, host := range hosts {
+			if strings.EqualFold(remote.Host, normalizeHost(host)) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, name := range remotePreference {
+		for _, remote := range remotes {
+			if remote.Name == name && onGitHub(remote) {
+				return remote, true
+			}
+		}
+	}
+	for _, remote := range remotes {
+		if onGitHub(remote) {
+			return remote, true
+		}
+	}
+	return Remote{}, false
+}
+
+// FindRemote returns the first remote whose repository matches owner/repo
+func FindRemote(remotes []Remote, fullName string) (Remote, bool) {
+	for _, remote := range remotes {
+		if strings.EqualFold(remote.FullName(), fullName) {
+			return remote, true
+		}
+	}
+	return Remote{}, false
+}
+
+// ParseRepository parses a repository argument with gh's --repo semantics:
+// OWNER/REPO, HOST/OWNER/REPO, or a repository URL. defaultHost applies to the
+// two-part form.
+func ParseRepository(spec, defaultHost string) (Remote, error) {
+	if strings.Contains(spec, "://") || strings.HasPrefix(spec, "git@") {
+		host, owner, repo, err := ParseRemoteURL(spec)
+		if err != nil {
+			return Remote{}, err
+		}
+		return Remote{Host: host, Owner: owner, Repo: repo}, nil
+	}
+
+	parts := strings.Split(spec, "/")
+	for _, part := range parts {
+		if part == "" {
+			return Remote{}, fmt.Errorf("expected the \"[HOST/]OWNER/REPO\" format, got %q", spec)
+		}
+	}
+
+	switch len(parts) {
+	case 2:
+		return Remote{Host: normalizeHost(defaultHost), Owner: parts[0], Repo: parts[1]}, nil
+	case 3:
+		return Remote{Host: normalizeHost(parts[0]), Owner: parts[1], Repo: parts[2]}, nil
+	}
+	return Remote{}, fmt.Errorf("expected the \"[HOST/]OWNER/REPO\" format, got %q", spec)
+}
@@ -0,0 +1,141 @@
+package git
+
+import "testing"
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		url   string
+		host  string
+		owner string
+		repo  string
+	}{
+		{"https://github.com/will-wright-eng/gh-nav.git", "github.com", "will-wright-eng", "gh-nav"},
+		{"https://github.com/will-wright-eng/gh-nav", "github.com", "will-wright-eng", "gh-nav"},
+		{"git@github.com:will-wright-eng/gh-nav.git", "github.com", "will-wright-eng", "gh-nav"},
+		{"ssh://git@github.com/will-wright-eng/gh-nav.git", "github.com", "will-wright-eng", "gh-nav"},
+		{"ssh://git@GHE.example.com:2222/team/service.git", "ghe.example.com", "team", "service"},
+		{"https://user@ghe.example.com/team/service/", "ghe.example.com", "team", "service"},
+		{"ssh://git@ssh.github.com:443/org/repo.git", "github.com", "org", "repo"},
+	}
+
+	for _, test := range tests {
+		host, owner, repo, err := ParseRemoteURL(test.url)
+		if err != nil {
+			t.Errorf("ParseRemoteURL(%q) returned error: %v", test.url, err)
+			continue
+		}
+		if host != test.host || owner != test.owner || repo != test.repo {
+			t.Errorf("ParseRemoteURL(%q) = %s %s/%s, expected %s %s/%s",
+				test.url, host, owner, repo, test.host, test.owner, test.repo)
+		}
+	}
+
+	for _, bad := range []string{"", "not-a-url", "https://github.com/only-owner"} {
+		if _, _, _, err := ParseRemoteURL(bad); err == nil {
+			t.Errorf("Expected error for %q", bad)
+		}
+	}
+}
+
+func TestParseRemotes(t *testing.T) {
+	output := "remote.origin.url git@github.com:me/fork.git\n" +
+		"remote.upstream.url https://github.com/org/project.git\n"
+
+	remotes := parseRemotes(output)
+	if len(remotes) != 2 {
+		t.Fatalf("Expected 2 remotes, got %d", len(remotes))
+	}
+
+	remote, ok := FindRemote(remotes, "Org/Project")
+	if !ok {
+		t.Fatal("Expected to find upstream remote")
+	}
+	if remote.Name != "upstream" {
+		t.Errorf("Expected upstream, got %s", remote.Name)
+	}
+
+	if _, ok := FindRemote(remotes, "org/other"); ok {
+		t.Error("Expected no remote for org/other")
+	}
+}
+
+func TestResolveRepository(t *testing.T) {
+	hosts := []string{"github.com"}
+	remotes := []Remote{
+		{Name: "mine", Host: "github.com", Owner: "me", Repo: "project"},
+		{Name: "origin", Host: "github.com", Owner: "me", Repo: "project"},
+		{Name: "upstream", Host: "github.com", Owner: "org", Repo: "project"},
+	}
+
+	remote, ok := ResolveRepository(remotes, hosts)
+	if !ok || remote.Name != "upstream" {
+		t.Errorf("Expected upstream to be preferred, got %+v", remote)
+	}
+
+	remote, ok = ResolveRepository(remotes[:2], hosts)
+	if !ok || remote.Name != "origin" {
+		t.Errorf("Expected origin without upstream, got %+v", remote)
+	}
+
+	remote, ok = ResolveRepository(remotes[:1], hosts)
+	if !ok || remote.Name != "mine" {
+		t.Errorf("Expected fallback to any GitHub remote, got %+v", remote)
+	}
+
+	if _, ok := ResolveRepository([]Remote{{Name: "origin", URL: "/local/path"}}, hosts); ok {
+		t.Error("Expected no repository for a non-GitHub remote")
+	}
+
+	// Remotes on other forges are skipped, even a preferred "upstream"
+	mixed := []Remote{
+		{Name: "origin", Host: "github.com", Owner: "me", Repo: "project"},
+		{Name: "upstream", Host: "gitlab.com", Owner: "org", Repo: "project"},
+	}
+	remote, ok = ResolveRepository(mixed, hosts)
+	if !ok || remote.Name != "origin" {
+		t.Errorf("Expected the GitHub origin over a gitlab upstream, got %+v", remote)
+	}
+	if _, ok := ResolveRepository(mixed[1:], hosts); ok {
+		t.Error("Expected no repository when the only remote is on gitlab.com")
+	}
+
+	// Enterprise hosts count once known
+	enterprise := []Remote{{Name: "origin", Host: "ghe.example.com", Owner: "org", Repo: "project"}}
+	if _, ok := ResolveRepository(enterprise, hosts); ok {
+		t.Error("Expected an unknown host to be skipped")
+	}
+	if _, ok := ResolveRepository(enterprise, append(hosts, "GHE.example.com")); !ok {
+		t.Error("Expected a known enterprise host to be accepted")
+	}
+}
+
+func TestParseRepository(t *testing.T) {
+	tests := []struct {
+		spec     string
+		host     string
+		fullName string
+	}{
+		{"cli/cli", "github.com", "cli/cli"},
+		{"ghe.example.com/team/service", "ghe.example.com", "team/service"},
+		{"https://github.com/cli/cli", "github.com", "cli/cli"},
+		{"git@ghe.example.com:team/service.git", "ghe.example.com", "team/service"},
+	}
+
+	for _, test := range tests {
+		remote, err := ParseRepository(test.spec, "github.com")
+		if err != nil {
+			t.Errorf("ParseRepository(%q) returned error: %v", test.spec, err)
+			continue
+		}
+		if remote.Host != test.host || remote.FullName() != test.fullName {
+			t.Errorf("ParseRepository(%q) = %s %s, expected %s %s",
+				test.spec, remote.Host, remote.FullName(), test.host, test.fullName)
+		}
+	}
+
+	for _, bad := range []string{"cli", "cli/", "/cli", "a/b/c/d"} {
+		if _, err := ParseRepository(bad, "github.com"); err == nil {
+			t.Errorf("Expected error for %q", bad)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package models
+
+// Bulk actions that can be applied to several pull requests at once
+const (
+	BulkApprove         = "approve"
+	BulkMerge           = "merge"
+	BulkClose           = "close"
+	BulkAddLabel        = "label"
+	BulkRequestReviewer = "reviewer"
+	BulkComment         = "comment"
+)
+
+// BulkActions lists the bulk actions in the order they are offered
+var BulkActions = []string{BulkApprove, BulkMerge, BulkClose, BulkAddLabel, BulkRequestReviewer, BulkComment}
+
+// BulkActionLabel returns a human-readable name for a bulk action
+func BulkActionLabel(action string) string {
+	switch action {
+	case BulkApprove:
+		return "Approve"
+	case BulkMerge:
+		return "Merge"
+	case BulkClose:
+		return "Close"
+	case BulkAddLabel:
+		return "Add label"
+	case BulkRequestReviewer:
+		return "Request reviewer"
+	case BulkComment:
+		return "Comment"
+	}
+	return action
+}
+
+// BulkActionPrompt returns what to ask for before running an action, or "" if it
+// needs no input
+func BulkActionPrompt(action string) string {
+	switch action {
+	case BulkAddLabel:
+		return "Label"
+	case BulkRequestReviewer:
+		return "Reviewer (login or org/team)"
+	case BulkComment:
+		return "Comment"
+	}
+	return ""
+}
+
+// ApplyBulkAction updates a pull request to reflect an action that succeeded
+func (p *PullRequest) ApplyBulkAction(action, arg string) {
+	switch action {
+	case BulkMerge:
+		p.State = PRStateMerged
+	case BulkClose:
+		p.State = PRStateClosed
+	case BulkAddLabel:
+		p.Labels = appendMissing(p.Labels, arg)
+	case BulkRequestReviewer:
+		p.Reviewers = appendMissing(p.Reviewers, arg)
+	case BulkComment:
+		p.Comments++
+	}
+}
+
+// appendMissing appends a value unless it is already present
+func appendMissing(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -0,0 +1,137 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/go-github/v58/github"
+)
+
+// CI status values, rolled up across all checks of a commit
+const (
+	CIStatusSuccess = "success"
+	CIStatusFailure = "failure"
+	CIStatusPending = "pending"
+	CIStatusNeutral = "neutral"
+	CIStatusNone    = "none"
+)
+
+// Check kinds
+const (
+	CheckKindRun    = "check_run"
+	CheckKindStatus = "status"
+)
+
+// Check represents a single CI result on a commit: a check run or a commit status
+type Check struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Kind        string    `json:"kind"`       // check_run, status
+	App         string    `json:"app"`        // e.g. github-actions; empty for statuses
+	Status      string    `json:"status"`     // queued, in_progress, completed
+	Conclusion  string    `json:"conclusion"` // success, failure, neutral, cancelled, skipped, timed_out, action_required
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+	URL         string    `json:"url"`
+}
+
+// CIStatus maps the check onto one of the CIStatus values
+func (c *Check) CIStatus() string {
+	if c.Status != "completed" {
+		return CIStatusPending
+	}
+
+	switch c.Conclusion {
+	case "success":
+		return CIStatusSuccess
+	case "failure", "error", "timed_out", "cancelled", "action_required", "startup_failure":
+		return CIStatusFailure
+	default: // neutral, skipped, stale
+		return CIStatusNeutral
+	}
+}
+
+// Duration returns how long the check ran, or has been running so far
+func (c *Check) Duration(now time.Time) time.Duration {
+	if c.StartedAt.IsZero() {
+		return 0
+	}
+	end := c.CompletedAt
+	if end.IsZero() {
+		end = now
+	}
+	return end.Sub(c.StartedAt)
+}
+
+// IsActionsJob reports whether the check run is a GitHub Actions job (whose
+// check run ID doubles as the job ID)
+func (c *Check) IsActionsJob() bool {
+	return c.Kind == CheckKindRun && c.App == "github-actions"
+}
+
+// AggregateCIStatus rolls up checks the way GitHub's status rollup does:
+// any failure wins, then anything still running, then success
+func AggregateCIStatus(checks []*Check) string {
+	if len(checks) == 0 {
+		return CIStatusNone
+	}
+
+	counts := make(map[string]int)
+	for _, check := range checks {
+		counts[check.CIStatus()]++
+	}
+
+	switch {
+	case counts[CIStatusFailure] > 0:
+		return CIStatusFailure
+	case counts[CIStatusPending] > 0:
+		return CIStatusPending
+	case counts[CIStatusSuccess] > 0:
+		return CIStatusSuccess
+	}
+	return CIStatusNeutral
+}
+
+// FromGitHubCheckRun converts a GitHub check run to our model
+func FromGitHubCheckRun(run *github.CheckRun) *Check {
+	check := &Check{
+		ID:         run.GetID(),
+		Name:       run.GetName(),
+		Kind:       CheckKindRun,
+		Status:     run.GetStatus(),
+		Conclusion: run.GetConclusion(),
+		URL:        run.GetHTMLURL(),
+	}
+	if run.App != nil {
+		check.App = run.App.GetSlug()
+	}
+	if run.StartedAt != nil {
+		check.StartedAt = run.StartedAt.Time
+	}
+	if run.CompletedAt != nil {
+		check.CompletedAt = run.CompletedAt.Time
+	}
+	return check
+}
+
+// FromGitHubStatus converts a GitHub commit status to our model
+func FromGitHubStatus(status *github.RepoStatus) *Check {
+	check := &Check{
+		ID:         status.GetID(),
+		Name:       status.GetContext(),
+		Kind:       CheckKindStatus,
+		Status:     "completed",
+		Conclusion: status.GetState(),
+		URL:        status.GetTargetURL(),
+	}
+	if check.Conclusion == "pending" {
+		check.Status = "in_progress"
+		check.Conclusion = ""
+	}
+	if status.CreatedAt != nil {
+		check.StartedAt = status.CreatedAt.Time
+	}
+	if status.UpdatedAt != nil && check.Status == "completed" {
+		check.CompletedAt = status.UpdatedAt.Time
+	}
+	return check
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v58/github"
+)
+
+func TestAggregateCIStatus(t *testing.T) {
+	success := &Check{Status: "completed", Conclusion: "success"}
+	failure := &Check{Status: "completed", Conclusion: "failure"}
+	skipped := &Check{Status: "completed", Conclusion: "skipped"}
+	running := &Check{Status: "in_progress"}
+
+	tests := []struct {
+		name     string
+		checks   []*Check
+		expected string
+	}{
+		{"no checks", nil, CIStatusNone},
+		{"all passed", []*Check{success, success}, CIStatusSuccess},
+		{"failure wins", []*Check{success, running, failure}, CIStatusFailure},
+		{"running", []*Check{success, running}, CIStatusPending},
+		{"skipped ignored", []*Check{success, skipped}, CIStatusSuccess},
+		{"only skipped", []*Check{skipped}, CIStatusNeutral},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AggregateCIStatus(tt.checks); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCheckDuration(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := start.Add(10 * time.Minute)
+
+	completed := &Check{StartedAt: start, CompletedAt: start.Add(90 * time.Second)}
+	if got := completed.Duration(now); got != 90*time.Second {
+		t.Errorf("Expected 1m30s for a completed check, got %s", got)
+	}
+
+	running := &Check{StartedAt: start}
+	if got := running.Duration(now); got != 10*time.Minute {
+		t.Errorf("Expected 10m for a running check, got %s", got)
+	}
+
+	queued := &Check{}
+	if got := queued.Duration(now); got != 0 {
+		t.Errorf("Expected 0 for a queued check, got %s", got)
+	}
+}
+
+func TestFromGitHubStatus(t *testing.T) {
+	pending := FromGitHubStatus(&github.RepoStatus{
+		Context: github.String("ci/jenkins"),
+		State:   github.String("pending"),
+	})
+	if pending.Status != "in_progress" || pending.CIStatus() != CIStatusPending {
+		t.Errorf("Expected pending status to be in progress, got %s/%s", pending.Status, pending.CIStatus())
+	}
+
+	errored := FromGitHubStatus(&github.RepoStatus{State: github.String("error")})
+	if errored.CIStatus() != CIStatusFailure {
+		t.Errorf("Expected error status to be a failure, got %s", errored.CIStatus())
+	}
+}
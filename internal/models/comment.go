@@ -0,0 +1,203 @@
+package models
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v58/github"
+)
+
+// Comment types
+const (
+	CommentTypeComment       = "comment"        // top-level conversation comment
+	CommentTypeReview        = "review"         // body of a submitted review
+	CommentTypeReviewComment = "review_comment" // inline comment on a diff line
+)
+
+// ConversationThreadID is the ID of the pseudo-thread holding the PR's top-level
+// conversation (issue comments and review bodies)
+const ConversationThreadID = "conversation"
+
+// Comment represents a comment on a pull request
+type Comment struct {
+	ID        int64     `json:"id"`
+	PRID      int64     `json:"pr_id"`
+	ThreadID  string    `json:"thread_id"` // For grouping related comments
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	Type      string    `json:"type"`      // review, comment, review_comment
+	FilePath  string    `json:"file_path"` // For review comments
+	Line      int       `json:"line"`      // For review comments
+	Resolved  bool      `json:"resolved"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Review comment details
+	InReplyTo   int64  `json:"in_reply_to,omitempty"`
+	Side        string `json:"side,omitempty"`         // LEFT (old) or RIGHT (new) side of the diff
+	ReviewState string `json:"review_state,omitempty"` // for review bodies: APPROVED, CHANGES_REQUESTED, COMMENTED
+	URL         string `json:"url"`
+}
+
+// Thread is a group of related comments: a review comment with its replies, or
+// the PR's top-level conversation
+type Thread struct {
+	ID        string     `json:"id"`
+	NodeID    string     `json:"node_id,omitempty"` // GraphQL ID of the review thread
+	FilePath  string     `json:"file_path"`
+	StartLine int        `json:"start_line"`
+	Line      int        `json:"line"`
+	Resolved  bool       `json:"resolved"`
+	Outdated  bool       `json:"outdated"`
+	Comments  []*Comment `json:"comments"`
+}
+
+// ThreadState is the resolution state of a review thread, which only the GraphQL
+// API exposes
+type ThreadState struct {
+	NodeID    string
+	Resolved  bool
+	Outdated  bool
+	StartLine int
+	Line      int
+}
+
+// IsConversation reports whether the thread is the top-level conversation
+func (t *Thread) IsConversation() bool {
+	return t.ID == ConversationThreadID
+}
+
+// CountUnresolved returns the number of unresolved review threads
+func CountUnresolved(threads []*Thread) int {
+	count := 0
+	for _, thread := range threads {
+		if !thread.IsConversation() && !thread.Resolved {
+			count++
+		}
+	}
+	return count
+}
+
+// GroupThreads builds threads from a PR's comments. Review comments are grouped
+// under the comment they reply to, and their resolution comes from states (keyed
+// by the root comment ID). Everything else goes into the conversation thread.
+// The conversation comes first, then review threads ordered by file and line.
+func GroupThreads(comments []*Comment, states map[int64]ThreadState) []*Thread {
+	conversation := &Thread{ID: ConversationThreadID}
+	byRoot := make(map[int64]*Thread)
+	var reviewThreads []*Thread
+
+	// Roots first, so replies always find their thread
+	sorted := make([]*Comment, len(comments))
+	copy(sorted, comments)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	for _, comment := range sorted {
+		if comment.Type != CommentTypeReviewComment {
+			comment.ThreadID = ConversationThreadID
+			conversation.Comments = append(conversation.Comments, comment)
+			continue
+		}
+
+		if thread, ok := byRoot[comment.InReplyTo]; ok && comment.InReplyTo != 0 {
+			comment.ThreadID = thread.ID
+			comment.Resolved = thread.Resolved
+			thread.Comments = append(thread.Comments, comment)
+			byRoot[comment.ID] = thread
+			continue
+		}
+
+		thread := &Thread{
+			ID:       strconv.FormatInt(comment.ID, 10),
+			FilePath: comment.FilePath,
+			Line:     comment.Line,
+			Comments: []*Comment{comment},
+		}
+		if state, ok := states[comment.ID]; ok {
+			thread.NodeID = state.NodeID
+			thread.Resolved = state.Resolved
+			thread.Outdated = state.Outdated
+			thread.StartLine = state.StartLine
+			if state.Line != 0 {
+				thread.Line = state.Line
+			}
+		}
+		if thread.StartLine == 0 {
+			thread.StartLine = thread.Line
+		}
+		comment.ThreadID = thread.ID
+		comment.Resolved = thread.Resolved
+		byRoot[comment.ID] = thread
+		reviewThreads = append(reviewThreads, thread)
+	}
+
+	sort.SliceStable(reviewThreads, func(i, j int) bool {
+		if reviewThreads[i].FilePath != reviewThreads[j].FilePath {
+			return reviewThreads[i].FilePath < reviewThreads[j].FilePath
+		}
+		return reviewThreads[i].StartLine < reviewThreads[j].StartLine
+	})
+
+	threads := make([]*Thread, 0, len(reviewThreads)+1)
+	if len(conversation.Comments) > 0 {
+		threads = append(threads, conversation)
+	}
+	return append(threads, reviewThreads...)
+}
+
+// FromGitHubIssueComment converts a top-level PR comment to our model
+func FromGitHubIssueComment(comment *github.IssueComment, prID int64) *Comment {
+	return &Comment{
+		ID:        comment.GetID(),
+		PRID:      prID,
+		Author:    comment.GetUser().GetLogin(),
+		Body:      comment.GetBody(),
+		Type:      CommentTypeComment,
+		CreatedAt: comment.GetCreatedAt().Time,
+		URL:       comment.GetHTMLURL(),
+	}
+}
+
+// FromGitHubReviewComment converts an inline review comment to our model
+func FromGitHubReviewComment(comment *github.PullRequestComment, prID int64) *Comment {
+	line := comment.GetLine()
+	if line == 0 {
+		// Outdated comments no longer map onto the current diff
+		line = comment.GetOriginalLine()
+	}
+	return &Comment{
+		ID:        comment.GetID(),
+		PRID:      prID,
+		Author:    comment.GetUser().GetLogin(),
+		Body:      comment.GetBody(),
+		Type:      CommentTypeReviewComment,
+		FilePath:  comment.GetPath(),
+		Line:      line,
+		CreatedAt: comment.GetCreatedAt().Time,
+		InReplyTo: comment.GetInReplyTo(),
+		Side:      comment.GetSide(),
+		URL:       comment.GetHTMLURL(),
+	}
+}
+
+// FromGitHubReview converts a submitted review to a conversation comment. It
+// returns nil for reviews without a body or verdict worth showing (the inline
+// comments of a plain "comment" review appear in their own threads).
+func FromGitHubReview(review *github.PullRequestReview, prID int64) *Comment {
+	state := review.GetState()
+	if review.GetBody() == "" && state != "APPROVED" && state != "CHANGES_REQUESTED" {
+		return nil
+	}
+	return &Comment{
+		ID:          review.GetID(),
+		PRID:        prID,
+		Author:      review.GetUser().GetLogin(),
+		Body:        review.GetBody(),
+		Type:        CommentTypeReview,
+		CreatedAt:   review.GetSubmittedAt().Time,
+		ReviewState: state,
+		URL:         review.GetHTMLURL(),
+	}
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGroupThreads(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	comments := []*Comment{
+		{ID: 4, Type: CommentTypeReviewComment, InReplyTo: 1, FilePath: "b.go", Line: 10, CreatedAt: base.Add(4 * time.Minute)},
+		{ID: 1, Type: CommentTypeReviewComment, FilePath: "b.go", Line: 10, CreatedAt: base.Add(time.Minute)},
+		{ID: 2, Type: CommentTypeReviewComment, FilePath: "a.go", Line: 3, CreatedAt: base.Add(2 * time.Minute)},
+		{ID: 3, Type: CommentTypeComment, CreatedAt: base.Add(3 * time.Minute)},
+		{ID: 5, Type: CommentTypeReview, ReviewState: "APPROVED", CreatedAt: base.Add(5 * time.Minute)},
+	}
+	states := map[int64]ThreadState{
+		1: {NodeID: "T_1", Resolved: true, StartLine: 8, Line: 10},
+		2: {NodeID: "T_2"},
+	}
+
+	threads := GroupThreads(comments, states)
+	if len(threads) != 3 {
+		t.Fatalf("Expected 3 threads, got %d", len(threads))
+	}
+
+	conversation := threads[0]
+	if !conversation.IsConversation() || len(conversation.Comments) != 2 {
+		t.Errorf("Expected the conversation first with 2 comments, got %s with %d", conversation.ID, len(conversation.Comments))
+	}
+
+	if threads[1].FilePath != "a.go" || threads[2].FilePath != "b.go" {
+		t.Errorf("Expected review threads ordered by file, got %s, %s", threads[1].FilePath, threads[2].FilePath)
+	}
+
+	resolved := threads[2]
+	if !resolved.Resolved || resolved.NodeID != "T_1" || resolved.StartLine != 8 {
+		t.Errorf("Expected thread state to be applied, got %+v", resolved)
+	}
+	if len(resolved.Comments) != 2 || resolved.Comments[1].ID != 4 || !resolved.Comments[1].Resolved {
+		t.Error("Expected the reply to join its thread in order and inherit its resolution")
+	}
+
+	if got := CountUnresolved(threads); got != 1 {
+		t.Errorf("Expected 1 unresolved thread, got %d", got)
+	}
+}
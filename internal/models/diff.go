@@ -0,0 +1,135 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v58/github"
+)
+
+// Diff line kinds
+const (
+	DiffLineContext = "context"
+	DiffLineAdded   = "added"
+	DiffLineRemoved = "removed"
+	DiffLineNote    = "note" // "\ No newline at end of file"
+)
+
+// FileChange is one file changed by a pull request
+type FileChange struct {
+	Filename         string  `json:"filename"`
+	PreviousFilename string  `json:"previous_filename,omitempty"` // for renames
+	Status           string  `json:"status"`                      // added, removed, modified, renamed, copied, changed, unchanged
+	Additions        int     `json:"additions"`
+	Deletions        int     `json:"deletions"`
+	Patch            string  `json:"-"`
+	Hunks            []*Hunk `json:"-"`
+}
+
+// Hunk is one @@ section of a unified diff
+type Hunk struct {
+	Header   string // the full @@ line, including any function context
+	OldStart int
+	OldLines int
+	NewStart int
+	NewLines int
+	Lines    []DiffLine
+}
+
+// DiffLine is one line of a hunk with its position in the old and new file
+// (0 when the line doesn't exist on that side)
+type DiffLine struct {
+	Kind    string
+	Text    string // without the leading +, - or space
+	OldLine int
+	NewLine int
+}
+
+// HasPatch reports whether GitHub returned a textual diff for the file (it omits
+// it for binary files and very large diffs)
+func (f *FileChange) HasPatch() bool {
+	return len(f.Hunks) > 0
+}
+
+// DisplayName returns the filename, showing the old name for renames
+func (f *FileChange) DisplayName() string {
+	if f.PreviousFilename != "" && f.PreviousFilename != f.Filename {
+		return f.PreviousFilename + " → " + f.Filename
+	}
+	return f.Filename
+}
+
+// ParsePatch parses the hunks of a unified diff patch as returned by the
+// pull request files endpoint (no file headers, starting at the first @@)
+func ParsePatch(patch string) []*Hunk {
+	var hunks []*Hunk
+	var hunk *Hunk
+	oldLine, newLine := 0, 0
+
+	for _, text := range strings.Split(strings.TrimSuffix(patch, "\n"), "\n") {
+		if strings.HasPrefix(text, "@@") {
+			hunk = &Hunk{Header: text}
+			parseHunkHeader(text, hunk)
+			oldLine, newLine = hunk.OldStart, hunk.NewStart
+			hunks = append(hunks, hunk)
+			continue
+		}
+		if hunk == nil {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(text, "+"):
+			hunk.Lines = append(hunk.Lines, DiffLine{Kind: DiffLineAdded, Text: text[1:], NewLine: newLine})
+			newLine++
+		case strings.HasPrefix(text, "-"):
+			hunk.Lines = append(hunk.Lines, DiffLine{Kind: DiffLineRemoved, Text: text[1:], OldLine: oldLine})
+			oldLine++
+		case strings.HasPrefix(text, `\`):
+			hunk.Lines = append(hunk.Lines, DiffLine{Kind: DiffLineNote, Text: text})
+		default:
+			hunk.Lines = append(hunk.Lines, DiffLine{Kind: DiffLineContext, Text: strings.TrimPrefix(text, " "), OldLine: oldLine, NewLine: newLine})
+			oldLine++
+			newLine++
+		}
+	}
+	return hunks
+}
+
+// parseHunkHeader reads the line ranges of "@@ -a,b +c,d @@ context"
+func parseHunkHeader(header string, hunk *Hunk) {
+	var ranges string
+	if fields := strings.SplitN(header, "@@", 3); len(fields) >= 2 {
+		ranges = strings.TrimSpace(fields[1])
+	}
+	for _, part := range strings.Fields(ranges) {
+		start, count := 0, 1
+		spec := part[1:]
+		if before, after, ok := strings.Cut(spec, ","); ok {
+			fmt.Sscanf(before, "%d", &start)
+			fmt.Sscanf(after, "%d", &count)
+		} else {
+			fmt.Sscanf(spec, "%d", &start)
+		}
+
+		switch part[0] {
+		case '-':
+			hunk.OldStart, hunk.OldLines = start, count
+		case '+':
+			hunk.NewStart, hunk.NewLines = start, count
+		}
+	}
+}
+
+// FromGitHubCommitFile converts a pull request file to our model, parsing its patch
+func FromGitHubCommitFile(file *github.CommitFile) *FileChange {
+	return &FileChange{
+		Filename:         file.GetFilename(),
+		PreviousFilename: file.GetPreviousFilename(),
+		Status:           file.GetStatus(),
+		Additions:        file.GetAdditions(),
+		Deletions:        file.GetDeletions(),
+		Patch:            file.GetPatch(),
+		Hunks:            ParsePatch(file.GetPatch()),
+	}
+}
@@ -0,0 +1,61 @@
+package models
+
+import "testing"
+
+const samplePatch = `@@ -1,4 +1,5 @@ package main
+ import "fmt"
+-func a() {}
++func a() int { return 1 }
++func b() {}
+ 
+ func main() {
+@@ -20,2 +21,2 @@ func main() {
+-	fmt.Println("x")
++	fmt.Println("y")
+\ No newline at end of file`
+
+func TestParsePatch(t *testing.T) {
+	hunks := ParsePatch(samplePatch)
+	if len(hunks) != 2 {
+		t.Fatalf("Expected 2 hunks, got %d", len(hunks))
+	}
+
+	first := hunks[0]
+	if first.OldStart != 1 || first.OldLines != 4 || first.NewStart != 1 || first.NewLines != 5 {
+		t.Errorf("Unexpected ranges for the first hunk: %+v", first)
+	}
+	if first.Header != "@@ -1,4 +1,5 @@ package main" {
+		t.Errorf("Expected the header to keep its context, got %q", first.Header)
+	}
+
+	expected := []DiffLine{
+		{Kind: DiffLineContext, Text: `import "fmt"`, OldLine: 1, NewLine: 1},
+		{Kind: DiffLineRemoved, Text: "func a() {}", OldLine: 2},
+		{Kind: DiffLineAdded, Text: "func a() int { return 1 }", NewLine: 2},
+		{Kind: DiffLineAdded, Text: "func b() {}", NewLine: 3},
+		{Kind: DiffLineContext, Text: "", OldLine: 3, NewLine: 4},
+		{Kind: DiffLineContext, Text: "func main() {", OldLine: 4, NewLine: 5},
+	}
+	if len(first.Lines) != len(expected) {
+		t.Fatalf("Expected %d lines, got %d", len(expected), len(first.Lines))
+	}
+	for i, want := range expected {
+		if first.Lines[i] != want {
+			t.Errorf("Line %d: expected %+v, got %+v", i, want, first.Lines[i])
+		}
+	}
+
+	second := hunks[1]
+	if second.OldStart != 20 || second.NewStart != 21 {
+		t.Errorf("Unexpected ranges for the second hunk: %+v", second)
+	}
+	if last := second.Lines[len(second.Lines)-1]; last.Kind != DiffLineNote {
+		t.Errorf("Expected the no-newline marker as a note, got %+v", last)
+	}
+}
+
+func TestParsePatchEmpty(t *testing.T) {
+	if hunks := ParsePatch(""); len(hunks) != 0 {
+		t.Errorf("Expected no hunks for an empty patch, got %d", len(hunks))
+	}
+}
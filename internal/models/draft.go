@@ -0,0 +1,54 @@
+package models
+
+import "fmt"
+
+// Draft kinds
+const (
+	DraftComment = "comment" // top-level PR comment
+	DraftReply   = "reply"   // reply to a review thread
+	DraftReview  = "review"  // review with a verdict
+	DraftClose   = "close"   // optional comment left when closing a PR
+)
+
+// Review events accepted by the API when submitting a review
+const (
+	ReviewEventComment        = "COMMENT"
+	ReviewEventApprove        = "APPROVE"
+	ReviewEventRequestChanges = "REQUEST_CHANGES"
+)
+
+// ReviewEvents lists the review events in the order they are offered
+var ReviewEvents = []string{ReviewEventComment, ReviewEventApprove, ReviewEventRequestChanges}
+
+// Draft is a comment, reply or review being written, before it is sent to GitHub
+type Draft struct {
+	Kind    string
+	PR      *PullRequest
+	ReplyTo int64   // root comment of the thread, for replies
+	Thread  *Thread // thread being replied to, for display
+	Event   string  // for reviews
+	Body    string
+}
+
+// Validate checks the draft against GitHub's rules: everything needs a body
+// except an approval and a closing comment
+func (d *Draft) Validate() error {
+	if d.Body != "" || d.Kind == DraftClose {
+		return nil
+	}
+	if d.Kind == DraftReview && d.Event == ReviewEventApprove {
+		return nil
+	}
+	return fmt.Errorf("%s body cannot be empty", d.Kind)
+}
+
+// ReviewEventLabel returns a human-readable name for a review event
+func ReviewEventLabel(event string) string {
+	switch event {
+	case ReviewEventApprove:
+		return "Approve"
+	case ReviewEventRequestChanges:
+		return "Request changes"
+	}
+	return "Comment"
+}
@@ -0,0 +1,28 @@
+package models
+
+import "testing"
+
+func TestDraftValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		draft   Draft
+		wantErr bool
+	}{
+		{"comment with body", Draft{Kind: DraftComment, Body: "LGTM"}, false},
+		{"empty comment", Draft{Kind: DraftComment}, true},
+		{"empty reply", Draft{Kind: DraftReply}, true},
+		{"approval without body", Draft{Kind: DraftReview, Event: ReviewEventApprove}, false},
+		{"request changes without body", Draft{Kind: DraftReview, Event: ReviewEventRequestChanges}, true},
+		{"review comment without body", Draft{Kind: DraftReview, Event: ReviewEventComment}, true},
+		{"close without comment", Draft{Kind: DraftClose}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.draft.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
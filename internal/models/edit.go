@@ -0,0 +1,62 @@
+package models
+
+import "strings"
+
+// Editable pull request fields
+const (
+	EditLabels    = "labels"
+	EditAssignees = "assignees"
+	EditReviewers = "reviewers"
+)
+
+// FieldValues returns the current values of an editable field
+func (p *PullRequest) FieldValues(field string) []string {
+	switch field {
+	case EditLabels:
+		return p.Labels
+	case EditAssignees:
+		return p.Assignees
+	case EditReviewers:
+		return p.Reviewers
+	}
+	return nil
+}
+
+// SetFieldValues replaces the values of an editable field
+func (p *PullRequest) SetFieldValues(field string, values []string) {
+	switch field {
+	case EditLabels:
+		p.Labels = values
+	case EditAssignees:
+		p.Assignees = values
+	case EditReviewers:
+		p.Reviewers = values
+	}
+}
+
+// DiffValues returns the values to add and remove to turn before into after
+func DiffValues(before, after []string) (add, remove []string) {
+	had := make(map[string]bool, len(before))
+	for _, v := range before {
+		had[v] = true
+	}
+	has := make(map[string]bool, len(after))
+	for _, v := range after {
+		has[v] = true
+		if !had[v] {
+			add = append(add, v)
+		}
+	}
+	for _, v := range before {
+		if !has[v] {
+			remove = append(remove, v)
+		}
+	}
+	return add, remove
+}
+
+// IsTeamReviewer reports whether a reviewer is a team ("org/team-slug") rather
+// than a user login
+func IsTeamReviewer(reviewer string) bool {
+	return strings.Contains(reviewer, "/")
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffValues(t *testing.T) {
+	add, remove := DiffValues([]string{"bug", "ui"}, []string{"ui", "deps", "ci"})
+
+	if !reflect.DeepEqual(add, []string{"deps", "ci"}) {
+		t.Errorf("Expected to add [deps ci], got %v", add)
+	}
+	if !reflect.DeepEqual(remove, []string{"bug"}) {
+		t.Errorf("Expected to remove [bug], got %v", remove)
+	}
+
+	if add, remove := DiffValues([]string{"a"}, []string{"a"}); add != nil || remove != nil {
+		t.Errorf("Expected no changes, got +%v -%v", add, remove)
+	}
+}
+
+func TestFieldValues(t *testing.T) {
+	pr := &PullRequest{}
+	pr.SetFieldValues(EditReviewers, []string{"alice", "acme/core"})
+
+	if !reflect.DeepEqual(pr.FieldValues(EditReviewers), []string{"alice", "acme/core"}) {
+		t.Errorf("Unexpected reviewers %v", pr.Reviewers)
+	}
+	if IsTeamReviewer("alice") || !IsTeamReviewer("acme/core") {
+		t.Error("Expected only org/slug reviewers to be teams")
+	}
+}
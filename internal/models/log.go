@@ -0,0 +1,183 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v58/github"
+)
+
+// Log line kinds
+const (
+	LogLineNormal  = "normal"
+	LogLineError   = "error"
+	LogLineWarning = "warning"
+	LogLineGroup   = "group"   // start of a collapsible section, usually a step
+	LogLineCommand = "command" // a command echoed by the runner
+)
+
+// Workflow command markers written by the Actions runner
+const (
+	logMarkerError    = "##[error]"
+	logMarkerWarning  = "##[warning]"
+	logMarkerGroup    = "##[group]"
+	logMarkerEndGroup = "##[endgroup]"
+	logMarkerCommand  = "[command]"
+)
+
+// errorPattern catches failures printed by tools that don't use ##[error]
+// (go test's "--- FAIL", "Error:", panics, ...)
+var errorPattern = regexp.MustCompile(`(?i)(^|\s)(error|fail(ed|ure)?|panic|fatal)(:|\s|$)|^--- FAIL`)
+
+// LogLine is a single line of a job log with the runner timestamp removed
+type LogLine struct {
+	Time time.Time
+	Text string
+	Kind string // normal, error, warning, group, command
+
+	// Annotation is set for lines written by ##[error]/##[warning] workflow
+	// commands, as opposed to error-looking tool output
+	Annotation bool
+}
+
+// JobStep is one step of an Actions job
+type JobStep struct {
+	Number     int64
+	Name       string
+	Status     string
+	Conclusion string
+}
+
+// JobLog is the log of an Actions job together with the job's step results
+type JobLog struct {
+	JobID      int64
+	RunID      int64
+	Name       string
+	Status     string
+	Conclusion string
+	URL        string
+	Steps      []*JobStep
+	Lines      []LogLine
+}
+
+// FromGitHubJob converts a GitHub workflow job to a JobLog without lines
+func FromGitHubJob(job *github.WorkflowJob) *JobLog {
+	log := &JobLog{
+		JobID:      job.GetID(),
+		RunID:      job.GetRunID(),
+		Name:       job.GetName(),
+		Status:     job.GetStatus(),
+		Conclusion: job.GetConclusion(),
+		URL:        job.GetHTMLURL(),
+	}
+	for _, step := range job.Steps {
+		log.Steps = append(log.Steps, &JobStep{
+			Number:     step.GetNumber(),
+			Name:       step.GetName(),
+			Status:     step.GetStatus(),
+			Conclusion: step.GetConclusion(),
+		})
+	}
+	return log
+}
+
+// ParseJobLog splits a raw Actions job log into lines, stripping timestamps and
+// classifying workflow command markers. ##[endgroup] lines are dropped.
+func ParseJobLog(raw string) []LogLine {
+	raw = strings.TrimPrefix(raw, "\ufeff")
+	raw = strings.ReplaceAll(raw, "\r\n", "\n")
+
+	var lines []LogLine
+	for _, text := range strings.Split(strings.TrimRight(raw, "\n"), "\n") {
+		line := LogLine{Kind: LogLineNormal}
+
+		// Each line starts with an RFC 3339 timestamp followed by a space
+		if stamp, rest, ok := strings.Cut(text, " "); ok {
+			if t, err := time.Parse(time.RFC3339Nano, stamp); err == nil {
+				line.Time = t
+				text = rest
+			}
+		}
+
+		switch {
+		case strings.HasPrefix(text, logMarkerEndGroup):
+			continue
+		case strings.HasPrefix(text, logMarkerGroup):
+			line.Kind = LogLineGroup
+			text = strings.TrimPrefix(text, logMarkerGroup)
+		case strings.HasPrefix(text, logMarkerError):
+			line.Kind = LogLineError
+			line.Annotation = true
+			text = "Error: " + strings.TrimPrefix(text, logMarkerError)
+		case strings.HasPrefix(text, logMarkerWarning):
+			line.Kind = LogLineWarning
+			line.Annotation = true
+			text = "Warning: " + strings.TrimPrefix(text, logMarkerWarning)
+		case strings.HasPrefix(text, logMarkerCommand):
+			line.Kind = LogLineCommand
+			text = strings.TrimPrefix(text, logMarkerCommand)
+		case errorPattern.MatchString(text):
+			line.Kind = LogLineError
+		}
+
+		line.Text = text
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// FailedStep returns the first step that failed, or nil
+func (l *JobLog) FailedStep() *JobStep {
+	for _, step := range l.Steps {
+		if step.Conclusion == "failure" {
+			return step
+		}
+	}
+	return nil
+}
+
+// FocusLine returns the index of the line a viewer should open at: the start of
+// the step section holding the first ##[error] annotation (the runner writes one
+// when a step fails), then that of the first error-looking line, then the failed
+// step's section by name. It returns 0 when nothing failed.
+func (l *JobLog) FocusLine() int {
+	for _, annotated := range []bool{true, false} {
+		for i, line := range l.Lines {
+			if line.Kind == LogLineError && (line.Annotation || !annotated) {
+				return l.sectionStart(i)
+			}
+		}
+	}
+
+	if step := l.FailedStep(); step != nil {
+		for i, line := range l.Lines {
+			if line.Kind == LogLineGroup && strings.Contains(line.Text, step.Name) {
+				return i
+			}
+		}
+	}
+	return 0
+}
+
+// sectionStart returns the index of the group line that opens the section
+// containing line i, or i itself outside any section
+func (l *JobLog) sectionStart(i int) int {
+	for j := i; j >= 0; j-- {
+		if l.Lines[j].Kind == LogLineGroup {
+			return j
+		}
+	}
+	return i
+}
+
+// ErrorLines returns the indexes of all error lines
+func (l *JobLog) ErrorLines() []int {
+	var indexes []int
+	for i, line := range l.Lines {
+		if line.Kind == LogLineError {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
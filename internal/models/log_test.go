@@ -0,0 +1,66 @@
+package models
+
+import "testing"
+
+const sampleJobLog = "\ufeff2024-01-15T10:00:00.0000000Z ##[group]Run actions/checkout@v4\r\n" +
+	"2024-01-15T10:00:01.0000000Z with:\r\n" +
+	"2024-01-15T10:00:02.0000000Z ##[endgroup]\r\n" +
+	"2024-01-15T10:00:03.0000000Z [command]/usr/bin/git fetch\r\n" +
+	"2024-01-15T10:00:04.0000000Z ##[group]Run go test ./...\r\n" +
+	"2024-01-15T10:00:05.0000000Z ok  \tpkg/a\r\n" +
+	"2024-01-15T10:00:06.0000000Z --- FAIL: TestB (0.00s)\r\n" +
+	"2024-01-15T10:00:07.0000000Z ##[error]Process completed with exit code 1.\r\n"
+
+func TestParseJobLog(t *testing.T) {
+	lines := ParseJobLog(sampleJobLog)
+
+	if len(lines) != 7 {
+		t.Fatalf("Expected 7 lines (endgroup dropped), got %d", len(lines))
+	}
+
+	expected := []struct {
+		kind string
+		text string
+	}{
+		{LogLineGroup, "Run actions/checkout@v4"},
+		{LogLineNormal, "with:"},
+		{LogLineCommand, "/usr/bin/git fetch"},
+		{LogLineGroup, "Run go test ./..."},
+		{LogLineNormal, "ok  \tpkg/a"},
+		{LogLineError, "--- FAIL: TestB (0.00s)"},
+		{LogLineError, "Error: Process completed with exit code 1."},
+	}
+	for i, want := range expected {
+		if lines[i].Kind != want.kind || lines[i].Text != want.text {
+			t.Errorf("Line %d: expected %s %q, got %s %q", i, want.kind, want.text, lines[i].Kind, lines[i].Text)
+		}
+	}
+
+	if lines[0].Time.IsZero() {
+		t.Error("Expected the timestamp to be parsed")
+	}
+	if !lines[6].Annotation || lines[5].Annotation {
+		t.Error("Expected only the ##[error] line to be an annotation")
+	}
+}
+
+func TestJobLogFocusLine(t *testing.T) {
+	log := &JobLog{Lines: ParseJobLog(sampleJobLog)}
+	if got := log.FocusLine(); got != 3 {
+		t.Errorf("Expected focus on the failing step's group (3), got %d", got)
+	}
+
+	// Without errors in the log, fall back to the failed step's name
+	log = &JobLog{
+		Lines: ParseJobLog("2024-01-15T10:00:00Z ##[group]Run make\n2024-01-15T10:00:01Z ##[group]Run make lint\n"),
+		Steps: []*JobStep{{Name: "Run make", Conclusion: "success"}, {Name: "Run make lint", Conclusion: "failure"}},
+	}
+	if got := log.FocusLine(); got != 1 {
+		t.Errorf("Expected focus on the failed step (1), got %d", got)
+	}
+
+	clean := &JobLog{Lines: ParseJobLog("2024-01-15T10:00:00Z all good\n")}
+	if got := clean.FocusLine(); got != 0 {
+		t.Errorf("Expected focus 0 for a passing log, got %d", got)
+	}
+}
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Merge methods, as named by the REST API
+const (
+	MergeMethodMerge  = "merge"
+	MergeMethodSquash = "squash"
+	MergeMethodRebase = "rebase"
+)
+
+// MergeInfo is what GitHub reports about whether and how a pull request can be merged
+type MergeInfo struct {
+	NodeID  string // GraphQL ID, needed to enable auto-merge
+	Title   string
+	Body    string
+	HeadSHA string
+	State   string // OPEN, CLOSED, MERGED
+	IsDraft bool
+
+	// Merge methods the repository allows, in the order GitHub offers them
+	Methods             []string
+	AutoMergeAllowed    bool
+	AutoMergeEnabled    bool // auto-merge is already switched on for this PR
+	DeleteBranchOnMerge bool // the repository deletes head branches itself
+
+	Mergeable        string // MERGEABLE, CONFLICTING, UNKNOWN
+	MergeStateStatus string // CLEAN, BLOCKED, BEHIND, DIRTY, UNSTABLE, HAS_HOOKS, DRAFT, UNKNOWN
+	ReviewDecision   string // APPROVED, CHANGES_REQUESTED, REVIEW_REQUIRED, or empty
+	ChecksState      string // SUCCESS, FAILURE, ERROR, PENDING, EXPECTED, or empty without checks
+}
+
+// MergeRequest is the user's choice of how to merge a pull request
+type MergeRequest struct {
+	Method       string
+	Title        string // commit title; unused for rebase
+	Body         string // commit message; unused for rebase
+	DeleteBranch bool
+	Auto         bool // enable auto-merge instead of merging now
+}
+
+// MergeReadiness explains whether a pull request can be merged right away
+type MergeReadiness struct {
+	Blockers []string // reasons GitHub would reject the merge
+	Warnings []string // merging is allowed but worth a second look
+	CanAuto  bool     // blocked only by pending checks, and auto-merge is available
+}
+
+// Blocked reports whether the merge can't go ahead now
+func (r MergeReadiness) Blocked() bool {
+	return len(r.Blockers) > 0
+}
+
+// ChecksPending reports whether required checks are still running
+func (i *MergeInfo) ChecksPending() bool {
+	return i.ChecksState == "PENDING" || i.ChecksState == "EXPECTED"
+}
+
+// checksFailing reports whether any check failed
+func (i *MergeInfo) checksFailing() bool {
+	return i.ChecksState == "FAILURE" || i.ChecksState == "ERROR"
+}
+
+// Readiness works out why GitHub would reject a merge, based on the merge state
+// it computed for the branch protection rules in force
+func (i *MergeInfo) Readiness() MergeReadiness {
+	var r MergeReadiness
+
+	switch {
+	case i.State == "MERGED":
+		r.Blockers = append(r.Blockers, "This pull request is already merged.")
+		return r
+	case i.State == "CLOSED":
+		r.Blockers = append(r.Blockers, "This pull request is closed; reopen it before merging.")
+		return r
+	case len(i.Methods) == 0:
+		r.Blockers = append(r.Blockers, "The repository doesn't allow any merge method.")
+		return r
+	}
+
+	if i.IsDraft || i.MergeStateStatus == "DRAFT" {
+		r.Blockers = append(r.Blockers, "This pull request is a draft; mark it ready for review first.")
+	}
+	if i.Mergeable == "CONFLICTING" || i.MergeStateStatus == "DIRTY" {
+		r.Blockers = append(r.Blockers, "The branch has conflicts with the base branch that must be resolved.")
+	}
+	if i.Mergeable == "UNKNOWN" || i.MergeStateStatus == "UNKNOWN" {
+		r.Blockers = append(r.Blockers, "GitHub is still checking whether the branch can be merged; try again in a moment.")
+	}
+	if len(r.Blockers) > 0 {
+		return r
+	}
+
+	switch i.MergeStateStatus {
+	case "BEHIND":
+		r.Blockers = append(r.Blockers, "Branch protection requires the branch to be up to date with the base branch.")
+	case "BLOCKED":
+		if i.ReviewDecision == "CHANGES_REQUESTED" {
+			r.Blockers = append(r.Blockers, "A reviewer requested changes.")
+		}
+		if i.ReviewDecision == "REVIEW_REQUIRED" {
+			r.Blockers = append(r.Blockers, "Branch protection requires an approving review.")
+		}
+		if i.checksFailing() {
+			r.Blockers = append(r.Blockers, "Required status checks are failing.")
+		}
+		if i.ChecksPending() {
+			if i.AutoMergeAllowed && len(r.Blockers) == 0 {
+				// Only waiting on CI: GitHub can merge once checks pass
+				r.CanAuto = true
+				return r
+			}
+			r.Blockers = append(r.Blockers, "Required status checks haven't finished.")
+		}
+		if len(r.Blockers) == 0 {
+			r.Blockers = append(r.Blockers, "Branch protection rules block merging (for example required signatures, conversation resolution or a restricted branch).")
+		}
+	case "UNSTABLE":
+		if i.ChecksPending() {
+			r.Warnings = append(r.Warnings, "Some checks haven't finished.")
+		} else {
+			r.Warnings = append(r.Warnings, "Some checks are failing (none of them required).")
+		}
+	}
+	if i.ReviewDecision == "CHANGES_REQUESTED" && !r.Blocked() {
+		r.Warnings = append(r.Warnings, "A reviewer requested changes.")
+	}
+	return r
+}
+
+// MergeMessage returns the commit title and body GitHub would suggest for a
+// merge method; rebase merges don't create a commit of their own
+func (i *MergeInfo) MergeMessage(pr *PullRequest, method string) (string, string) {
+	switch method {
+	case MergeMethodMerge:
+		owner, _, _ := strings.Cut(pr.HeadRepo, "/")
+		if owner == "" {
+			owner, _, _ = strings.Cut(pr.RepoName, "/")
+		}
+		return fmt.Sprintf("Merge pull request #%d from %s/%s", pr.Number, owner, pr.HeadRef), i.Title
+	case MergeMethodSquash:
+		return fmt.Sprintf("%s (#%d)", i.Title, pr.Number), i.Body
+	}
+	return "", ""
+}
+
+// MergeMethodLabel returns a human-readable name for a merge method
+func MergeMethodLabel(method string) string {
+	switch method {
+	case MergeMethodSquash:
+		return "Squash and merge"
+	case MergeMethodRebase:
+		return "Rebase and merge"
+	}
+	return "Create a merge commit"
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func cleanMergeInfo() *MergeInfo {
+	return &MergeInfo{
+		Title:            "Add widgets",
+		Body:             "Widgets for everyone",
+		State:            "OPEN",
+		Methods:          []string{MergeMethodMerge, MergeMethodSquash},
+		Mergeable:        "MERGEABLE",
+		MergeStateStatus: "CLEAN",
+		ChecksState:      "SUCCESS",
+	}
+}
+
+func TestMergeReadiness(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*MergeInfo)
+		wantBlocked string // substring of the first blocker, empty if mergeable
+		wantAuto    bool
+	}{
+		{"clean", func(i *MergeInfo) {}, "", false},
+		{"merged", func(i *MergeInfo) { i.State = "MERGED" }, "already merged", false},
+		{"draft", func(i *MergeInfo) { i.IsDraft = true; i.MergeStateStatus = "DRAFT" }, "draft", false},
+		{"conflicts", func(i *MergeInfo) { i.Mergeable = "CONFLICTING"; i.MergeStateStatus = "DIRTY" }, "conflicts", false},
+		{"behind", func(i *MergeInfo) { i.MergeStateStatus = "BEHIND" }, "up to date", false},
+		{"missing approval", func(i *MergeInfo) {
+			i.MergeStateStatus = "BLOCKED"
+			i.ReviewDecision = "REVIEW_REQUIRED"
+		}, "approving review", false},
+		{"failing checks", func(i *MergeInfo) {
+			i.MergeStateStatus = "BLOCKED"
+			i.ChecksState = "FAILURE"
+		}, "checks are failing", false},
+		{"pending checks with auto-merge", func(i *MergeInfo) {
+			i.MergeStateStatus = "BLOCKED"
+			i.ChecksState = "PENDING"
+			i.AutoMergeAllowed = true
+		}, "", true},
+		{"pending checks without auto-merge", func(i *MergeInfo) {
+			i.MergeStateStatus = "BLOCKED"
+			i.ChecksState = "PENDING"
+		}, "haven't finished", false},
+		{"other protection", func(i *MergeInfo) { i.MergeStateStatus = "BLOCKED" }, "Branch protection", false},
+		{"no methods", func(i *MergeInfo) { i.Methods = nil }, "merge method", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := cleanMergeInfo()
+			tt.modify(info)
+			r := info.Readiness()
+
+			if tt.wantBlocked == "" && r.Blocked() {
+				t.Errorf("Expected mergeable, got blockers %v", r.Blockers)
+			}
+			if tt.wantBlocked != "" && (!r.Blocked() || !strings.Contains(r.Blockers[0], tt.wantBlocked)) {
+				t.Errorf("Expected blocker containing %q, got %v", tt.wantBlocked, r.Blockers)
+			}
+			if r.CanAuto != tt.wantAuto {
+				t.Errorf("Expected CanAuto %v, got %v", tt.wantAuto, r.CanAuto)
+			}
+		})
+	}
+}
+
+func TestMergeReadinessWarnsWhenUnstable(t *testing.T) {
+	info := cleanMergeInfo()
+	info.MergeStateStatus = "UNSTABLE"
+	info.ChecksState = "FAILURE"
+
+	r := info.Readiness()
+	if r.Blocked() || len(r.Warnings) == 0 {
+		t.Errorf("Expected a warning without blockers, got %+v", r)
+	}
+}
+
+func TestMergeMessage(t *testing.T) {
+	info := cleanMergeInfo()
+	pr := &PullRequest{Number: 12, RepoName: "acme/app", HeadRepo: "fork/app", HeadRef: "widgets"}
+
+	title, body := info.MergeMessage(pr, MergeMethodMerge)
+	if title != "Merge pull request #12 from fork/widgets" || body != "Add widgets" {
+		t.Errorf("Unexpected merge message %q / %q", title, body)
+	}
+
+	title, body = info.MergeMessage(pr, MergeMethodSquash)
+	if title != "Add widgets (#12)" || body != "Widgets for everyone" {
+		t.Errorf("Unexpected squash message %q / %q", title, body)
+	}
+
+	if title, body = info.MergeMessage(pr, MergeMethodRebase); title != "" || body != "" {
+		t.Errorf("Expected no message for rebase, got %q / %q", title, body)
+	}
+}
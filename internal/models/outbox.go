@@ -0,0 +1,75 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// QueuedField is the kind of a queued change to an editable field; other
+// queued changes have the kind of the draft they were written as
+const QueuedField = "field"
+
+// QueuedChange is a write made while offline, kept until the user agrees to
+// send it once GitHub is reachable again
+type QueuedChange struct {
+	ID       int       `json:"id"`
+	Kind     string    `json:"kind"` // a draft kind or QueuedField
+	Host     string    `json:"host"` // the GitHub host Repo is on
+	Repo     string    `json:"repo"`
+	Number   int       `json:"number"`
+	ReplyTo  int64     `json:"reply_to,omitempty"`
+	Event    string    `json:"event,omitempty"`
+	Body     string    `json:"body,omitempty"`
+	Field    string    `json:"field,omitempty"`
+	Add      []string  `json:"add,omitempty"`
+	Remove   []string  `json:"remove,omitempty"`
+	QueuedAt time.Time `json:"queued_at"`
+}
+
+// QueueDraft turns a comment, reply or review into a queued change
+func QueueDraft(d *Draft, now time.Time) *QueuedChange {
+	return &QueuedChange{
+		Kind:     d.Kind,
+		Repo:     d.PR.RepoName,
+		Number:   d.PR.Number,
+		ReplyTo:  d.ReplyTo,
+		Event:    d.Event,
+		Body:     d.Body,
+		QueuedAt: now,
+	}
+}
+
+// QueueFieldEdit turns an edit of a PR's labels, assignees or reviewers into a
+// queued change
+func QueueFieldEdit(pr *PullRequest, field string, add, remove []string, now time.Time) *QueuedChange {
+	return &QueuedChange{
+		Kind:     QueuedField,
+		Repo:     pr.RepoName,
+		Number:   pr.Number,
+		Field:    field,
+		Add:      add,
+		Remove:   remove,
+		QueuedAt: now,
+	}
+}
+
+// Describe summarizes the change for the status line (e.g. "labels of
+// acme/api#142 (+bug -wip)")
+func (c *QueuedChange) Describe() string {
+	target := fmt.Sprintf("%s#%d", c.Repo, c.Number)
+	switch c.Kind {
+	case QueuedField:
+		var changes []string
+		for _, v := range c.Add {
+			changes = append(changes, "+"+v)
+		}
+		for _, v := range c.Remove {
+			changes = append(changes, "-"+v)
+		}
+		return fmt.Sprintf("%s of %s (%s)", c.Field, target, strings.Join(changes, " "))
+	case DraftReview:
+		return fmt.Sprintf("%s review of %s", strings.ToLower(ReviewEventLabel(c.Event)), target)
+	}
+	return fmt.Sprintf("%s on %s", c.Kind, target)
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQueuedChangeDescribe(t *testing.T) {
+	pr := &PullRequest{RepoName: "acme/api", Number: 142}
+	now := time.Now()
+	tests := []struct {
+		change *QueuedChange
+		want   string
+	}{
+		{QueueDraft(&Draft{Kind: DraftComment, PR: pr, Body: "hi"}, now), "comment on acme/api#142"},
+		{QueueDraft(&Draft{Kind: DraftReply, PR: pr, ReplyTo: 7, Body: "done"}, now), "reply on acme/api#142"},
+		{QueueDraft(&Draft{Kind: DraftReview, PR: pr, Event: ReviewEventApprove}, now), "approve review of acme/api#142"},
+		{QueueFieldEdit(pr, EditLabels, []string{"bug"}, []string{"wip"}, now), "labels of acme/api#142 (+bug -wip)"},
+	}
+	for _, tt := range tests {
+		if got := tt.change.Describe(); got != tt.want {
+			t.Errorf("Expected %q, got %q", tt.want, got)
+		}
+	}
+}
@@ -1,33 +1,133 @@
 package models
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/go-github/v58/github"
 )
 
+// Pull request states; PRStateAll only applies to listing
+const (
+	PRStateOpen   = "open"
+	PRStateClosed = "closed"
+	PRStateMerged = "merged"
+	PRStateAll    = "all"
+)
+
+// PRListStates are the states the PR list cycles through
+var PRListStates = []string{PRStateOpen, PRStateClosed, PRStateMerged, PRStateAll}
+
+// NextPRListState returns the list state after state, wrapping around
+func NextPRListState(state string) string {
+	for i, s := range PRListStates {
+		if s == state {
+			return PRListStates[(i+1)%len(PRListStates)]
+		}
+	}
+	return PRStateOpen
+}
+
 // PullRequest represents a GitHub pull request
 type PullRequest struct {
 	ID        int64     `json:"id"`
+	NodeID    string    `json:"node_id"` // GraphQL ID, for mutations REST lacks
 	Number    int       `json:"number"`
 	Title     string    `json:"title"`
 	State     string    `json:"state"` // open, closed, merged
+	URL       string    `json:"url"`
 	RepoID    int64     `json:"repo_id"`
 	Author    string    `json:"author"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	ClosedAt  time.Time `json:"closed_at"`
+	MergedAt  time.Time `json:"merged_at"`
+	MergedBy  string    `json:"merged_by"` // only set by single-PR fetches, not listings
 	Additions int       `json:"additions"`
 	Deletions int       `json:"deletions"`
 
 	// Additional fields for list view
 	Labels       []string `json:"labels"`
 	Assignees    []string `json:"assignees"`
-	Reviewers    []string `json:"reviewers"`
+	Reviewers    []string `json:"reviewers"`     // users, and teams as org/team-slug
 	ReviewStatus string   `json:"review_status"` // approved, changes_requested, pending
 	IsDraft      bool     `json:"is_draft"`
 	Mergeable    *bool    `json:"mergeable"`
 	Comments     int      `json:"comments"`
 	Commits      int      `json:"commits"`
+
+	// CI results for the head commit; CIStatus is empty until checks are loaded
+	CIStatus string   `json:"ci_status"` // success, failure, pending, neutral, none
+	Checks   []*Check `json:"checks,omitempty"`
+
+	// Review threads not yet marked resolved
+	UnresolvedThreads int `json:"unresolved_threads"`
+
+	// Comment and thread counts of PR list rows; nil until loaded
+	Activity *Activity `json:"activity,omitempty"`
+
+	// Branch information used for local checkouts
+	RepoName string `json:"repo_name"` // base repository as owner/repo
+	HeadRef  string `json:"head_ref"`
+	HeadRepo string `json:"head_repo"` // head repository as owner/repo, empty if the fork was deleted
+	HeadSHA  string `json:"head_sha"`
+	BaseRef  string `json:"base_ref"`
+}
+
+// SetChecks stores the head commit's checks and their rolled-up status
+func (p *PullRequest) SetChecks(checks []*Check) {
+	p.Checks = checks
+	p.CIStatus = AggregateCIStatus(checks)
+}
+
+// ChangeSince describes the most notable change to a PR since prev, an earlier
+// listing of it: "new" when there was none, "new commits", a state change and
+// so on, or "" when nothing changed. Listings don't carry comment counts, so new
+// comments show as "new activity".
+func (p *PullRequest) ChangeSince(prev *PullRequest) string {
+	switch {
+	case prev == nil:
+		return "new"
+	case p.State != prev.State && p.State == PRStateOpen:
+		return "reopened"
+	case p.State != prev.State:
+		return p.State
+	case p.IsDraft != prev.IsDraft && p.IsDraft:
+		return "converted to draft"
+	case p.IsDraft != prev.IsDraft:
+		return "ready for review"
+	case p.HeadSHA != prev.HeadSHA:
+		return "new commits"
+	case p.UpdatedAt.After(prev.UpdatedAt):
+		return "new activity"
+	}
+	return ""
+}
+
+// IsCrossRepository reports whether the PR head lives in a different repository (a fork)
+func (p *PullRequest) IsCrossRepository() bool {
+	return p.HeadRepo != "" && !strings.EqualFold(p.HeadRepo, p.RepoName)
+}
+
+// IsSameRepository reports whether the PR head is known to live in the base
+// repository; false for forks, and when the head repository was deleted
+func (p *PullRequest) IsSameRepository() bool {
+	return p.HeadRepo != "" && strings.EqualFold(p.HeadRepo, p.RepoName)
+}
+
+// CheckoutBranch returns the local branch name used when checking out the PR.
+// Fork branches are prefixed with the fork owner so they can't collide with
+// base repository branches of the same name (e.g. a fork's "main").
+func (p *PullRequest) CheckoutBranch() string {
+	if p.HeadRef == "" {
+		return fmt.Sprintf("pr-%d", p.Number)
+	}
+	if p.IsCrossRepository() {
+		owner := strings.SplitN(p.HeadRepo, "/", 2)[0]
+		return owner + "/" + p.HeadRef
+	}
+	return p.HeadRef
 }
 
 // FromGitHubPR converts a GitHub PR to our model
@@ -84,13 +184,19 @@ func FromGitHubPR(pr *github.PullRequest, repoName string) *PullRequest {
 		}
 	}
 
-	// Extract reviewers safely
-	reviewers := make([]string, 0, len(pr.RequestedReviewers))
+	// Extract reviewers safely; teams are named org/team-slug
+	reviewers := make([]string, 0, len(pr.RequestedReviewers)+len(pr.RequestedTeams))
 	for _, reviewer := range pr.RequestedReviewers {
 		if reviewer != nil && reviewer.Login != nil {
 			reviewers = append(reviewers, *reviewer.Login)
 		}
 	}
+	org, _, _ := strings.Cut(repoName, "/")
+	for _, team := range pr.RequestedTeams {
+		if team != nil && team.Slug != nil {
+			reviewers = append(reviewers, org+"/"+*team.Slug)
+		}
+	}
 
 	// Determine review status based on mergeable state and reviews
 	reviewStatus := "pending"
@@ -106,15 +212,43 @@ func FromGitHubPR(pr *github.PullRequest, repoName string) *PullRequest {
 		author = *pr.User.Login
 	}
 
+	// GitHub reports merged PRs as closed; merged_at tells them apart
+	state := safeString(pr.State)
+	if pr.MergedAt != nil {
+		state = PRStateMerged
+	}
+	mergedBy := ""
+	if pr.MergedBy != nil {
+		mergedBy = safeString(pr.MergedBy.Login)
+	}
+
+	// Extract branch information safely
+	headRef, headRepo, headSHA, baseRef := "", "", "", ""
+	if pr.Head != nil {
+		headRef = safeString(pr.Head.Ref)
+		headSHA = safeString(pr.Head.SHA)
+		if pr.Head.Repo != nil {
+			headRepo = safeString(pr.Head.Repo.FullName)
+		}
+	}
+	if pr.Base != nil {
+		baseRef = safeString(pr.Base.Ref)
+	}
+
 	return &PullRequest{
 		ID:           safeInt64(pr.ID),
+		NodeID:       safeString(pr.NodeID),
 		Number:       safeInt(pr.Number),
 		Title:        safeString(pr.Title),
-		State:        safeString(pr.State),
+		State:        state,
+		URL:          safeString(pr.HTMLURL),
 		RepoID:       0, // Will be set by caller if needed
 		Author:       author,
 		CreatedAt:    safeTime(pr.CreatedAt),
 		UpdatedAt:    safeTime(pr.UpdatedAt),
+		ClosedAt:     safeTime(pr.ClosedAt),
+		MergedAt:     safeTime(pr.MergedAt),
+		MergedBy:     mergedBy,
 		Additions:    safeInt(pr.Additions),
 		Deletions:    safeInt(pr.Deletions),
 		Labels:       labels,
@@ -125,5 +259,19 @@ func FromGitHubPR(pr *github.PullRequest, repoName string) *PullRequest {
 		Mergeable:    pr.Mergeable,
 		Comments:     safeInt(pr.Comments),
 		Commits:      safeInt(pr.Commits),
+		RepoName:     repoName,
+		HeadRef:      headRef,
+		HeadRepo:     headRepo,
+		HeadSHA:      headSHA,
+		BaseRef:      baseRef,
+	}
+}
+
+// FromGitHubPRs converts a list of GitHub PRs from one repository to our model
+func FromGitHubPRs(prs []*github.PullRequest, repoName string) []*PullRequest {
+	result := make([]*PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		result = append(result, FromGitHubPR(pr, repoName))
 	}
+	return result
 }
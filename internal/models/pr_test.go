@@ -94,3 +94,90 @@ func TestFromGitHubPR(t *testing.T) {
 		t.Errorf("Expected empty author for nil PR, got %s", nilResult.Author)
 	}
 }
+
+func TestCheckoutBranch(t *testing.T) {
+	tests := []struct {
+		pr       PullRequest
+		expected string
+	}{
+		{PullRequest{Number: 1, RepoName: "org/repo", HeadRepo: "org/repo", HeadRef: "feature"}, "feature"},
+		{PullRequest{Number: 2, RepoName: "org/repo", HeadRepo: "someone/repo", HeadRef: "main"}, "someone/main"},
+		{PullRequest{Number: 3, RepoName: "org/repo", HeadRef: "orphaned"}, "orphaned"},
+		{PullRequest{Number: 4, RepoName: "org/repo"}, "pr-4"},
+	}
+
+	for _, test := range tests {
+		if result := test.pr.CheckoutBranch(); result != test.expected {
+			t.Errorf("CheckoutBranch() for #%d = %q, expected %q", test.pr.Number, result, test.expected)
+		}
+	}
+}
+
+func TestFromGitHubPRMerged(t *testing.T) {
+	mergedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	githubPR := &github.PullRequest{
+		State:    github.String("closed"),
+		MergedAt: &github.Timestamp{Time: mergedAt},
+		MergedBy: &github.User{Login: github.String("octocat")},
+	}
+
+	pr := FromGitHubPR(githubPR, "test/repo")
+	if pr.State != PRStateMerged {
+		t.Errorf("Expected State merged, got %s", pr.State)
+	}
+	if !pr.MergedAt.Equal(mergedAt) || pr.MergedBy != "octocat" {
+		t.Errorf("Unexpected merge details %v %q", pr.MergedAt, pr.MergedBy)
+	}
+
+	closed := FromGitHubPR(&github.PullRequest{State: github.String("closed")}, "test/repo")
+	if closed.State != PRStateClosed {
+		t.Errorf("Expected State closed, got %s", closed.State)
+	}
+}
+
+func TestNextPRListState(t *testing.T) {
+	state := PRStateOpen
+	var seen []string
+	for range PRListStates {
+		state = NextPRListState(state)
+		seen = append(seen, state)
+	}
+	expected := []string{PRStateClosed, PRStateMerged, PRStateAll, PRStateOpen}
+	for i := range expected {
+		if seen[i] != expected[i] {
+			t.Fatalf("Expected cycle %v, got %v", expected, seen)
+		}
+	}
+	if NextPRListState("bogus") != PRStateOpen {
+		t.Error("Expected an unknown state to reset to open")
+	}
+}
+
+func TestChangeSince(t *testing.T) {
+	now := time.Now()
+	prev := &PullRequest{State: PRStateOpen, HeadSHA: "a", UpdatedAt: now}
+	tests := []struct {
+		modify func(*PullRequest)
+		want   string
+	}{
+		{func(p *PullRequest) {}, ""},
+		{func(p *PullRequest) { p.HeadSHA = "b"; p.UpdatedAt = now.Add(time.Minute) }, "new commits"},
+		{func(p *PullRequest) { p.UpdatedAt = now.Add(time.Minute) }, "new activity"},
+		{func(p *PullRequest) { p.State = PRStateMerged; p.HeadSHA = "b" }, "merged"},
+		{func(p *PullRequest) { p.IsDraft = true }, "converted to draft"},
+	}
+	for _, tt := range tests {
+		cur := *prev
+		tt.modify(&cur)
+		if got := cur.ChangeSince(prev); got != tt.want {
+			t.Errorf("Expected %q, got %q", tt.want, got)
+		}
+	}
+	if got := prev.ChangeSince(nil); got != "new" {
+		t.Errorf("Expected a PR without an earlier listing to be new, got %q", got)
+	}
+	reopened := &PullRequest{State: PRStateOpen}
+	if got := reopened.ChangeSince(&PullRequest{State: PRStateClosed}); got != "reopened" {
+		t.Errorf("Expected reopened, got %q", got)
+	}
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Activity is the discussion on a pull request that listings leave out,
+// loaded for the rows of the PR list
+type Activity struct {
+	UnresolvedThreads int `json:"unresolved_threads"`
+	Comments          int `json:"comments"` // conversation and review comments
+}
+
+// Seen records what a pull request looked like when the user last read it, to
+// tell what's new since
+type Seen struct {
+	ReadAt    time.Time `json:"read_at"`
+	UpdatedAt time.Time `json:"updated_at"` // the PR's last activity at the time
+	HeadSHA   string    `json:"head_sha"`
+	Comments  int       `json:"comments"` // -1 when the count wasn't loaded
+}
+
+// MarkSeen records pr as read at now
+func MarkSeen(pr *PullRequest, now time.Time) *Seen {
+	seen := &Seen{ReadAt: now, UpdatedAt: pr.UpdatedAt, HeadSHA: pr.HeadSHA, Comments: -1}
+	if pr.Activity != nil {
+		seen.Comments = pr.Activity.Comments
+	}
+	return seen
+}
+
+// Unread is what happened on a pull request since the user last read it
+type Unread struct {
+	Unread      bool // never read, or active since
+	NewComments int
+	NewCommits  bool
+}
+
+// UnreadSince compares pr with how it looked when last read; a nil Seen means
+// it never was
+func (pr *PullRequest) UnreadSince(seen *Seen) Unread {
+	if seen == nil {
+		return Unread{Unread: true}
+	}
+	u := Unread{
+		Unread:     pr.UpdatedAt.After(seen.UpdatedAt),
+		NewCommits: pr.HeadSHA != seen.HeadSHA,
+	}
+	if pr.Activity != nil && seen.Comments >= 0 {
+		u.NewComments = max(pr.Activity.Comments-seen.Comments, 0)
+	}
+	u.Unread = u.Unread || u.NewCommits || u.NewComments > 0
+	return u
+}
+
+// Describe summarizes what's new (e.g. "2 new comments, new commits"), or
+// returns "" when nothing countable is
+func (u Unread) Describe() string {
+	var parts []string
+	switch {
+	case u.NewComments == 1:
+		parts = append(parts, "1 new comment")
+	case u.NewComments > 1:
+		parts = append(parts, fmt.Sprintf("%d new comments", u.NewComments))
+	}
+	if u.NewCommits {
+		parts = append(parts, "new commits")
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUnreadSince(t *testing.T) {
+	now := time.Now()
+	pr := &PullRequest{HeadSHA: "a", UpdatedAt: now, Activity: &Activity{Comments: 2}}
+	seen := MarkSeen(pr, now)
+
+	if u := pr.UnreadSince(nil); !u.Unread || u.Describe() != "" {
+		t.Errorf("Expected a PR never read to be unread with nothing to count, got %+v", u)
+	}
+	if u := pr.UnreadSince(seen); u.Unread {
+		t.Errorf("Expected a PR just read to be read, got %+v", u)
+	}
+
+	pr.HeadSHA, pr.UpdatedAt, pr.Activity = "b", now.Add(time.Minute), &Activity{Comments: 5}
+	u := pr.UnreadSince(seen)
+	if !u.Unread || u.Describe() != "3 new comments, new commits" {
+		t.Errorf("Expected new comments and commits, got %+v (%q)", u, u.Describe())
+	}
+
+	// Comments can't be counted when the count wasn't known at the time
+	seen.Comments = -1
+	if u := pr.UnreadSince(seen); u.NewComments != 0 || u.Describe() != "new commits" {
+		t.Errorf("Expected only new commits, got %q", u.Describe())
+	}
+}
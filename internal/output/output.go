@@ -0,0 +1,306 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+
+	"github.com/itchyny/gojq"
+)
+
+// Supported output formats
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatCSV   = "csv"
+)
+
+// Options controls how a list of records is written
+type Options struct {
+	Format   string   // table, json or csv
+	Fields   []string // JSON field names to include, in order; empty means defaults
+	JQ       string   // jq expression applied to the JSON output
+	Template string   // Go template applied to the JSON data
+}
+
+// Validate checks the options against the fields available on a record type
+func (o Options) Validate(available []string) error {
+	switch o.Format {
+	case "", FormatTable, FormatJSON, FormatCSV:
+	default:
+		return fmt.Errorf("unknown format %q (expected table, json or csv)", o.Format)
+	}
+
+	if o.JQ != "" && o.Template != "" {
+		return fmt.Errorf("--jq and --template cannot be combined")
+	}
+
+	known := make(map[string]bool, len(available))
+	for _, field := range available {
+		known[field] = true
+	}
+	for _, field := range o.Fields {
+		if !known[field] {
+			return fmt.Errorf("unknown field %q; available fields: %s", field, strings.Join(available, ", "))
+		}
+	}
+	return nil
+}
+
+// FieldNames returns the JSON field names of a struct type, in declaration order
+func FieldNames(record interface{}) []string {
+	t := reflect.TypeOf(record)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Write renders records (a slice of structs with JSON tags). defaultFields are used
+// for table and CSV output when no fields were requested.
+func Write(w io.Writer, records interface{}, defaultFields []string, opts Options) error {
+	rows, err := toMaps(records)
+	if err != nil {
+		return err
+	}
+
+	// Without explicit fields, JSON, jq and templates see every field while
+	// tables and CSV fall back to the defaults
+	fields := opts.Fields
+	if len(fields) > 0 {
+		rows = selectFields(rows, fields)
+	} else {
+		fields = defaultFields
+	}
+
+	switch {
+	case opts.JQ != "":
+		return writeJQ(w, rows, opts.JQ)
+	case opts.Template != "":
+		return writeTemplate(w, rows, opts.Template)
+	}
+
+	switch opts.Format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rows)
+	case FormatCSV:
+		return writeCSV(w, rows, fields)
+	default:
+		return writeTable(w, rows, fields)
+	}
+}
+
+// toMaps converts records to generic JSON values through a JSON round trip, so
+// field names and formatting match the struct tags exactly
+func toMaps(records interface{}) ([]map[string]interface{}, error) {
+	data, err := json.Marshal(records)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode records: %w", err)
+	}
+
+	rows := []map[string]interface{}{}
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("failed to decode records: %w", err)
+	}
+	return rows, nil
+}
+
+// selectFields keeps only the requested fields of each row
+func selectFields(rows []map[string]interface{}, fields []string) []map[string]interface{} {
+	selected := make([]map[string]interface{}, 0, len(rows))
+	for _, row := range rows {
+		picked := make(map[string]interface{}, len(fields))
+		for _, field := range fields {
+			picked[field] = row[field]
+		}
+		selected = append(selected, picked)
+	}
+	return selected
+}
+
+// writeTable writes an aligned plain-text table with an upper-case header
+func writeTable(w io.Writer, rows []map[string]interface{}, fields []string) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	header := make([]string, len(fields))
+	for i, field := range fields {
+		header[i] = strings.ToUpper(field)
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	for _, row := range rows {
+		cells := make([]string, len(fields))
+		for i, field := range fields {
+			// Tabs or newlines inside a value would break the alignment
+			cells[i] = strings.Join(strings.Fields(formatValue(row[field])), " ")
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+// writeCSV writes rows as CSV with a header line
+func writeCSV(w io.Writer, rows []map[string]interface{}, fields []string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(fields); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		record := make([]string, len(fields))
+		for i, field := range fields {
+			record[i] = formatValue(row[field])
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeJQ applies a jq expression to the rows, printing each result like `gh --jq`:
+// strings raw, everything else as compact JSON
+func writeJQ(w io.Writer, rows []map[string]interface{}, expr string) error {
+	query, err := gojq.Parse(expr)
+	if err != nil {
+		return fmt.Errorf("invalid jq expression: %w", err)
+	}
+
+	// gojq only accepts plain JSON types
+	input := make([]interface{}, len(rows))
+	for i, row := range rows {
+		input[i] = row
+	}
+
+	iter := query.Run(input)
+	for {
+		value, ok := iter.Next()
+		if !ok {
+			return nil
+		}
+		if err, isErr := value.(error); isErr {
+			return fmt.Errorf("jq: %w", err)
+		}
+
+		if s, isString := value.(string); isString {
+			fmt.Fprintln(w, s)
+			continue
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(data))
+	}
+}
+
+// writeTemplate executes a Go template against the rows
+func writeTemplate(w io.Writer, rows []map[string]interface{}, text string) error {
+	tmpl, err := template.New("output").Funcs(templateFuncs()).Parse(text)
+	if err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl.Execute(w, rows)
+}
+
+// templateFuncs are the helpers available to --template, modeled on gh's
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"join": func(sep string, values interface{}) string {
+			return formatList(values, sep)
+		},
+		"truncate": func(length int, value interface{}) string {
+			s := formatValue(value)
+			if len(s) <= length || length < 4 {
+				return s
+			}
+			return s[:length-3] + "..."
+		},
+		"timeago": func(value interface{}) string {
+			t, err := time.Parse(time.RFC3339, formatValue(value))
+			if err != nil {
+				return formatValue(value)
+			}
+			return TimeAgo(t, time.Now())
+		},
+		"timefmt": func(layout string, value interface{}) string {
+			t, err := time.Parse(time.RFC3339, formatValue(value))
+			if err != nil {
+				return formatValue(value)
+			}
+			return t.Format(layout)
+		},
+	}
+}
+
+// formatValue renders a JSON value as a single cell of text
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []interface{}:
+		return formatList(v, ",")
+	case map[string]interface{}:
+		data, _ := json.Marshal(v)
+		return string(data)
+	case float64:
+		// JSON numbers decode as float64; print integers without a fraction
+		if v == float64(int64(v)) {
+			return fmt.Sprintf("%d", int64(v))
+		}
+		return fmt.Sprintf("%g", v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// formatList joins a JSON array into a single string
+func formatList(values interface{}, sep string) string {
+	list, ok := values.([]interface{})
+	if !ok {
+		return formatValue(values)
+	}
+	parts := make([]string, len(list))
+	for i, item := range list {
+		parts[i] = formatValue(item)
+	}
+	return strings.Join(parts, sep)
+}
+
+// TimeAgo formats the time elapsed since t in the compact style gh uses
+func TimeAgo(t, now time.Time) string {
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	case d < 30*24*time.Hour:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	case d < 365*24*time.Hour:
+		return fmt.Sprintf("%dmo ago", int(d.Hours()/24/30))
+	}
+	return fmt.Sprintf("%dy ago", int(d.Hours()/24/365))
+}
@@ -0,0 +1,144 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+type record struct {
+	Number int       `json:"number"`
+	Title  string    `json:"title"`
+	Labels []string  `json:"labels"`
+	When   time.Time `json:"when"`
+	Secret string    `json:"-"`
+}
+
+var records = []record{
+	{Number: 1, Title: "First, with comma", Labels: []string{"bug", "ui"}, When: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+	{Number: 22, Title: "Second", Labels: []string{}, When: time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC)},
+}
+
+func TestFieldNames(t *testing.T) {
+	names := FieldNames(&record{})
+	expected := "number,title,labels,when"
+	if strings.Join(names, ",") != expected {
+		t.Errorf("Expected %s, got %v", expected, names)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	available := FieldNames(record{})
+
+	if err := (Options{Format: "xml"}).Validate(available); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+	if err := (Options{Fields: []string{"nope"}}).Validate(available); err == nil {
+		t.Error("Expected error for unknown field")
+	}
+	if err := (Options{JQ: ".", Template: "x"}).Validate(available); err == nil {
+		t.Error("Expected error for --jq with --template")
+	}
+	if err := (Options{Format: FormatCSV, Fields: []string{"title"}}).Validate(available); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, records, []string{"number", "title", "labels"}, Options{}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "NUMBER  TITLE              LABELS\n" +
+		"1       First, with comma  bug,ui\n" +
+		"22      Second             \n"
+	if buf.String() != expected {
+		t.Errorf("Unexpected table:\n%q\nexpected:\n%q", buf.String(), expected)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	opts := Options{Format: FormatCSV, Fields: []string{"number", "title"}}
+	if err := Write(&buf, records, nil, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "number,title\n1,\"First, with comma\"\n22,Second\n"
+	if buf.String() != expected {
+		t.Errorf("Unexpected CSV:\n%q", buf.String())
+	}
+}
+
+func TestWriteJSONFields(t *testing.T) {
+	var buf bytes.Buffer
+	opts := Options{Format: FormatJSON, Fields: []string{"number"}}
+	if err := Write(&buf, records, nil, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "[\n  {\n    \"number\": 1\n  },\n  {\n    \"number\": 22\n  }\n]\n"
+	if buf.String() != expected {
+		t.Errorf("Unexpected JSON:\n%s", buf.String())
+	}
+}
+
+func TestWriteJQ(t *testing.T) {
+	var buf bytes.Buffer
+	opts := Options{JQ: `.[] | select(.number > 5) | .title`}
+	if err := Write(&buf, records, nil, opts); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "Second\n" {
+		t.Errorf("Unexpected jq output %q", buf.String())
+	}
+
+	buf.Reset()
+	opts = Options{JQ: `map(.number)`}
+	if err := Write(&buf, records, nil, opts); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "[1,22]\n" {
+		t.Errorf("Unexpected jq output %q", buf.String())
+	}
+
+	if err := Write(&buf, records, nil, Options{JQ: ".[["}); err == nil {
+		t.Error("Expected error for invalid jq expression")
+	}
+}
+
+func TestWriteTemplate(t *testing.T) {
+	var buf bytes.Buffer
+	opts := Options{Template: `{{range .}}#{{.number}} {{truncate 8 .title}} [{{join "|" .labels}}] {{timefmt "2006-01-02" .when}}{{"\n"}}{{end}}`}
+	if err := Write(&buf, records, nil, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "#1 First... [bug|ui] 2024-01-02\n#22 Second [] 2024-02-03\n"
+	if buf.String() != expected {
+		t.Errorf("Unexpected template output:\n%q", buf.String())
+	}
+}
+
+func TestTimeAgo(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		ago      time.Duration
+		expected string
+	}{
+		{10 * time.Second, "just now"},
+		{5 * time.Minute, "5m ago"},
+		{3 * time.Hour, "3h ago"},
+		{4 * 24 * time.Hour, "4d ago"},
+		{65 * 24 * time.Hour, "2mo ago"},
+		{800 * 24 * time.Hour, "2y ago"},
+	}
+
+	for _, test := range tests {
+		if result := TimeAgo(now.Add(-test.ago), now); result != test.expected {
+			t.Errorf("TimeAgo(%v) = %q, expected %q", test.ago, result, test.expected)
+		}
+	}
+}
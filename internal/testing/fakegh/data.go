@@ -0,0 +1,122 @@
+// Package fakegh serves an in-memory GitHub over httptest, for tests and the
+// --demo mode. It implements the parts of the REST and GraphQL APIs gh-nav
+// uses, with pagination links, rate limit headers and injectable failures.
+package fakegh
+
+import "time"
+
+// Data is the GitHub a Server serves: the authenticated user, their
+// organizations and the repositories of both
+type Data struct {
+	User  string
+	Orgs  []string
+	Repos []*Repo
+}
+
+// Repo is a repository with its pull requests
+type Repo struct {
+	Owner     string
+	Name      string
+	Private   bool
+	UpdatedAt time.Time
+
+	Labels        []string
+	Collaborators []string // assignable users
+	Teams         []string // team slugs with access
+
+	// Merge settings; all methods are allowed when MergeMethods is empty
+	MergeMethods        []string
+	AutoMergeAllowed    bool
+	DeleteBranchOnMerge bool
+
+	PullRequests []*PullRequest
+	Branches     []string // head branches that exist; deleting one removes it
+}
+
+// FullName returns owner/name
+func (r *Repo) FullName() string {
+	return r.Owner + "/" + r.Name
+}
+
+// PullRequest is a pull request with everything hanging off it
+type PullRequest struct {
+	Number    int
+	Title     string
+	Body      string
+	Author    string
+	State     string // open or closed
+	Draft     bool
+	Merged    bool
+	MergedBy  string
+	Head      string // branch name
+	Base      string
+	HeadSHA   string
+	Mergeable *bool // nil while GitHub is still computing it
+	AutoMerge bool
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	ClosedAt  time.Time
+	MergedAt  time.Time
+
+	Labels    []string
+	Assignees []string
+	Reviewers []string // users, and teams as org/team-slug
+
+	Files          []*File
+	Checks         []*Check
+	Comments       []*Comment
+	Reviews        []*Review
+	ReviewComments []*ReviewComment
+}
+
+// File is a file changed by a pull request
+type File struct {
+	Name      string
+	Status    string // added, modified, removed, renamed
+	Additions int
+	Deletions int
+	Patch     string
+}
+
+// Check is a check run. Checks of the github-actions app are jobs whose Log
+// can be downloaded.
+type Check struct {
+	ID         int64
+	Name       string
+	App        string // e.g. github-actions
+	Status     string // queued, in_progress, completed
+	Conclusion string
+	Log        string
+	Reruns     int // times a re-run of failed jobs was requested
+}
+
+// Comment is a top-level comment on a pull request
+type Comment struct {
+	ID        int64
+	Author    string
+	Body      string
+	CreatedAt time.Time
+}
+
+// Review is a submitted review
+type Review struct {
+	ID          int64
+	Author      string
+	State       string // APPROVED, CHANGES_REQUESTED, COMMENTED
+	Body        string
+	SubmittedAt time.Time
+}
+
+// ReviewComment is an inline comment on a pull request's diff. Replies point
+// at the first comment of their thread; Resolved is read from that comment.
+type ReviewComment struct {
+	ID        int64
+	Author    string
+	Body      string
+	Path      string
+	Line      int
+	InReplyTo int64
+	Resolved  bool
+	CreatedAt time.Time
+}
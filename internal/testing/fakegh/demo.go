@@ -0,0 +1,168 @@
+package fakegh
+
+import (
+	"fmt"
+	"time"
+)
+
+// Demo returns seeded data for --demo: a user in two organizations, with
+// pull requests in every state, CI results, review threads and diffs
+func Demo() *Data {
+	now := time.Now().Truncate(time.Minute)
+	ago := func(d time.Duration) time.Time { return now.Add(-d) }
+	yes, no := true, false
+
+	failingLog := `2024-05-01T10:00:00.0000000Z ##[group]Run go test ./...
+2024-05-01T10:00:01.0000000Z go test ./...
+2024-05-01T10:00:02.0000000Z ##[endgroup]
+2024-05-01T10:00:05.0000000Z ok   github.com/acme/api/internal/auth  0.012s
+2024-05-01T10:00:06.0000000Z --- FAIL: TestRateLimiter (0.00s)
+2024-05-01T10:00:06.0000000Z     limiter_test.go:42: expected 429 after 10 requests, got 200
+2024-05-01T10:00:06.0000000Z FAIL github.com/acme/api/internal/limiter  0.004s
+2024-05-01T10:00:07.0000000Z ##[error]Process completed with exit code 1.
+`
+	passingLog := `2024-05-01T10:00:00.0000000Z ##[group]Run golangci-lint run
+2024-05-01T10:00:01.0000000Z golangci-lint run
+2024-05-01T10:00:02.0000000Z ##[endgroup]
+2024-05-01T10:00:09.0000000Z 0 issues.
+`
+	ci := func(test string) []*Check {
+		checks := []*Check{
+			{Name: "lint", App: "github-actions", Status: "completed", Conclusion: "success", Log: passingLog},
+			{Name: "test", App: "github-actions", Status: "completed", Conclusion: "success", Log: passingLog},
+		}
+		switch test {
+		case "failure":
+			checks[1].Conclusion, checks[1].Log = "failure", failingLog
+		case "pending":
+			checks[1].Status, checks[1].Conclusion = "in_progress", ""
+		}
+		return checks
+	}
+
+	limiterPatch := `@@ -10,7 +10,12 @@ type Limiter struct {
+ 	mu       sync.Mutex
+ 	requests map[string]int
+-	limit    int
++	limit    int
++	window   time.Duration
++}
++
++func NewLimiter(limit int, window time.Duration) *Limiter {
++	return &Limiter{requests: make(map[string]int), limit: limit, window: window}
+ }
+
+ func (l *Limiter) Allow(key string) bool {`
+
+	api := &Repo{
+		Owner: "acme", Name: "api", UpdatedAt: ago(time.Hour),
+		Labels:           []string{"bug", "enhancement", "security", "needs-review"},
+		Collaborators:    []string{"octocat", "hubot", "mona", "linus"},
+		Teams:            []string{"backend", "platform"},
+		AutoMergeAllowed: true, DeleteBranchOnMerge: true,
+		Branches: []string{"rate-limiter", "oauth-scopes", "fix/session-expiry", "docs/errors"},
+		PullRequests: []*PullRequest{
+			{
+				Number: 142, Title: "Add sliding-window rate limiter", Author: "mona",
+				Body:  "Replaces the fixed counter with a sliding window so bursts at window edges are limited.",
+				State: "open", Head: "rate-limiter", Base: "main", HeadSHA: sha(142), Mergeable: &no,
+				CreatedAt: ago(3 * 24 * time.Hour), UpdatedAt: ago(20 * time.Minute),
+				Labels: []string{"enhancement"}, Assignees: []string{"mona"}, Reviewers: []string{"octocat", "acme/backend"},
+				Files: []*File{
+					{Name: "internal/limiter/limiter.go", Status: "modified", Additions: 6, Deletions: 1, Patch: limiterPatch},
+					{Name: "internal/limiter/limiter_test.go", Status: "added", Additions: 3, Deletions: 0, Patch: "@@ -0,0 +1,3 @@\n+package limiter\n+\n+// TODO: cover the window edges"},
+				},
+				Checks: ci("failure"),
+				Reviews: []*Review{
+					{Author: "linus", State: "CHANGES_REQUESTED", Body: "The window never slides; see inline comment.", SubmittedAt: ago(2 * time.Hour)},
+				},
+				ReviewComments: []*ReviewComment{
+					{ID: 9001, Author: "linus", Path: "internal/limiter/limiter.go", Line: 14, Body: "`window` is stored but never used in Allow.", CreatedAt: ago(2 * time.Hour)},
+					{Author: "mona", Path: "internal/limiter/limiter.go", Line: 14, InReplyTo: 9001, Body: "Good catch, fixing.", CreatedAt: ago(time.Hour)},
+				},
+				Comments: []*Comment{
+					{Author: "hubot", Body: "Coverage dropped by 1.2% on this branch.", CreatedAt: ago(3 * time.Hour)},
+				},
+			},
+			{
+				Number: 139, Title: "Request only the OAuth scopes we use", Author: "hubot",
+				State: "open", Head: "oauth-scopes", Base: "main", HeadSHA: sha(139), Mergeable: &yes,
+				CreatedAt: ago(5 * 24 * time.Hour), UpdatedAt: ago(4 * time.Hour),
+				Labels: []string{"security"}, Reviewers: []string{"octocat"},
+				Files: []*File{
+					{Name: "internal/auth/oauth.go", Status: "modified", Additions: 1, Deletions: 1, Patch: "@@ -3,3 +3,3 @@\n var scopes = []string{\n-\t\"repo\", \"admin:org\",\n+\t\"repo\",\n }"},
+				},
+				Checks: ci("success"),
+				Reviews: []*Review{
+					{Author: "mona", State: "APPROVED", SubmittedAt: ago(5 * time.Hour)},
+				},
+			},
+			{
+				Number: 137, Title: "WIP: structured error responses", Author: "octocat", Draft: true,
+				State: "open", Head: "docs/errors", Base: "main", HeadSHA: sha(137),
+				CreatedAt: ago(6 * 24 * time.Hour), UpdatedAt: ago(24 * time.Hour),
+				Files: []*File{
+					{Name: "docs/errors.md", Status: "added", Additions: 2, Deletions: 0, Patch: "@@ -0,0 +1,2 @@\n+# Errors\n+Every error response carries a `code` and a `message`."},
+				},
+				Checks: ci("pending"),
+			},
+			{
+				Number: 131, Title: "Fix session expiry off-by-one", Author: "linus",
+				State: "closed", Merged: true, MergedBy: "octocat", Head: "fix/session-expiry", Base: "main", HeadSHA: sha(131),
+				CreatedAt: ago(10 * 24 * time.Hour), UpdatedAt: ago(8 * 24 * time.Hour),
+				ClosedAt: ago(8 * 24 * time.Hour), MergedAt: ago(8 * 24 * time.Hour),
+				Labels: []string{"bug"}, Checks: ci("success"),
+			},
+			{
+				Number: 128, Title: "Switch to a new JSON library", Author: "hubot",
+				State: "closed", Head: "json-lib", Base: "main", HeadSHA: sha(128),
+				CreatedAt: ago(20 * 24 * time.Hour), UpdatedAt: ago(15 * 24 * time.Hour), ClosedAt: ago(15 * 24 * time.Hour),
+				Comments: []*Comment{
+					{Author: "octocat", Body: "Closing: the gain doesn't justify the migration.", CreatedAt: ago(15 * 24 * time.Hour)},
+				},
+			},
+		},
+	}
+
+	web := &Repo{
+		Owner: "acme", Name: "web", UpdatedAt: ago(2 * time.Hour),
+		Labels:        []string{"bug", "ui"},
+		Collaborators: []string{"octocat", "mona"},
+		MergeMethods:  []string{"squash"},
+		PullRequests: []*PullRequest{
+			{
+				Number: 57, Title: "Dark mode for the settings page", Author: "mona",
+				State: "open", Head: "dark-settings", Base: "main", HeadSHA: sha(57), Mergeable: &yes,
+				CreatedAt: ago(2 * 24 * time.Hour), UpdatedAt: ago(2 * time.Hour),
+				Labels: []string{"ui"}, Checks: ci("success"),
+			},
+		},
+	}
+
+	var widgets []*PullRequest
+	for i := 1; i <= 24; i++ {
+		widgets = append(widgets, &PullRequest{
+			Number: i, Title: fmt.Sprintf("Bump dependency %d", i), Author: "dependabot[bot]",
+			State: "open", Head: fmt.Sprintf("deps/%d", i), Base: "main", HeadSHA: sha(1000 + i), Mergeable: &yes,
+			CreatedAt: ago(time.Duration(i) * 24 * time.Hour), UpdatedAt: ago(time.Duration(i) * time.Hour),
+			Labels: []string{"dependencies"}, Checks: ci("success"),
+		})
+	}
+
+	return &Data{
+		User: "octocat",
+		Orgs: []string{"acme", "widgets-inc"},
+		Repos: []*Repo{
+			api,
+			web,
+			{Owner: "widgets-inc", Name: "widgets", UpdatedAt: ago(30 * time.Minute), Labels: []string{"dependencies"}, PullRequests: widgets},
+			{Owner: "widgets-inc", Name: "docs", UpdatedAt: ago(7 * 24 * time.Hour)},
+			{Owner: "octocat", Name: "dotfiles", UpdatedAt: ago(3 * 24 * time.Hour)},
+		},
+	}
+}
+
+// sha makes up a commit SHA
+func sha(n int) string {
+	return fmt.Sprintf("%040x", n*7919)
+}
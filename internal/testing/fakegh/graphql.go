@@ -0,0 +1,313 @@
+package fakegh
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// graphQLRequest is the body of a GraphQL call
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+// activityField matches the aliased pullRequest fields of an activity query
+var activityField = regexp.MustCompile(`(pr\d+): pullRequest\(number: (\d+)\)`)
+
+// serveGraphQL answers the queries and mutations gh-nav sends. It recognizes
+// them by the fields they ask for rather than parsing GraphQL.
+func (s *Server) serveGraphQL(w http.ResponseWriter, r *http.Request) {
+	var req graphQLRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	var (
+		data interface{}
+		err  error
+	)
+	switch q := req.Query; {
+	case strings.Contains(q, "convertPullRequestToDraft"):
+		data, err = s.setDraft(req.Variables, true)
+	case strings.Contains(q, "markPullRequestReadyForReview"):
+		data, err = s.setDraft(req.Variables, false)
+	case strings.Contains(q, "enablePullRequestAutoMerge"):
+		data, err = s.enableAutoMerge(req.Variables)
+	case strings.Contains(q, "mergeCommitAllowed"):
+		data, err = s.mergeInfo(req.Variables)
+	case strings.Contains(q, "reviewThreads(first: 100, after: $cursor)"):
+		data, err = s.reviewThreads(req.Variables)
+	case activityField.MatchString(q):
+		data, err = s.activity(q, req.Variables)
+	default:
+		err = fmt.Errorf("fakegh doesn't support this query")
+	}
+
+	if err != nil {
+		s.writeJSON(w, r, http.StatusOK, map[string]interface{}{
+			"data":   nil,
+			"errors": []map[string]string{{"message": err.Error()}},
+		})
+		return
+	}
+	s.writeJSON(w, r, http.StatusOK, map[string]interface{}{"data": data})
+}
+
+// graphQLRepo looks up the repository named by the owner and repo variables
+func (s *Server) graphQLRepo(vars map[string]interface{}) (*Repo, error) {
+	owner, _ := vars["owner"].(string)
+	name, _ := vars["repo"].(string)
+	for _, repo := range s.data.Repos {
+		if strings.EqualFold(repo.Owner, owner) && strings.EqualFold(repo.Name, name) {
+			return repo, nil
+		}
+	}
+	return nil, fmt.Errorf("Could not resolve to a Repository with the name '%s/%s'.", owner, name)
+}
+
+// graphQLPull looks up the pull request named by the owner, repo and number variables
+func (s *Server) graphQLPull(vars map[string]interface{}) (*Repo, *PullRequest, error) {
+	repo, err := s.graphQLRepo(vars)
+	if err != nil {
+		return nil, nil, err
+	}
+	number, _ := vars["number"].(float64)
+	for _, pr := range repo.PullRequests {
+		if pr.Number == int(number) {
+			return repo, pr, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("Could not resolve to a PullRequest with the number of %d.", int(number))
+}
+
+// nodePull looks up a pull request by the GraphQL ID in the id variable
+func (s *Server) nodePull(vars map[string]interface{}) (*Repo, *PullRequest, error) {
+	id, _ := vars["id"].(string)
+	for _, repo := range s.data.Repos {
+		for _, pr := range repo.PullRequests {
+			if nodeID(repo, pr) == id {
+				return repo, pr, nil
+			}
+		}
+	}
+	return nil, nil, fmt.Errorf("Could not resolve to a node with the global id of '%s'", id)
+}
+
+func (s *Server) setDraft(vars map[string]interface{}, draft bool) (interface{}, error) {
+	_, pr, err := s.nodePull(vars)
+	if err != nil {
+		return nil, err
+	}
+	if pr.State != "open" {
+		return nil, fmt.Errorf("Pull request is closed")
+	}
+	pr.Draft = draft
+	return map[string]interface{}{"result": map[string]interface{}{"clientMutationId": nil}}, nil
+}
+
+func (s *Server) enableAutoMerge(vars map[string]interface{}) (interface{}, error) {
+	repo, pr, err := s.nodePull(vars)
+	if err != nil {
+		return nil, err
+	}
+	if !repo.AutoMergeAllowed {
+		return nil, fmt.Errorf("Pull request Auto merge is not allowed for this repository")
+	}
+	method, _ := vars["method"].(string)
+	if !allowsMethod(repo, strings.ToLower(method)) {
+		return nil, fmt.Errorf("Merge method %s is not allowed on this repository", method)
+	}
+	pr.AutoMerge = true
+	return map[string]interface{}{"enablePullRequestAutoMerge": map[string]interface{}{"clientMutationId": nil}}, nil
+}
+
+// mergeInfo answers the merge status query with the repository's settings
+// and a merge state derived from the PR's draft flag, conflicts, checks and reviews
+func (s *Server) mergeInfo(vars map[string]interface{}) (interface{}, error) {
+	repo, pr, err := s.graphQLPull(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	state := strings.ToUpper(pr.State)
+	if pr.Merged {
+		state = "MERGED"
+	}
+	mergeable := "UNKNOWN"
+	if pr.Mergeable != nil {
+		mergeable = map[bool]string{true: "MERGEABLE", false: "CONFLICTING"}[*pr.Mergeable]
+	}
+
+	var rollup interface{}
+	checks := checksState(pr.Checks)
+	if checks != "" {
+		rollup = map[string]string{"state": checks}
+	}
+	var decision interface{}
+	if d := reviewDecision(pr.Reviews); d != "" {
+		decision = d
+	}
+
+	mergeState := "CLEAN"
+	switch {
+	case pr.Draft:
+		mergeState = "DRAFT"
+	case mergeable == "CONFLICTING":
+		mergeState = "DIRTY"
+	case decision == "CHANGES_REQUESTED":
+		mergeState = "BLOCKED"
+	case checks == "FAILURE" || checks == "PENDING":
+		mergeState = "UNSTABLE"
+	}
+
+	var autoMerge interface{}
+	if pr.AutoMerge {
+		autoMerge = map[string]string{"enabledAt": pr.UpdatedAt.Format("2006-01-02T15:04:05Z")}
+	}
+
+	return map[string]interface{}{"repository": map[string]interface{}{
+		"mergeCommitAllowed":  allowsMethod(repo, "merge"),
+		"squashMergeAllowed":  allowsMethod(repo, "squash"),
+		"rebaseMergeAllowed":  allowsMethod(repo, "rebase"),
+		"autoMergeAllowed":    repo.AutoMergeAllowed,
+		"deleteBranchOnMerge": repo.DeleteBranchOnMerge,
+		"pullRequest": map[string]interface{}{
+			"id":               nodeID(repo, pr),
+			"title":            pr.Title,
+			"body":             pr.Body,
+			"state":            state,
+			"isDraft":          pr.Draft,
+			"headRefOid":       pr.HeadSHA,
+			"mergeable":        mergeable,
+			"mergeStateStatus": mergeState,
+			"reviewDecision":   decision,
+			"autoMergeRequest": autoMerge,
+			"commits": map[string]interface{}{"nodes": []interface{}{
+				map[string]interface{}{"commit": map[string]interface{}{"statusCheckRollup": rollup}},
+			}},
+		},
+	}}, nil
+}
+
+// checksState rolls checks up the way statusCheckRollup does, or "" without checks
+func checksState(checks []*Check) string {
+	if len(checks) == 0 {
+		return ""
+	}
+	state := "SUCCESS"
+	for _, c := range checks {
+		switch {
+		case c.Status != "completed":
+			if state == "SUCCESS" {
+				state = "PENDING"
+			}
+		case c.Conclusion == "failure" || c.Conclusion == "timed_out" || c.Conclusion == "cancelled":
+			return "FAILURE"
+		}
+	}
+	return state
+}
+
+// reviewDecision derives the review decision from each reviewer's latest verdict
+func reviewDecision(reviews []*Review) string {
+	latest := make(map[string]string)
+	for _, rv := range reviews {
+		if rv.State == "APPROVED" || rv.State == "CHANGES_REQUESTED" {
+			latest[rv.Author] = rv.State
+		}
+	}
+	decision := ""
+	for _, state := range latest {
+		if state == "CHANGES_REQUESTED" {
+			return state
+		}
+		decision = state
+	}
+	return decision
+}
+
+// threads returns the first comment of each review thread of a pull request
+func threads(pr *PullRequest) []*ReviewComment {
+	var first []*ReviewComment
+	for _, c := range pr.ReviewComments {
+		if c.InReplyTo == 0 {
+			first = append(first, c)
+		}
+	}
+	return first
+}
+
+// reviewThreads answers the review thread query, all in one page
+func (s *Server) reviewThreads(vars map[string]interface{}) (interface{}, error) {
+	_, pr, err := s.graphQLPull(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]interface{}, 0)
+	for _, c := range threads(pr) {
+		nodes = append(nodes, map[string]interface{}{
+			"id":         "PRRT_" + strconv.FormatInt(c.ID, 10),
+			"isResolved": c.Resolved,
+			"isOutdated": false,
+			"line":       c.Line,
+			"startLine":  nil,
+			"comments": map[string]interface{}{"nodes": []interface{}{
+				map[string]interface{}{"databaseId": c.ID},
+			}},
+		})
+	}
+	return map[string]interface{}{"repository": map[string]interface{}{
+		"pullRequest": map[string]interface{}{
+			"reviewThreads": map[string]interface{}{
+				"pageInfo": map[string]interface{}{"hasNextPage": false, "endCursor": nil},
+				"nodes":    nodes,
+			},
+		},
+	}}, nil
+}
+
+// activity answers a query with one aliased pullRequest field per PR,
+// counting its comments and listing its review threads with theirs
+func (s *Server) activity(query string, vars map[string]interface{}) (interface{}, error) {
+	repo, err := s.graphQLRepo(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]interface{})
+	for _, m := range activityField.FindAllStringSubmatch(query, -1) {
+		number, _ := strconv.Atoi(m[2])
+		var pr *PullRequest
+		for _, candidate := range repo.PullRequests {
+			if candidate.Number == number {
+				pr = candidate
+			}
+		}
+		if pr == nil {
+			fields[m[1]] = nil
+			continue
+		}
+		nodes := make([]interface{}, 0)
+		for _, first := range threads(pr) {
+			comments := 0
+			for _, c := range pr.ReviewComments {
+				if c == first || c.InReplyTo == first.ID {
+					comments++
+				}
+			}
+			nodes = append(nodes, map[string]interface{}{
+				"isResolved": first.Resolved,
+				"comments":   map[string]int{"totalCount": comments},
+			})
+		}
+		fields[m[1]] = map[string]interface{}{
+			"comments":      map[string]int{"totalCount": len(pr.Comments)},
+			"reviewThreads": map[string]interface{}{"nodes": nodes},
+		}
+	}
+	return map[string]interface{}{"repository": fields}, nil
+}
@@ -0,0 +1,166 @@
+package fakegh
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v58/github"
+)
+
+// The functions below render the data as GitHub's REST API does, using
+// go-github's types so field names and formats match the real thing.
+
+func user(login string) *github.User {
+	return &github.User{
+		Login:   github.String(login),
+		Type:    github.String("User"),
+		HTMLURL: github.String("https://github.com/" + login),
+	}
+}
+
+func users(logins []string) []*github.User {
+	out := make([]*github.User, 0, len(logins))
+	for _, login := range logins {
+		out = append(out, user(login))
+	}
+	return out
+}
+
+func labels(names []string) []*github.Label {
+	out := make([]*github.Label, 0, len(names))
+	for _, name := range names {
+		out = append(out, &github.Label{Name: github.String(name)})
+	}
+	return out
+}
+
+// timestamp renders a time, or nothing for the zero time
+func timestamp(t time.Time) *github.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return &github.Timestamp{Time: t}
+}
+
+func repoJSON(repo *Repo) *github.Repository {
+	return &github.Repository{
+		Name:      github.String(repo.Name),
+		FullName:  github.String(repo.FullName()),
+		Owner:     user(repo.Owner),
+		Private:   github.Bool(repo.Private),
+		HTMLURL:   github.String("https://github.com/" + repo.FullName()),
+		UpdatedAt: timestamp(repo.UpdatedAt),
+	}
+}
+
+func prJSON(repo *Repo, repoIndex int, pr *PullRequest) *github.PullRequest {
+	out := &github.PullRequest{
+		ID:        github.Int64(prID(repoIndex, pr.Number)),
+		NodeID:    github.String(nodeID(repo, pr)),
+		Number:    github.Int(pr.Number),
+		Title:     github.String(pr.Title),
+		Body:      github.String(pr.Body),
+		State:     github.String(pr.State),
+		Draft:     github.Bool(pr.Draft),
+		Merged:    github.Bool(pr.Merged),
+		Mergeable: pr.Mergeable,
+		HTMLURL:   github.String(fmt.Sprintf("https://github.com/%s/pull/%d", repo.FullName(), pr.Number)),
+		User:      user(pr.Author),
+		CreatedAt: timestamp(pr.CreatedAt),
+		UpdatedAt: timestamp(pr.UpdatedAt),
+		ClosedAt:  timestamp(pr.ClosedAt),
+		MergedAt:  timestamp(pr.MergedAt),
+		Labels:    labels(pr.Labels),
+		Assignees: users(pr.Assignees),
+		Comments:  github.Int(len(pr.Comments)),
+		Commits:   github.Int(1),
+		Head: &github.PullRequestBranch{
+			Ref:  github.String(pr.Head),
+			SHA:  github.String(pr.HeadSHA),
+			Repo: repoJSON(repo),
+		},
+		Base: &github.PullRequestBranch{
+			Ref:  github.String(pr.Base),
+			Repo: repoJSON(repo),
+		},
+	}
+	if pr.MergedBy != "" {
+		out.MergedBy = user(pr.MergedBy)
+	}
+
+	additions, deletions := 0, 0
+	for _, f := range pr.Files {
+		additions += f.Additions
+		deletions += f.Deletions
+	}
+	out.Additions, out.Deletions, out.ChangedFiles = github.Int(additions), github.Int(deletions), github.Int(len(pr.Files))
+
+	for _, reviewer := range pr.Reviewers {
+		if owner, slug, ok := strings.Cut(reviewer, "/"); ok && owner == repo.Owner {
+			out.RequestedTeams = append(out.RequestedTeams, &github.Team{Slug: github.String(slug)})
+		} else {
+			out.RequestedReviewers = append(out.RequestedReviewers, user(reviewer))
+		}
+	}
+	return out
+}
+
+func commentJSON(repo *Repo, pr *PullRequest, c *Comment) *github.IssueComment {
+	return &github.IssueComment{
+		ID:        github.Int64(c.ID),
+		Body:      github.String(c.Body),
+		User:      user(c.Author),
+		CreatedAt: timestamp(c.CreatedAt),
+		UpdatedAt: timestamp(c.CreatedAt),
+		HTMLURL:   github.String(fmt.Sprintf("https://github.com/%s/pull/%d#issuecomment-%d", repo.FullName(), pr.Number, c.ID)),
+	}
+}
+
+func reviewCommentJSON(repo *Repo, pr *PullRequest, c *ReviewComment) *github.PullRequestComment {
+	out := &github.PullRequestComment{
+		ID:           github.Int64(c.ID),
+		Body:         github.String(c.Body),
+		User:         user(c.Author),
+		Path:         github.String(c.Path),
+		Line:         github.Int(c.Line),
+		OriginalLine: github.Int(c.Line),
+		Side:         github.String("RIGHT"),
+		CommitID:     github.String(pr.HeadSHA),
+		CreatedAt:    timestamp(c.CreatedAt),
+		UpdatedAt:    timestamp(c.CreatedAt),
+		HTMLURL:      github.String(fmt.Sprintf("https://github.com/%s/pull/%d#discussion_r%d", repo.FullName(), pr.Number, c.ID)),
+	}
+	if c.InReplyTo != 0 {
+		out.InReplyTo = github.Int64(c.InReplyTo)
+	}
+	return out
+}
+
+func reviewJSON(repo *Repo, pr *PullRequest, rv *Review) *github.PullRequestReview {
+	return &github.PullRequestReview{
+		ID:          github.Int64(rv.ID),
+		Body:        github.String(rv.Body),
+		User:        user(rv.Author),
+		State:       github.String(rv.State),
+		CommitID:    github.String(pr.HeadSHA),
+		SubmittedAt: timestamp(rv.SubmittedAt),
+		HTMLURL:     github.String(fmt.Sprintf("https://github.com/%s/pull/%d#pullrequestreview-%d", repo.FullName(), pr.Number, rv.ID)),
+	}
+}
+
+func checkRunJSON(repo *Repo, c *Check) *github.CheckRun {
+	run := &github.CheckRun{
+		ID:      github.Int64(c.ID),
+		Name:    github.String(c.Name),
+		Status:  github.String(c.Status),
+		HTMLURL: github.String(fmt.Sprintf("https://github.com/%s/runs/%d", repo.FullName(), c.ID)),
+	}
+	if c.Conclusion != "" {
+		run.Conclusion = github.String(c.Conclusion)
+	}
+	if c.App != "" {
+		run.App = &github.App{Slug: github.String(c.App)}
+	}
+	return run
+}
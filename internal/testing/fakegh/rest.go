@@ -0,0 +1,673 @@
+package fakegh
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v58/github"
+)
+
+// restRoutes lists the REST endpoints the server implements
+func (s *Server) restRoutes() []route {
+	return []route{
+		handle("GET", "/user", s.getUser),
+		handle("GET", "/user/orgs", s.listOrgs),
+		handle("GET", "/users/{owner}/repos", s.listRepos),
+		handle("GET", "/orgs/{owner}/repos", s.listRepos),
+		handle("GET", "/rate_limit", s.getRateLimit),
+
+		handle("GET", "/repos/{owner}/{repo}", s.getRepo),
+		handle("GET", "/repos/{owner}/{repo}/labels", s.listLabels),
+		handle("GET", "/repos/{owner}/{repo}/assignees", s.listAssignees),
+		handle("GET", "/repos/{owner}/{repo}/teams", s.listTeams),
+		handle("DELETE", "/repos/{owner}/{repo}/git/refs/heads/{branch...}", s.deleteBranch),
+
+		handle("GET", "/repos/{owner}/{repo}/pulls", s.listPulls),
+		handle("GET", "/repos/{owner}/{repo}/pulls/{number}", s.getPull),
+		handle("PATCH", "/repos/{owner}/{repo}/pulls/{number}", s.editPull),
+		handle("PUT", "/repos/{owner}/{repo}/pulls/{number}/merge", s.mergePull),
+		handle("GET", "/repos/{owner}/{repo}/pulls/{number}/files", s.listFiles),
+		handle("GET", "/repos/{owner}/{repo}/pulls/{number}/comments", s.listReviewComments),
+		handle("POST", "/repos/{owner}/{repo}/pulls/{number}/comments", s.createReviewComment),
+		handle("GET", "/repos/{owner}/{repo}/pulls/{number}/reviews", s.listReviews),
+		handle("POST", "/repos/{owner}/{repo}/pulls/{number}/reviews", s.createReview),
+		handle("POST", "/repos/{owner}/{repo}/pulls/{number}/requested_reviewers", s.editReviewers),
+		handle("DELETE", "/repos/{owner}/{repo}/pulls/{number}/requested_reviewers", s.editReviewers),
+
+		handle("GET", "/repos/{owner}/{repo}/issues/{number}/comments", s.listComments),
+		handle("POST", "/repos/{owner}/{repo}/issues/{number}/comments", s.createComment),
+		handle("POST", "/repos/{owner}/{repo}/issues/{number}/labels", s.addLabels),
+		handle("DELETE", "/repos/{owner}/{repo}/issues/{number}/labels/{label}", s.removeLabel),
+		handle("POST", "/repos/{owner}/{repo}/issues/{number}/assignees", s.editAssignees),
+		handle("DELETE", "/repos/{owner}/{repo}/issues/{number}/assignees", s.editAssignees),
+
+		handle("GET", "/repos/{owner}/{repo}/commits/{ref}/check-runs", s.listCheckRuns),
+		handle("GET", "/repos/{owner}/{repo}/commits/{ref}/status", s.getCombinedStatus),
+		handle("GET", "/repos/{owner}/{repo}/actions/jobs/{job}", s.getJob),
+		handle("GET", "/repos/{owner}/{repo}/actions/jobs/{job}/logs", s.getJobLogs),
+		handle("POST", "/repos/{owner}/{repo}/actions/runs/{run}/rerun-failed-jobs", s.rerunFailedJobs),
+	}
+}
+
+// repo looks up the repository of a request, answering with a 404 if there is none
+func (s *Server) repo(w http.ResponseWriter, p params) (*Repo, int) {
+	for i, repo := range s.data.Repos {
+		if strings.EqualFold(repo.Owner, p["owner"]) && strings.EqualFold(repo.Name, p["repo"]) {
+			return repo, i
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not Found")
+	return nil, 0
+}
+
+// pull looks up the pull request of a request, answering with a 404 if there
+// is none. It also returns the index of the repository, which PR IDs derive from.
+func (s *Server) pull(w http.ResponseWriter, p params) (*Repo, *PullRequest, int) {
+	repo, index := s.repo(w, p)
+	if repo == nil {
+		return nil, nil, 0
+	}
+	number := p.int("number")
+	for _, pr := range repo.PullRequests {
+		if pr.Number == number {
+			return repo, pr, index
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not Found")
+	return nil, nil, 0
+}
+
+// prID is a pull request's database ID
+func prID(repoIndex, number int) int64 {
+	return int64(repoIndex+1)*100000 + int64(number)
+}
+
+// nodeID is a pull request's GraphQL ID
+func nodeID(repo *Repo, pr *PullRequest) string {
+	return fmt.Sprintf("PR_%s/%s/%d", repo.Owner, repo.Name, pr.Number)
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request, p params) {
+	s.writeJSON(w, r, http.StatusOK, user(s.data.User))
+}
+
+func (s *Server) listOrgs(w http.ResponseWriter, r *http.Request, p params) {
+	orgs := make([]*github.Organization, 0, len(s.data.Orgs))
+	for _, org := range s.data.Orgs {
+		orgs = append(orgs, &github.Organization{Login: github.String(org)})
+	}
+	s.writeJSON(w, r, http.StatusOK, paginate(s, w, r, orgs))
+}
+
+// listRepos lists an owner's repositories, most recently updated first
+func (s *Server) listRepos(w http.ResponseWriter, r *http.Request, p params) {
+	// Any owner with repositories can be listed, not only the viewer's orgs
+	known := strings.EqualFold(p["owner"], s.data.User)
+	for _, org := range s.data.Orgs {
+		known = known || strings.EqualFold(p["owner"], org)
+	}
+	for _, repo := range s.data.Repos {
+		known = known || strings.EqualFold(p["owner"], repo.Owner)
+	}
+	if !known {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	var repos []*Repo
+	for _, repo := range s.data.Repos {
+		if strings.EqualFold(repo.Owner, p["owner"]) {
+			repos = append(repos, repo)
+		}
+	}
+	sort.SliceStable(repos, func(i, j int) bool { return repos[i].UpdatedAt.After(repos[j].UpdatedAt) })
+
+	out := make([]*github.Repository, 0, len(repos))
+	for _, repo := range paginate(s, w, r, repos) {
+		out = append(out, repoJSON(repo))
+	}
+	s.writeJSON(w, r, http.StatusOK, out)
+}
+
+func (s *Server) getRateLimit(w http.ResponseWriter, r *http.Request, p params) {
+	core := map[string]int64{"limit": int64(s.limit), "remaining": int64(s.remaining), "reset": s.reset.Unix()}
+	s.writeJSON(w, r, http.StatusOK, map[string]interface{}{
+		"resources": map[string]interface{}{"core": core},
+		"rate":      core,
+	})
+}
+
+func (s *Server) getRepo(w http.ResponseWriter, r *http.Request, p params) {
+	if repo, _ := s.repo(w, p); repo != nil {
+		s.writeJSON(w, r, http.StatusOK, repoJSON(repo))
+	}
+}
+
+func (s *Server) listLabels(w http.ResponseWriter, r *http.Request, p params) {
+	if repo, _ := s.repo(w, p); repo != nil {
+		s.writeJSON(w, r, http.StatusOK, paginate(s, w, r, labels(repo.Labels)))
+	}
+}
+
+func (s *Server) listAssignees(w http.ResponseWriter, r *http.Request, p params) {
+	if repo, _ := s.repo(w, p); repo != nil {
+		s.writeJSON(w, r, http.StatusOK, paginate(s, w, r, users(repo.Collaborators)))
+	}
+}
+
+func (s *Server) listTeams(w http.ResponseWriter, r *http.Request, p params) {
+	repo, _ := s.repo(w, p)
+	if repo == nil {
+		return
+	}
+	teams := make([]*github.Team, 0, len(repo.Teams))
+	for _, slug := range repo.Teams {
+		teams = append(teams, &github.Team{Slug: github.String(slug), Name: github.String(slug)})
+	}
+	s.writeJSON(w, r, http.StatusOK, paginate(s, w, r, teams))
+}
+
+func (s *Server) deleteBranch(w http.ResponseWriter, r *http.Request, p params) {
+	repo, _ := s.repo(w, p)
+	if repo == nil {
+		return
+	}
+	for i, branch := range repo.Branches {
+		if branch == p["branch"] {
+			repo.Branches = append(repo.Branches[:i], repo.Branches[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	writeError(w, http.StatusUnprocessableEntity, "Reference does not exist")
+}
+
+// listPulls lists pull requests in a state (open, closed or all), most
+// recently updated first
+func (s *Server) listPulls(w http.ResponseWriter, r *http.Request, p params) {
+	repo, index := s.repo(w, p)
+	if repo == nil {
+		return
+	}
+	state := r.URL.Query().Get("state")
+	if state == "" {
+		state = "open"
+	}
+
+	var prs []*PullRequest
+	for _, pr := range repo.PullRequests {
+		if state == "all" || pr.State == state {
+			prs = append(prs, pr)
+		}
+	}
+	sort.SliceStable(prs, func(i, j int) bool { return prs[i].UpdatedAt.After(prs[j].UpdatedAt) })
+
+	out := make([]*github.PullRequest, 0, len(prs))
+	for _, pr := range paginate(s, w, r, prs) {
+		out = append(out, prJSON(repo, index, pr))
+	}
+	s.writeJSON(w, r, http.StatusOK, out)
+}
+
+func (s *Server) getPull(w http.ResponseWriter, r *http.Request, p params) {
+	if repo, pr, index := s.pull(w, p); pr != nil {
+		s.writeJSON(w, r, http.StatusOK, prJSON(repo, index, pr))
+	}
+}
+
+// editPull updates a pull request's title, body or state
+func (s *Server) editPull(w http.ResponseWriter, r *http.Request, p params) {
+	repo, pr, index := s.pull(w, p)
+	if pr == nil {
+		return
+	}
+	var update struct {
+		Title *string `json:"title"`
+		Body  *string `json:"body"`
+		State *string `json:"state"`
+	}
+	if !decode(w, r, &update) {
+		return
+	}
+
+	now := time.Now()
+	if update.State != nil && *update.State != pr.State {
+		if pr.Merged {
+			writeError(w, http.StatusUnprocessableEntity, "Cannot change the state of a merged pull request")
+			return
+		}
+		pr.State = *update.State
+		pr.ClosedAt = time.Time{}
+		if pr.State == "closed" {
+			pr.ClosedAt = now
+		}
+	}
+	if update.Title != nil {
+		pr.Title = *update.Title
+	}
+	if update.Body != nil {
+		pr.Body = *update.Body
+	}
+	pr.UpdatedAt = now
+	s.writeJSON(w, r, http.StatusOK, prJSON(repo, index, pr))
+}
+
+// mergePull merges a pull request if it's open, mergeable and still at the
+// head commit the caller expects
+func (s *Server) mergePull(w http.ResponseWriter, r *http.Request, p params) {
+	repo, pr, _ := s.pull(w, p)
+	if pr == nil {
+		return
+	}
+	var req struct {
+		SHA         string `json:"sha"`
+		MergeMethod string `json:"merge_method"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	switch {
+	case pr.State != "open" || pr.Draft || (pr.Mergeable != nil && !*pr.Mergeable):
+		writeError(w, http.StatusMethodNotAllowed, "Pull Request is not mergeable")
+		return
+	case req.SHA != "" && req.SHA != pr.HeadSHA:
+		writeError(w, http.StatusConflict, "Head branch was modified. Review and try the merge again.")
+		return
+	case req.MergeMethod != "" && !allowsMethod(repo, req.MergeMethod):
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("%s merges are not allowed on this repository.", req.MergeMethod))
+		return
+	}
+
+	now := time.Now()
+	pr.State, pr.Merged, pr.MergedBy = "closed", true, s.data.User
+	pr.MergedAt, pr.ClosedAt, pr.UpdatedAt = now, now, now
+	s.writeJSON(w, r, http.StatusOK, &github.PullRequestMergeResult{
+		SHA:     github.String(pr.HeadSHA),
+		Merged:  github.Bool(true),
+		Message: github.String("Pull Request successfully merged"),
+	})
+}
+
+// allowsMethod reports whether a repository allows a merge method
+func allowsMethod(repo *Repo, method string) bool {
+	if len(repo.MergeMethods) == 0 {
+		return true
+	}
+	for _, m := range repo.MergeMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) listFiles(w http.ResponseWriter, r *http.Request, p params) {
+	_, pr, _ := s.pull(w, p)
+	if pr == nil {
+		return
+	}
+	files := make([]*github.CommitFile, 0, len(pr.Files))
+	for _, f := range paginate(s, w, r, pr.Files) {
+		files = append(files, &github.CommitFile{
+			Filename:  github.String(f.Name),
+			Status:    github.String(f.Status),
+			Additions: github.Int(f.Additions),
+			Deletions: github.Int(f.Deletions),
+			Changes:   github.Int(f.Additions + f.Deletions),
+			Patch:     github.String(f.Patch),
+		})
+	}
+	s.writeJSON(w, r, http.StatusOK, files)
+}
+
+func (s *Server) listReviewComments(w http.ResponseWriter, r *http.Request, p params) {
+	repo, pr, _ := s.pull(w, p)
+	if pr == nil {
+		return
+	}
+	out := make([]*github.PullRequestComment, 0, len(pr.ReviewComments))
+	for _, c := range paginate(s, w, r, pr.ReviewComments) {
+		out = append(out, reviewCommentJSON(repo, pr, c))
+	}
+	s.writeJSON(w, r, http.StatusOK, out)
+}
+
+// createReviewComment posts a reply in a review thread
+func (s *Server) createReviewComment(w http.ResponseWriter, r *http.Request, p params) {
+	repo, pr, _ := s.pull(w, p)
+	if pr == nil {
+		return
+	}
+	var req struct {
+		Body      string `json:"body"`
+		InReplyTo int64  `json:"in_reply_to"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	var parent *ReviewComment
+	for _, c := range pr.ReviewComments {
+		if c.ID == req.InReplyTo {
+			parent = c
+		}
+	}
+	if parent == nil {
+		writeError(w, http.StatusUnprocessableEntity, "in_reply_to is not a review comment of this pull request")
+		return
+	}
+	if parent.InReplyTo != 0 {
+		req.InReplyTo = parent.InReplyTo
+	}
+
+	c := &ReviewComment{
+		ID:        s.id(),
+		Author:    s.data.User,
+		Body:      req.Body,
+		Path:      parent.Path,
+		Line:      parent.Line,
+		InReplyTo: req.InReplyTo,
+		CreatedAt: time.Now(),
+	}
+	pr.ReviewComments = append(pr.ReviewComments, c)
+	pr.UpdatedAt = c.CreatedAt
+	s.writeJSON(w, r, http.StatusCreated, reviewCommentJSON(repo, pr, c))
+}
+
+func (s *Server) listReviews(w http.ResponseWriter, r *http.Request, p params) {
+	repo, pr, _ := s.pull(w, p)
+	if pr == nil {
+		return
+	}
+	out := make([]*github.PullRequestReview, 0, len(pr.Reviews))
+	for _, rv := range paginate(s, w, r, pr.Reviews) {
+		out = append(out, reviewJSON(repo, pr, rv))
+	}
+	s.writeJSON(w, r, http.StatusOK, out)
+}
+
+// reviewStates maps review events to the state of the submitted review
+var reviewStates = map[string]string{
+	"APPROVE":         "APPROVED",
+	"REQUEST_CHANGES": "CHANGES_REQUESTED",
+	"COMMENT":         "COMMENTED",
+}
+
+// createReview submits a review. Like GitHub, authors can't approve or request
+// changes on their own pull requests.
+func (s *Server) createReview(w http.ResponseWriter, r *http.Request, p params) {
+	repo, pr, _ := s.pull(w, p)
+	if pr == nil {
+		return
+	}
+	var req struct {
+		Body  string `json:"body"`
+		Event string `json:"event"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	state, ok := reviewStates[req.Event]
+	switch {
+	case !ok:
+		writeError(w, http.StatusUnprocessableEntity, "Unknown review event")
+		return
+	case state != "COMMENTED" && pr.Author == s.data.User:
+		writeError(w, http.StatusUnprocessableEntity, "Can not approve or request changes on your own pull request")
+		return
+	case state != "APPROVED" && req.Body == "":
+		writeError(w, http.StatusUnprocessableEntity, "Review body is required")
+		return
+	}
+
+	rv := &Review{ID: s.id(), Author: s.data.User, State: state, Body: req.Body, SubmittedAt: time.Now()}
+	pr.Reviews = append(pr.Reviews, rv)
+	pr.UpdatedAt = rv.SubmittedAt
+	s.writeJSON(w, r, http.StatusOK, reviewJSON(repo, pr, rv))
+}
+
+// editReviewers requests (POST) or removes (DELETE) reviewers
+func (s *Server) editReviewers(w http.ResponseWriter, r *http.Request, p params) {
+	repo, pr, index := s.pull(w, p)
+	if pr == nil {
+		return
+	}
+	var req github.ReviewersRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	reviewers := append([]string(nil), req.Reviewers...)
+	for _, team := range req.TeamReviewers {
+		reviewers = append(reviewers, repo.Owner+"/"+team)
+	}
+	pr.Reviewers = edit(pr.Reviewers, reviewers, r.Method == http.MethodDelete)
+	pr.UpdatedAt = time.Now()
+	s.writeJSON(w, r, http.StatusOK, prJSON(repo, index, pr))
+}
+
+func (s *Server) listComments(w http.ResponseWriter, r *http.Request, p params) {
+	repo, pr, _ := s.pull(w, p)
+	if pr == nil {
+		return
+	}
+	out := make([]*github.IssueComment, 0, len(pr.Comments))
+	for _, c := range paginate(s, w, r, pr.Comments) {
+		out = append(out, commentJSON(repo, pr, c))
+	}
+	s.writeJSON(w, r, http.StatusOK, out)
+}
+
+func (s *Server) createComment(w http.ResponseWriter, r *http.Request, p params) {
+	repo, pr, _ := s.pull(w, p)
+	if pr == nil {
+		return
+	}
+	var req struct {
+		Body string `json:"body"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Body == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Body cannot be blank")
+		return
+	}
+
+	c := &Comment{ID: s.id(), Author: s.data.User, Body: req.Body, CreatedAt: time.Now()}
+	pr.Comments = append(pr.Comments, c)
+	pr.UpdatedAt = c.CreatedAt
+	s.writeJSON(w, r, http.StatusCreated, commentJSON(repo, pr, c))
+}
+
+// addLabels adds labels, which must exist in the repository
+func (s *Server) addLabels(w http.ResponseWriter, r *http.Request, p params) {
+	repo, pr, _ := s.pull(w, p)
+	if pr == nil {
+		return
+	}
+	var add []string
+	if !decode(w, r, &add) {
+		return
+	}
+	for _, label := range add {
+		if !contains(repo.Labels, label) {
+			writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Label %q does not exist", label))
+			return
+		}
+	}
+	pr.Labels = edit(pr.Labels, add, false)
+	pr.UpdatedAt = time.Now()
+	s.writeJSON(w, r, http.StatusOK, labels(pr.Labels))
+}
+
+func (s *Server) removeLabel(w http.ResponseWriter, r *http.Request, p params) {
+	_, pr, _ := s.pull(w, p)
+	if pr == nil {
+		return
+	}
+	if !contains(pr.Labels, p["label"]) {
+		writeError(w, http.StatusNotFound, "Label does not exist")
+		return
+	}
+	pr.Labels = edit(pr.Labels, []string{p["label"]}, true)
+	pr.UpdatedAt = time.Now()
+	s.writeJSON(w, r, http.StatusOK, labels(pr.Labels))
+}
+
+// editAssignees adds (POST) or removes (DELETE) assignees
+func (s *Server) editAssignees(w http.ResponseWriter, r *http.Request, p params) {
+	_, pr, _ := s.pull(w, p)
+	if pr == nil {
+		return
+	}
+	var req struct {
+		Assignees []string `json:"assignees"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	pr.Assignees = edit(pr.Assignees, req.Assignees, r.Method == http.MethodDelete)
+	pr.UpdatedAt = time.Now()
+	s.writeJSON(w, r, http.StatusOK, &github.Issue{Number: github.Int(pr.Number), Assignees: users(pr.Assignees)})
+}
+
+// checksFor returns the checks of the pull request whose head is ref (a SHA or branch)
+func (s *Server) checksFor(repo *Repo, ref string) []*Check {
+	for _, pr := range repo.PullRequests {
+		if pr.HeadSHA == ref || pr.Head == ref {
+			return pr.Checks
+		}
+	}
+	return nil
+}
+
+func (s *Server) listCheckRuns(w http.ResponseWriter, r *http.Request, p params) {
+	repo, _ := s.repo(w, p)
+	if repo == nil {
+		return
+	}
+	checks := s.checksFor(repo, p["ref"])
+	runs := make([]*github.CheckRun, 0, len(checks))
+	for _, c := range paginate(s, w, r, checks) {
+		runs = append(runs, checkRunJSON(repo, c))
+	}
+	s.writeJSON(w, r, http.StatusOK, &github.ListCheckRunsResults{Total: github.Int(len(checks)), CheckRuns: runs})
+}
+
+// getCombinedStatus reports no commit statuses; checks are modelled as check runs
+func (s *Server) getCombinedStatus(w http.ResponseWriter, r *http.Request, p params) {
+	if repo, _ := s.repo(w, p); repo != nil {
+		s.writeJSON(w, r, http.StatusOK, &github.CombinedStatus{
+			State:      github.String("success"),
+			SHA:        github.String(p["ref"]),
+			TotalCount: github.Int(0),
+			Statuses:   []*github.RepoStatus{},
+		})
+	}
+}
+
+// job looks up an Actions job by ID, answering with a 404 if there is none
+func (s *Server) job(w http.ResponseWriter, repo *Repo, id int64) *Check {
+	for _, pr := range repo.PullRequests {
+		for _, c := range pr.Checks {
+			if c.ID == id && c.App == "github-actions" {
+				return c
+			}
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not Found")
+	return nil
+}
+
+// getJob returns an Actions job. Each job is its own workflow run, with one
+// step per check.
+func (s *Server) getJob(w http.ResponseWriter, r *http.Request, p params) {
+	repo, _ := s.repo(w, p)
+	if repo == nil {
+		return
+	}
+	id, _ := strconv.ParseInt(p["job"], 10, 64)
+	c := s.job(w, repo, id)
+	if c == nil {
+		return
+	}
+	s.writeJSON(w, r, http.StatusOK, &github.WorkflowJob{
+		ID:         github.Int64(c.ID),
+		RunID:      github.Int64(c.ID),
+		Name:       github.String(c.Name),
+		Status:     github.String(c.Status),
+		Conclusion: github.String(c.Conclusion),
+		HTMLURL:    github.String(fmt.Sprintf("https://github.com/%s/actions/runs/%d/job/%d", repo.FullName(), c.ID, c.ID)),
+		Steps: []*github.TaskStep{{
+			Number:     github.Int64(1),
+			Name:       github.String(c.Name),
+			Status:     github.String(c.Status),
+			Conclusion: github.String(c.Conclusion),
+		}},
+	})
+}
+
+// getJobLogs redirects to the log download, as GitHub does
+func (s *Server) getJobLogs(w http.ResponseWriter, r *http.Request, p params) {
+	repo, _ := s.repo(w, p)
+	if repo == nil {
+		return
+	}
+	id, _ := strconv.ParseInt(p["job"], 10, 64)
+	if c := s.job(w, repo, id); c != nil {
+		http.Redirect(w, r, fmt.Sprintf("http://%s/_logs/%d", r.Host, c.ID), http.StatusFound)
+	}
+}
+
+func (s *Server) rerunFailedJobs(w http.ResponseWriter, r *http.Request, p params) {
+	repo, _ := s.repo(w, p)
+	if repo == nil {
+		return
+	}
+	id, _ := strconv.ParseInt(p["run"], 10, 64)
+	c := s.job(w, repo, id)
+	if c == nil {
+		return
+	}
+	if c.Conclusion != "failure" {
+		writeError(w, http.StatusForbidden, "This workflow run has no failed jobs to re-run")
+		return
+	}
+	c.Reruns++
+	c.Status, c.Conclusion = "queued", ""
+	w.WriteHeader(http.StatusCreated)
+}
+
+// edit adds values to a list, or removes them from it, keeping it free of duplicates
+func edit(list, values []string, remove bool) []string {
+	var out []string
+	for _, v := range list {
+		if !remove || !contains(values, v) {
+			out = append(out, v)
+		}
+	}
+	if !remove {
+		for _, v := range values {
+			if !contains(out, v) {
+				out = append(out, v)
+			}
+		}
+	}
+	return out
+}
+
+// contains reports whether list holds v
+func contains(list []string, v string) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
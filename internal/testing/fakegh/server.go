@@ -0,0 +1,424 @@
+package fakegh
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Fault makes matching requests fail, or answer slowly
+type Fault struct {
+	Method  string        // empty matches any method
+	Path    string        // prefix of the URL path, e.g. /repos/org/repo/pulls
+	Status  int           // status to fail with; 0 only delays the request
+	Message string        // error message, defaulting to the status text
+	Times   int           // requests to fail; 0 fails every matching request
+	Delay   time.Duration // wait before answering
+}
+
+// Server is an in-memory GitHub API. Its URL, with a trailing slash, is the
+// API base URL; GraphQL is served at URL/graphql.
+type Server struct {
+	URL string
+
+	server *httptest.Server
+	routes []route
+
+	mu         sync.Mutex
+	data       *Data
+	token      string
+	nextID     int64
+	maxPerPage int
+	limit      int
+	remaining  int
+	reset      time.Time
+	faults     []*Fault
+	requests   []string
+}
+
+// NewServer starts a server for data. Seeded comments, reviews and checks
+// without an ID get one.
+func NewServer(data *Data) *Server {
+	s := &Server{
+		data:       data,
+		nextID:     1000,
+		maxPerPage: 100,
+		limit:      5000,
+		remaining:  5000,
+		reset:      time.Now().Add(time.Hour),
+	}
+	s.assignIDs()
+	s.routes = s.restRoutes()
+	s.server = httptest.NewServer(s)
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts the server down
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// RequireToken makes the server answer requests without this token with a 401
+func (s *Server) RequireToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = token
+}
+
+// SetMaxPerPage caps page sizes below GitHub's 100, so small data sets span pages
+func (s *Server) SetMaxPerPage(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxPerPage = n
+}
+
+// SetRateLimit sets the core rate limit. Once remaining reaches 0, requests
+// fail with GitHub's rate limit error until reset.
+func (s *Server) SetRateLimit(limit, remaining int, reset time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.limit, s.remaining, s.reset = limit, remaining, reset
+}
+
+// Inject adds a fault; faults are matched in the order they were added
+func (s *Server) Inject(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// Requests returns the requests served so far, as "METHOD /path"
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// Edit runs fn with the data locked, to change it between requests or to
+// inspect what requests did to it
+func (s *Server) Edit(fn func(d *Data)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(s.data)
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fault := s.matchFault(r)
+	if fault != nil && fault.Delay > 0 {
+		select {
+		case <-time.After(fault.Delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+
+	// Log downloads are pre-signed URLs outside the API
+	if strings.HasPrefix(r.URL.Path, "/_logs/") {
+		s.serveLog(w, r)
+		return
+	}
+
+	if s.token != "" && !hasToken(r, s.token) {
+		writeError(w, http.StatusUnauthorized, "Bad credentials")
+		return
+	}
+	if !s.takeRateLimit(w, r) {
+		return
+	}
+	if fault != nil && fault.Status != 0 {
+		message := fault.Message
+		if message == "" {
+			message = http.StatusText(fault.Status)
+		}
+		writeError(w, fault.Status, message)
+		return
+	}
+
+	if r.URL.Path == "/graphql" && r.Method == http.MethodPost {
+		s.serveGraphQL(w, r)
+		return
+	}
+	for _, rt := range s.routes {
+		if params, ok := rt.match(r); ok {
+			rt.handle(w, r, params)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not Found")
+}
+
+// matchFault returns the first fault matching r, using up one of its times
+func (s *Server) matchFault(r *http.Request) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, f := range s.faults {
+		if (f.Method != "" && f.Method != r.Method) || !strings.HasPrefix(r.URL.Path, f.Path) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// hasToken reports whether r carries token, in either of the forms GitHub accepts
+func hasToken(r *http.Request, token string) bool {
+	auth := r.Header.Get("Authorization")
+	return auth == "Bearer "+token || auth == "token "+token
+}
+
+// takeRateLimit counts r against the rate limit and sets the rate limit
+// headers. It answers with GitHub's rate limit error and returns false once
+// the limit is used up.
+func (s *Server) takeRateLimit(w http.ResponseWriter, r *http.Request) bool {
+	now := time.Now()
+	if now.After(s.reset) {
+		s.remaining = s.limit
+		s.reset = now.Add(time.Hour)
+	}
+	exceeded := s.remaining == 0 && r.URL.Path != "/rate_limit"
+	if !exceeded && r.URL.Path != "/rate_limit" {
+		s.remaining--
+	}
+
+	h := w.Header()
+	h.Set("X-RateLimit-Limit", strconv.Itoa(s.limit))
+	h.Set("X-RateLimit-Remaining", strconv.Itoa(s.remaining))
+	h.Set("X-RateLimit-Used", strconv.Itoa(s.limit-s.remaining))
+	h.Set("X-RateLimit-Reset", strconv.FormatInt(s.reset.Unix(), 10))
+	h.Set("X-RateLimit-Resource", "core")
+
+	if exceeded {
+		writeError(w, http.StatusForbidden, "API rate limit exceeded for user.")
+		return false
+	}
+	return true
+}
+
+// refundRateLimit gives back a request GitHub doesn't count, e.g. a 304
+func (s *Server) refundRateLimit(w http.ResponseWriter) {
+	if s.remaining < s.limit {
+		s.remaining++
+	}
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(s.remaining))
+	w.Header().Set("X-RateLimit-Used", strconv.Itoa(s.limit-s.remaining))
+}
+
+// writeJSON answers with v. GET responses carry an ETag, and a matching
+// If-None-Match is answered with a 304 that doesn't count against the rate limit.
+func (s *Server) writeJSON(w http.ResponseWriter, r *http.Request, status int, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if r.Method == http.MethodGet && status == http.StatusOK {
+		sum := sha256.Sum256(body)
+		etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			s.refundRateLimit(w)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// writeError answers with a GitHub error body
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message":           message,
+		"documentation_url": "https://docs.github.com/rest",
+	})
+}
+
+// decode reads a JSON request body into v, answering with a 400 on failure
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+		return false
+	}
+	return true
+}
+
+// paginate returns the page of items r asks for and sets the Link header
+// pointing at the other pages, the way GitHub does
+func paginate[T any](s *Server, w http.ResponseWriter, r *http.Request, items []T) []T {
+	perPage := 30
+	if n, err := strconv.Atoi(r.URL.Query().Get("per_page")); err == nil && n > 0 {
+		perPage = n
+	}
+	if perPage > s.maxPerPage {
+		perPage = s.maxPerPage
+	}
+	page := 1
+	if n, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && n > 0 {
+		page = n
+	}
+
+	last := (len(items) + perPage - 1) / perPage
+	if last == 0 {
+		last = 1
+	}
+
+	var links []string
+	link := func(p int, rel string) {
+		u := url.URL{Scheme: "http", Host: r.Host, Path: r.URL.Path}
+		q := r.URL.Query()
+		q.Set("page", strconv.Itoa(p))
+		u.RawQuery = q.Encode()
+		links = append(links, fmt.Sprintf(`<%s>; rel="%s"`, u.String(), rel))
+	}
+	if page < last {
+		link(page+1, "next")
+		link(last, "last")
+	}
+	if page > 1 {
+		link(1, "first")
+		link(page-1, "prev")
+	}
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+
+	start := (page - 1) * perPage
+	if start >= len(items) {
+		return []T{}
+	}
+	end := start + perPage
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
+
+// route is a REST endpoint. Pattern segments in braces are parameters; a
+// last parameter ending in "..." takes the rest of the path (e.g. a branch
+// name with slashes).
+type route struct {
+	method  string
+	pattern []string
+	handle  func(w http.ResponseWriter, r *http.Request, p params)
+}
+
+// params are a route's path parameters
+type params map[string]string
+
+// int returns a numeric parameter, or 0
+func (p params) int(name string) int {
+	n, _ := strconv.Atoi(p[name])
+	return n
+}
+
+// match reports whether r is for this route and returns its parameters
+func (rt route) match(r *http.Request) (params, bool) {
+	if r.Method != rt.method {
+		return nil, false
+	}
+	segments := strings.Split(strings.Trim(r.URL.EscapedPath(), "/"), "/")
+	rest := strings.HasSuffix(rt.pattern[len(rt.pattern)-1], "...}")
+	if len(segments) != len(rt.pattern) && !(rest && len(segments) > len(rt.pattern)) {
+		return nil, false
+	}
+	p := make(params)
+	for i, want := range rt.pattern {
+		segment := segments[i]
+		if rest && i == len(rt.pattern)-1 {
+			segment = strings.Join(segments[i:], "/")
+			want = strings.Replace(want, "...", "", 1)
+		}
+		got, err := url.PathUnescape(segment)
+		if err != nil {
+			return nil, false
+		}
+		if strings.HasPrefix(want, "{") {
+			p[strings.Trim(want, "{}")] = got
+		} else if got != want {
+			return nil, false
+		}
+	}
+	return p, true
+}
+
+// handle registers a REST endpoint, e.g. "GET /repos/{owner}/{repo}"
+func handle(method, pattern string, fn func(w http.ResponseWriter, r *http.Request, p params)) route {
+	return route{method: method, pattern: strings.Split(strings.Trim(pattern, "/"), "/"), handle: fn}
+}
+
+// id returns a new ID for a created object
+func (s *Server) id() int64 {
+	s.nextID++
+	return s.nextID
+}
+
+// assignIDs gives seeded objects without an ID one
+func (s *Server) assignIDs() {
+	for _, repo := range s.data.Repos {
+		for _, pr := range repo.PullRequests {
+			for _, c := range pr.Checks {
+				if c.ID == 0 {
+					c.ID = s.id()
+				}
+			}
+			for _, c := range pr.Comments {
+				if c.ID == 0 {
+					c.ID = s.id()
+				}
+			}
+			for _, rv := range pr.Reviews {
+				if rv.ID == 0 {
+					rv.ID = s.id()
+				}
+			}
+			for _, c := range pr.ReviewComments {
+				if c.ID == 0 {
+					c.ID = s.id()
+				}
+			}
+		}
+	}
+}
+
+// serveLog serves the download of a job's log, at /_logs/{check ID}
+func (s *Server) serveLog(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/_logs/"), 10, 64)
+	for _, repo := range s.data.Repos {
+		for _, pr := range repo.PullRequests {
+			for _, c := range pr.Checks {
+				if c.ID == id {
+					w.Header().Set("Content-Type", "text/plain")
+					w.Write(bytes.TrimLeft([]byte(c.Log), "\n"))
+					return
+				}
+			}
+		}
+	}
+	http.NotFound(w, r)
+}
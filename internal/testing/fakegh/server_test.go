@@ -0,0 +1,74 @@
+package fakegh
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func get(t *testing.T, url string, header http.Header) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp
+}
+
+func TestPaginationLinks(t *testing.T) {
+	s := NewServer(Demo())
+	defer s.Close()
+
+	resp := get(t, s.URL+"/repos/widgets-inc/widgets/pulls?per_page=10&page=2", nil)
+	link := resp.Header.Get("Link")
+	for _, want := range []string{`page=3&per_page=10>; rel="next"`, `page=3&per_page=10>; rel="last"`, `page=1&per_page=10>; rel="first"`, `page=1&per_page=10>; rel="prev"`} {
+		if !strings.Contains(link, want) {
+			t.Errorf("Link header %q is missing %s", link, want)
+		}
+	}
+}
+
+func TestConditionalRequestsAreFree(t *testing.T) {
+	s := NewServer(Demo())
+	defer s.Close()
+
+	resp := get(t, s.URL+"/repos/acme/api", nil)
+	remaining := resp.Header.Get("X-RateLimit-Remaining")
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("Expected an ETag")
+	}
+
+	resp = get(t, s.URL+"/repos/acme/api", http.Header{"If-None-Match": {etag}})
+	if resp.StatusCode != http.StatusNotModified {
+		t.Errorf("Expected 304, got %d", resp.StatusCode)
+	}
+	if got := resp.Header.Get("X-RateLimit-Remaining"); got != remaining {
+		t.Errorf("Expected a 304 not to use up the rate limit, remaining went from %s to %s", remaining, got)
+	}
+}
+
+func TestFaultTimes(t *testing.T) {
+	s := NewServer(Demo())
+	defer s.Close()
+	s.Inject(Fault{Path: "/repos/acme", Status: http.StatusBadGateway, Times: 2})
+
+	var statuses []int
+	for i := 0; i < 3; i++ {
+		statuses = append(statuses, get(t, s.URL+"/repos/acme/web", nil).StatusCode)
+	}
+	if statuses[0] != http.StatusBadGateway || statuses[1] != http.StatusBadGateway || statuses[2] != http.StatusOK {
+		t.Errorf("Expected two failures then success, got %v", statuses)
+	}
+	if got := len(s.Requests()); got != 3 {
+		t.Errorf("Expected 3 recorded requests, got %d", got)
+	}
+}
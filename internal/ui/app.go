@@ -2,29 +2,148 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/will-wright-eng/gh-nav/internal/api"
+	"github.com/will-wright-eng/gh-nav/internal/browser"
 	"github.com/will-wright-eng/gh-nav/internal/constants"
+	"github.com/will-wright-eng/gh-nav/internal/git"
 	"github.com/will-wright-eng/gh-nav/internal/models"
 	"github.com/will-wright-eng/gh-nav/internal/ui/theme"
 	"github.com/will-wright-eng/gh-nav/internal/ui/views"
 	"github.com/will-wright-eng/gh-nav/pkg/config"
 )
 
-// Message types for the UI
+// Message types for the UI. Results of loads carry the ID of the request
+// they belong to (see requests), so late results for a view the user has
+// since left are dropped.
 type tickMsg time.Time
+type ownersLoadedMsg struct {
+	req  int
+	user string
+	orgs []string
+	err  error
+}
 type reposLoadedMsg struct {
-	repos []string
+	req   int
+	pager *api.Pager[string]
+	owner string
+	repos []string // one page of the owner's repositories
+	more  bool
 	err   error
 }
 type prsLoadedMsg struct {
-	prs []*models.PullRequest
+	req   int
+	first bool // the first page, replacing the list (or refreshing it)
+	more  bool
+	prs   []*models.PullRequest
+	err   error
+}
+type mergedByLoadedMsg struct {
+	req      int
+	pr       *models.PullRequest
+	mergedBy string
+	err      error
+}
+type statusMsg struct {
+	notice string
+	err    error
+}
+type checkoutDoneMsg struct {
+	pr     *models.PullRequest
+	result *git.CheckoutResult
+	err    error
+}
+type checksLoadedMsg struct {
+	req    int
+	checks []*models.Check
+	err    error
+}
+type filesLoadedMsg struct {
+	req        int
+	files      []*models.FileChange
+	threads    []*models.Thread
+	err        error
+	threadsErr error // review comments are optional in the diff
+}
+type jobLogLoadedMsg struct {
+	req int
+	log *models.JobLog
 	err error
 }
+type threadsLoadedMsg struct {
+	req     int
+	threads []*models.Thread
+	err     error
+}
+type activityLoadedMsg struct {
+	req      int
+	activity map[int]*models.Activity // by PR number
+	err      error
+}
+type draftPostedMsg struct {
+	draft  *models.Draft
+	closed bool // for closing drafts: the PR was closed, even if the comment failed
+	err    error
+}
+type lifecycleDoneMsg struct {
+	pr        *models.PullRequest
+	notice    string
+	prevDraft bool // restored if GitHub refuses the change
+	prevState string
+	err       error
+}
+type mergeInfoLoadedMsg struct {
+	req  int
+	info *models.MergeInfo
+	err  error
+}
+type mergeDoneMsg struct {
+	pr        *models.PullRequest
+	request   models.MergeRequest
+	err       error
+	branchErr error // the merge succeeded but the branch couldn't be deleted
+}
+type pickerOptionsLoadedMsg struct {
+	req     int
+	options []string
+	err     error
+}
+type fieldUpdatedMsg struct {
+	pr     *models.PullRequest
+	field  string
+	before []string
+	after  []string
+	err    error
+
+	// After a failure, the field's values on GitHub: updates aren't atomic,
+	// so some of the change may have been applied. Nil if they couldn't be
+	// fetched either.
+	current []string
+}
+type bulkStepDoneMsg struct {
+	job       int // bulk job the step belongs to
+	index     int // position of the PR in the job
+	autoMerge bool
+	err       error
+}
+type ciStatusesLoadedMsg struct {
+	req    int
+	checks map[int][]*models.Check // PR number -> checks
+	err    error
+}
+type outboxSentMsg struct {
+	total int
+	sent  []int // IDs of the queued changes GitHub accepted
+	err   error // the first failure; changes that failed stay queued
+}
 
 // ViewMode represents the current view state
 type ViewMode int
@@ -33,8 +152,25 @@ const (
 	OwnerSelection ViewMode = iota
 	RepoSelection
 	PRList
+	PRDetail
+	LogView
+	ThreadsView
+	FilesView
+	DiffView
+	ComposeView
+	MergeView
+	PickerView
+	BulkView
 )
 
+// bulkJob is a bulk action being applied to several PRs, one at a time
+type bulkJob struct {
+	id     int
+	action string
+	arg    string
+	prs    []*models.PullRequest
+}
+
 // AppModel represents the main application state
 type AppModel struct {
 	config *config.Config
@@ -42,6 +178,7 @@ type AppModel struct {
 
 	// View management
 	currentView ViewMode
+	viewStack   []ViewMode // views to return to with the back key
 	views       map[ViewMode]views.View
 
 	// Repository grouping
@@ -51,61 +188,182 @@ type AppModel struct {
 	selectedOwner string
 	selectedRepo  string
 
+	// Per-row data (CI status, unresolved threads) already requested for the
+	// PR list, keyed by PR number
+	rowsRequested map[int]bool
+
+	// Repositories load per owner: once the owners are known, each owner's
+	// repositories stream in a page at a time from its own pager, a few owners
+	// at a time.
+	ownerPagers map[string]*api.Pager[string]
+	ownerQueue  []string // owners waiting for a free loader
+	ownerLoads  int      // owners currently streaming
+
+	// The PR list pages through prPager on demand; prLoadingMore is set while
+	// a page is in flight. Pages from a pager that has since been replaced are
+	// dropped.
+	prPager       *api.Pager[*models.PullRequest]
+	prLoadingMore bool
+
+	// The PR list reloads its first page every ui.auto_refresh while shown;
+	// prRefreshing is set while a refresh is in flight, and its page is merged
+	// into the list rather than replacing it
+	prRefreshing bool
+	lastRefresh  time.Time // when the PR list's first page last arrived
+
+	// In-flight loads of each view, cancelled when the user leaves it
+	requests *requests
+
+	// Bulk action in progress, if any
+	bulk      *bulkJob
+	bulkCount int
+
+	// Writes made while offline wait in the outbox. Once GitHub is reachable
+	// again the user is asked, once, whether to send them.
+	outbox         *outbox
+	outboxPrompted bool      // asked since GitHub came back
+	confirmOutbox  bool      // asking now
+	sendingOutbox  bool      // sending now
+	lastProbe      time.Time // when the outbox last checked whether GitHub is back
+
+	// When each pull request was last read, to mark what's new since
+	seen *seenStore
+
 	// UI state
 	width   int
 	height  int
 	loading bool
 	error   string
+	notice  string
+
+	// GitHub API, shared by every command
+	client api.GitHub
 
 	// Debug information
 	debugMode bool
 	debugInfo string
 }
 
-// NewApp creates a new application model
-func NewApp(cfg *config.Config) *AppModel {
+// NewApp creates a new application model talking to GitHub through client
+func NewApp(cfg *config.Config, client api.GitHub) *AppModel {
 	// Initialize views
 	ownerList := views.NewOwnerList(constants.DefaultPageSize)
 	repoList := views.NewRepoList(constants.DefaultPageSize)
 	prList := views.NewPRList(constants.DefaultPageSize)
+	prDetail := views.NewPRDetail(constants.DefaultPageSize)
+	logViewer := views.NewLogViewer()
+	threads := views.NewThreads(&theme.DefaultTheme)
+	files := views.NewFiles(constants.DefaultPageSize, &theme.DefaultTheme)
+	diff := views.NewDiff(&theme.DefaultTheme)
+	compose := views.NewCompose(&theme.DefaultTheme, cfg.External.Editor)
+	merge := views.NewMerge(&theme.DefaultTheme)
+	picker := views.NewPicker(constants.DefaultPageSize, &theme.DefaultTheme)
+	bulk := views.NewBulk(constants.DefaultPageSize, &theme.DefaultTheme)
+
+	outbox, outboxErr := loadOutbox(stateDir(cfg), cfg.GitHub.Host)
+	seen, seenErr := loadSeen(stateDir(cfg), cfg.GitHub.Host)
+	errText := ""
+	if outboxErr != nil {
+		errText = fmt.Sprintf("Queued changes couldn't be loaded: %v", outboxErr)
+	} else if seenErr != nil {
+		errText = fmt.Sprintf("Read pull requests couldn't be loaded: %v", seenErr)
+	}
+	prList.SetSeen(seen.get)
 
 	viewsMap := map[ViewMode]views.View{
 		OwnerSelection: ownerList,
 		RepoSelection:  repoList,
 		PRList:         prList,
+		PRDetail:       prDetail,
+		LogView:        logViewer,
+		ThreadsView:    threads,
+		FilesView:      files,
+		DiffView:       diff,
+		ComposeView:    compose,
+		MergeView:      merge,
+		PickerView:     picker,
+		BulkView:       bulk,
 	}
 
 	return &AppModel{
 		config:        cfg,
+		client:        client,
 		theme:         &theme.DefaultTheme,
 		currentView:   OwnerSelection,
 		views:         viewsMap,
 		repoGroups:    make(map[string][]string),
+		rowsRequested: make(map[int]bool),
+		requests:      newRequests(),
+		outbox:        outbox,
+		seen:          seen,
 		selectedOwner: "",
 		selectedRepo:  "",
 		width:         0,
 		height:        0,
 		loading:       true,
-		error:         "",
+		error:         errText,
 		debugMode:     false,
 		debugInfo:     "Initializing...",
 	}
 }
 
+// StartInRepository opens the app directly on a repository's PR list, with the
+// owner and repository levels already on the view stack so back navigation works
+func (m *AppModel) StartInRepository(fullName string) {
+	parts := strings.Split(fullName, "/")
+	if len(parts) != 2 {
+		return
+	}
+
+	m.selectedOwner = parts[0]
+	m.selectedRepo = fullName
+	m.currentView = PRList
+	m.viewStack = []ViewMode{OwnerSelection, RepoSelection}
+	m.prPager = m.newPRPager()
+
+	// Seed the repo list so it isn't empty before the repository fetch completes
+	if repoList, ok := m.views[RepoSelection].(*views.RepoListModel); ok {
+		repoList.SetData(m.selectedOwner, []string{fullName})
+		m.views[RepoSelection] = repoList
+	}
+}
+
+// StartInOwner opens the app on an owner's repository list
+func (m *AppModel) StartInOwner(owner string) {
+	if owner == "" {
+		return
+	}
+	m.selectedOwner = owner
+	m.currentView = RepoSelection
+	m.viewStack = []ViewMode{OwnerSelection}
+}
+
 // Init initializes the application
 func (m AppModel) Init() tea.Cmd {
-	return tea.Batch(
-		loadRepositories(m.config),
-		tea.Tick(time.Second, func(t time.Time) tea.Msg {
-			return tickMsg(t)
-		}),
-	)
+	cmds := []tea.Cmd{
+		loadOwners(m.requests.begin(OwnerSelection), m.client),
+		m.tick(),
+	}
+	if m.currentView == PRList && m.prPager != nil {
+		cmds = append(cmds, loadPullRequests(m.requests.begin(PRList), m.prPager, true))
+	}
+	return tea.Batch(cmds...)
 }
 
 // Update handles messages and updates the model
 func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.confirmOutbox {
+			return m.handleOutboxKey(msg)
+		}
+		// A view taking text input gets every key but ctrl+c
+		if capturer, ok := m.views[m.currentView].(views.InputCapturer); ok && capturer.CapturesInput() && msg.String() != constants.KeyQuitAlt {
+			updatedView, cmd := m.views[m.currentView].Update(msg)
+			m.views[m.currentView] = updatedView
+			return m, cmd
+		}
+
 		switch msg.String() {
 		case constants.KeyQuitAlt, constants.KeyQuit:
 			return m, tea.Quit
@@ -114,12 +372,55 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if view, exists := m.views[m.currentView]; exists {
 				updatedView, cmd := view.Update(msg)
 				m.views[m.currentView] = updatedView
+				if m.currentView == PRList {
+					// Paging may reveal PRs whose CI status isn't known yet, or
+					// reach the last loaded page
+					return m, tea.Batch(cmd, m.loadVisibleRowData(), m.loadMorePRs())
+				}
 				return m, cmd
 			}
 		case constants.KeyEnter:
 			return m.handleEnterKey()
 		case constants.KeyBack, constants.KeyBackAlt:
 			return m.handleBackKey()
+		case constants.KeyCheckout:
+			return m.handleCheckoutKey()
+		case constants.KeyBrowser:
+			return m.handleBrowserKey()
+		case constants.KeyRerun:
+			return m.handleRerunKey()
+		case constants.KeyThreads:
+			return m.handleThreadsKey()
+		case constants.KeyFiles:
+			return m.handleFilesKey()
+		case constants.KeyComment:
+			return m.handleCommentKey()
+		case constants.KeyReview:
+			return m.handleReviewKey()
+		case constants.KeyMerge:
+			return m.handleMergeKey()
+		case constants.KeyLabels:
+			return m.handlePickerKey(models.EditLabels)
+		case constants.KeyAssign:
+			return m.handlePickerKey(models.EditAssignees)
+		case constants.KeyReviewer:
+			return m.handlePickerKey(models.EditReviewers)
+		case constants.KeyBulk:
+			return m.handleBulkKey()
+		case constants.KeyDraft:
+			return m.handleDraftKey()
+		case constants.KeyClose:
+			return m.handleCloseKey()
+		case constants.KeyState:
+			// The diff view uses s for its side-by-side toggle
+			if m.currentView != PRList {
+				return m.updateCurrentView(msg)
+			}
+			return m.handleStateKey()
+		case constants.KeyRead:
+			return m.handleReadKey()
+		case constants.KeyReadAll:
+			return m.handleReadAllKey()
 		case constants.KeyDebug:
 			// Toggle debug mode
 			m.debugMode = !m.debugMode
@@ -127,10 +428,28 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Reload repositories
 			m.loading = true
 			m.error = ""
+			m.notice = ""
 			m.currentView = OwnerSelection
+			m.viewStack = nil
 			m.selectedOwner = ""
 			m.selectedRepo = ""
-			return m, loadRepositories(m.config)
+			m.rowsRequested = make(map[int]bool)
+			m.groupRepositories(nil)
+			m.requests.cancelAll()
+			m.ownerPagers = nil
+			m.ownerQueue = nil
+			m.ownerLoads = 0
+			m.prPager = nil
+			m.prLoadingMore = false
+			if ownerList, ok := m.views[OwnerSelection].(*views.OwnerListModel); ok {
+				ownerList.StartLoading(nil)
+				ownerList.SetData(m.repoGroups)
+				m.views[OwnerSelection] = ownerList
+			}
+			return m, loadOwners(m.requests.begin(OwnerSelection), m.client)
+		default:
+			// Remaining keys are view-specific (e.g. search in the log viewer)
+			return m.updateCurrentView(msg)
 		}
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -141,39 +460,236 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			view.SetSize(msg.Width, msg.Height)
 		}
 	case tickMsg:
-		// Update debug info every second
+		// Update debug info, and start background work that is due
 		m.debugInfo = fmt.Sprintf("Last update: %s | Token: %s",
 			time.Now().Format("15:04:05"),
-			maskToken(m.config.GitHub.Token))
-		return m, tea.Tick(time.Second, func(t time.Time) tea.Msg {
-			return tickMsg(t)
-		})
+			config.MaskToken(m.config.GitHub.Token))
+		return m, tea.Batch(m.watchOutbox(time.Time(msg)), m.autoRefresh(time.Time(msg)), m.tick())
+	case ownersLoadedMsg:
+		return m.handleOwnersLoaded(msg)
 	case reposLoadedMsg:
-		m.loading = false
+		return m.handleReposLoaded(msg)
+	case spinner.TickMsg:
+		// The owner list keeps spinning while owners load, whichever view is
+		// shown; each spinner ignores the other spinners' ticks
+		var cmds []tea.Cmd
+		if ownerList, ok := m.views[OwnerSelection].(*views.OwnerListModel); ok {
+			_, cmd := ownerList.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+		if m.currentView != OwnerSelection {
+			updatedView, cmd := m.views[m.currentView].Update(msg)
+			m.views[m.currentView] = updatedView
+			cmds = append(cmds, cmd)
+		}
+		return m, tea.Batch(cmds...)
+	case prsLoadedMsg:
+		return m.handlePRsLoaded(msg)
+	case mergedByLoadedMsg:
+		// Merged-by is a nicety; the detail pane shows the merge date without it
+		if m.requests.isCurrent(PRDetail, msg.req) && msg.err == nil {
+			msg.pr.MergedBy = msg.mergedBy
+		}
+	case ciStatusesLoadedMsg:
+		if !m.requests.isCurrent(PRList, msg.req) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.error = fmt.Sprintf("%s: %v", constants.ErrLoadingChecks, msg.err)
+		}
+		if prList, ok := m.views[PRList].(*views.PRListModel); ok {
+			prList.SetChecks(msg.checks)
+			m.views[PRList] = prList
+		}
+	case activityLoadedMsg:
+		if !m.requests.isCurrent(PRList, msg.req) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.error = fmt.Sprintf("%s: %v", constants.ErrLoadingThreads, msg.err)
+		}
+		if prList, ok := m.views[PRList].(*views.PRListModel); ok {
+			prList.SetActivity(msg.activity)
+			m.views[PRList] = prList
+		}
+	case threadsLoadedMsg:
+		threadsView, ok := m.views[ThreadsView].(*views.ThreadsModel)
+		if !ok || !m.requests.isCurrent(ThreadsView, msg.req) {
+			return m, nil
+		}
+		pr := threadsView.GetPR()
+		if msg.err != nil {
+			threadsView.SetFailed()
+			m.error = fmt.Sprintf("%s: %v", constants.ErrLoadingThreads, msg.err)
+		} else {
+			threadsView.SetThreads(msg.threads)
+			pr.UnresolvedThreads = models.CountUnresolved(msg.threads)
+		}
+		m.views[ThreadsView] = threadsView
+	case filesLoadedMsg:
+		filesView, ok := m.views[FilesView].(*views.FilesModel)
+		if !ok || !m.requests.isCurrent(FilesView, msg.req) {
+			return m, nil
+		}
+		if msg.err != nil {
+			filesView.SetFailed()
+			m.error = fmt.Sprintf("%s: %v", constants.ErrLoadingFiles, msg.err)
+		} else {
+			filesView.SetFiles(msg.files, msg.threads)
+			if msg.threadsErr != nil {
+				m.error = fmt.Sprintf("%s: %v", constants.ErrLoadingThreads, msg.threadsErr)
+			}
+		}
+		m.views[FilesView] = filesView
+	case jobLogLoadedMsg:
+		logViewer, ok := m.views[LogView].(*views.LogViewerModel)
+		if !ok || !m.requests.isCurrent(LogView, msg.req) {
+			return m, nil
+		}
+		if msg.err != nil {
+			logViewer.SetFailed()
+			m.error = fmt.Sprintf("%s: %v", constants.ErrLoadingLog, msg.err)
+		} else {
+			logViewer.SetLog(msg.log)
+		}
+		m.views[LogView] = logViewer
+	case checksLoadedMsg:
+		prDetail, ok := m.views[PRDetail].(*views.PRDetailModel)
+		if !ok || !m.requests.isCurrent(PRDetail, msg.req) {
+			return m, nil
+		}
+		if msg.err != nil {
+			prDetail.SetChecksFailed()
+			m.error = fmt.Sprintf("%s: %v", constants.ErrLoadingChecks, msg.err)
+		} else {
+			prDetail.SetChecks(msg.checks)
+		}
+		m.views[PRDetail] = prDetail
+	case views.ComposeSubmitMsg:
+		// Closing can't wait; it fails like any other write while offline
+		if m.client.Offline() && msg.Draft.Kind != models.DraftClose {
+			if m.currentView == ComposeView {
+				m.handleBackKey()
+			}
+			return m.queueChange(models.QueueDraft(msg.Draft, time.Now()))
+		}
+		m.error = ""
+		m.notice = constants.MsgPosting
+		if msg.Draft.Kind == models.DraftClose {
+			m.notice = constants.MsgClosing
+		}
+		return m, postDraft(m.client, msg.Draft)
+	case views.ComposeCancelMsg:
+		if m.currentView == ComposeView {
+			return m.handleBackKey()
+		}
+	case draftPostedMsg:
+		return m.handleDraftPosted(msg)
+	case lifecycleDoneMsg:
+		m.notice = ""
 		if msg.err != nil {
+			// Roll back the optimistic update
+			msg.pr.IsDraft = msg.prevDraft
+			msg.pr.State = msg.prevState
 			m.error = msg.err.Error()
 		} else {
-			m.groupRepositories(msg.repos) // Group repositories by owner
 			m.error = ""
-
-			// Update owner list with data
-			if ownerList, ok := m.views[OwnerSelection].(*views.OwnerListModel); ok {
-				ownerList.SetData(m.repoGroups)
-				m.views[OwnerSelection] = ownerList
+			m.notice = msg.notice
+		}
+	case mergeInfoLoadedMsg:
+		mergeView, ok := m.views[MergeView].(*views.MergeModel)
+		if !ok || !m.requests.isCurrent(MergeView, msg.req) {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		if msg.err != nil {
+			mergeView.SetFailed(fmt.Errorf("%s: %v", constants.ErrLoadingMerge, msg.err))
+		} else {
+			cmd = mergeView.SetInfo(msg.info)
+		}
+		m.views[MergeView] = mergeView
+		return m, cmd
+	case views.MergeSubmitMsg:
+		m.error = ""
+		m.notice = constants.MsgMerging
+		return m, mergePullRequest(m.client, msg.PR, msg.Info, msg.Request)
+	case views.MergeCancelMsg:
+		if m.currentView == MergeView {
+			return m.handleBackKey()
+		}
+	case mergeDoneMsg:
+		return m.handleMergeDone(msg)
+	case pickerOptionsLoadedMsg:
+		picker, ok := m.views[PickerView].(*views.PickerModel)
+		if !ok || !m.requests.isCurrent(PickerView, msg.req) {
+			return m, nil
+		}
+		if msg.err != nil {
+			picker.SetFailed()
+			m.error = fmt.Sprintf("%s: %v", constants.ErrLoadingOptions, msg.err)
+		} else {
+			picker.SetOptions(msg.options)
+		}
+		m.views[PickerView] = picker
+	case views.PickerApplyMsg:
+		return m.handlePickerApply(msg)
+	case views.PickerCancelMsg:
+		if m.currentView == PickerView {
+			return m.handleBackKey()
+		}
+	case views.BulkRunMsg:
+		m.bulkCount++
+		m.bulk = &bulkJob{id: m.bulkCount, action: msg.Action, arg: msg.Arg, prs: msg.PRs}
+		return m, runBulkStep(m.client, m.bulk, 0)
+	case views.BulkCancelMsg:
+		if m.currentView == BulkView {
+			return m.handleBackKey()
+		}
+	case bulkStepDoneMsg:
+		return m.handleBulkStepDone(msg)
+	case outboxSentMsg:
+		return m.handleOutboxSent(msg)
+	case fieldUpdatedMsg:
+		m.notice = ""
+		if msg.err != nil {
+			// Replace the optimistic update with what GitHub has, unless the
+			// field was edited again since; that edit's result settles it
+			if slices.Equal(msg.pr.FieldValues(msg.field), msg.after) {
+				if msg.current != nil {
+					msg.pr.SetFieldValues(msg.field, msg.current)
+				} else {
+					msg.pr.SetFieldValues(msg.field, msg.before)
+				}
 			}
+			m.error = msg.err.Error()
+		} else {
+			m.error = ""
+			m.notice = fmt.Sprintf("Updated %s of #%d", msg.field, msg.pr.Number)
 		}
-	case prsLoadedMsg:
-		m.loading = false
+	case statusMsg:
+		m.notice = ""
 		if msg.err != nil {
 			m.error = msg.err.Error()
 		} else {
 			m.error = ""
-
-			// Update PR list with data
-			if prList, ok := m.views[PRList].(*views.PRListModel); ok {
-				prList.SetData(m.selectedRepo, msg.prs)
-				m.views[PRList] = prList
-			}
+			m.notice = msg.notice
+		}
+	case checkoutDoneMsg:
+		m.notice = ""
+		if msg.err != nil {
+			m.error = fmt.Sprintf("Checkout of #%d failed: %v", msg.pr.Number, msg.err)
+		} else if msg.result.Path != "" {
+			m.notice = fmt.Sprintf("Checked out #%d as %s in worktree %s", msg.pr.Number, msg.result.Branch, msg.result.Path)
+		} else {
+			m.notice = fmt.Sprintf("Checked out #%d as %s", msg.pr.Number, msg.result.Branch)
+		}
+	default:
+		// Anything else belongs to the current view (e.g. the composer's cursor
+		// blink and the result of an external editor)
+		if view, exists := m.views[m.currentView]; exists {
+			updatedView, cmd := view.Update(msg)
+			m.views[m.currentView] = updatedView
+			return m, cmd
 		}
 	}
 
@@ -188,7 +704,7 @@ func (m *AppModel) handleEnterKey() (tea.Model, tea.Cmd) {
 			selectedOwner := ownerList.GetSelectedOwner()
 			if selectedOwner != "" {
 				m.selectedOwner = selectedOwner
-				m.currentView = RepoSelection
+				m.pushView(RepoSelection)
 
 				// Update repo list with data
 				if repoList, ok := m.views[RepoSelection].(*views.RepoListModel); ok {
@@ -203,192 +719,1641 @@ func (m *AppModel) handleEnterKey() (tea.Model, tea.Cmd) {
 			selectedRepo := repoList.GetSelectedRepo()
 			if selectedRepo != "" {
 				m.selectedRepo = selectedRepo
-				m.currentView = PRList
+				m.pushView(PRList)
 				m.loading = true
 				m.error = ""
-				return m, loadPullRequests(m.config, m.selectedOwner, m.selectedRepo)
+				m.notice = ""
+				return m, m.reloadPullRequests()
 			}
 		}
-	}
-	return m, nil
-}
-
-// handleBackKey handles the back key press for navigation
-func (m *AppModel) handleBackKey() (tea.Model, tea.Cmd) {
-	switch m.currentView {
-	case RepoSelection:
-		m.currentView = OwnerSelection
-		m.selectedOwner = ""
-		m.selectedRepo = ""
 	case PRList:
-		m.currentView = RepoSelection
-		m.selectedRepo = ""
-		// Clear PR list data
-		if prList, ok := m.views[PRList].(*views.PRListModel); ok {
-			prList.SetData("", []*models.PullRequest{})
-			m.views[PRList] = prList
+		pr := m.selectedPR()
+		if pr == nil {
+			return m, nil
+		}
+		if prDetail, ok := m.views[PRDetail].(*views.PRDetailModel); ok {
+			prDetail.SetPR(pr)
+			m.views[PRDetail] = prDetail
 		}
+		m.pushView(PRDetail)
+		m.markViewed(pr)
+		m.error = ""
+		m.notice = ""
+		if pr.State == models.PRStateMerged && pr.MergedBy == "" {
+			// The list endpoint doesn't say who merged a PR
+			req := m.requests.begin(PRDetail)
+			return m, tea.Batch(loadChecks(req, m.client, pr), loadMergedBy(req, m.client, pr))
+		}
+		return m, loadChecks(m.requests.begin(PRDetail), m.client, pr)
+	case PRDetail:
+		pr, check := m.selectedCheck()
+		if check == nil {
+			return m, nil
+		}
+		if !check.IsActionsJob() {
+			// Other CI systems don't expose logs through the API
+			if check.URL == "" {
+				m.error = constants.ErrNoJobLog
+				return m, nil
+			}
+			return m, openInBrowser(m.config.External.Browser, check.URL)
+		}
+		if logViewer, ok := m.views[LogView].(*views.LogViewerModel); ok {
+			logViewer.SetLoading(check.ID, check.Name, check.URL)
+			m.views[LogView] = logViewer
+		}
+		m.pushView(LogView)
+		m.error = ""
+		m.notice = ""
+		return m, loadJobLog(m.requests.begin(LogView), m.client, pr.RepoName, check.ID)
+	case ThreadsView:
+		if threadsView, ok := m.views[ThreadsView].(*views.ThreadsModel); ok {
+			threadsView.ToggleSelected()
+			m.views[ThreadsView] = threadsView
+		}
+	case FilesView:
+		filesView, ok := m.views[FilesView].(*views.FilesModel)
+		if !ok || filesView.GetSelectedIndex() < 0 {
+			return m, nil
+		}
+		if diff, ok := m.views[DiffView].(*views.DiffModel); ok {
+			diff.SetFiles(filesView.GetPR(), filesView.GetFiles(), filesView.GetThreads(), filesView.GetSelectedIndex())
+			m.views[DiffView] = diff
+		}
+		m.pushView(DiffView)
 	}
 	return m, nil
 }
 
-// View renders the UI
-func (m AppModel) View() string {
-	if m.width == 0 {
-		return "Loading..."
+// handleFilesKey opens the list of files changed by the selected pull request
+func (m *AppModel) handleFilesKey() (tea.Model, tea.Cmd) {
+	if m.currentView != PRList && m.currentView != PRDetail {
+		return m, nil
 	}
-
-	title := m.theme.Styles.Title.Render("GitHub PR Dashboard")
-
-	// Status line
-	status := ""
-	if m.loading {
-		status = m.theme.Styles.Warning.Render(fmt.Sprintf("%s Loading repositories...", m.theme.Icons.Loading))
-	} else if m.error != "" {
-		status = m.theme.Styles.Error.Render(fmt.Sprintf("%s %s", m.theme.Icons.Error, m.error))
-	} else {
-		switch m.currentView {
-		case OwnerSelection:
-			orgCount := len(m.repoGroups)
-			status = m.theme.Styles.Success.Render(fmt.Sprintf("%s %s (%d organizations)",
-				m.theme.Icons.Success, m.getPageInfo(), orgCount))
-		case RepoSelection:
-			status = m.theme.Styles.Success.Render(fmt.Sprintf("%s %s - %s",
-				m.theme.Icons.Success, m.getPageInfo(), m.selectedOwner))
-		case PRList:
-			status = m.theme.Styles.Success.Render(fmt.Sprintf("%s %s - %s",
-				m.theme.Icons.Success, m.getPageInfo(), m.selectedRepo))
-		}
+	pr := m.selectedPR()
+	if pr == nil {
+		return m, nil
 	}
 
-	// Get content from current view
-	list := ""
-	if view, exists := m.views[m.currentView]; exists {
-		list = view.View()
+	if filesView, ok := m.views[FilesView].(*views.FilesModel); ok {
+		filesView.SetLoading(pr)
+		m.views[FilesView] = filesView
 	}
+	m.pushView(FilesView)
+	m.markViewed(pr)
+	m.error = ""
+	m.notice = ""
+	return m, loadFiles(m.requests.begin(FilesView), m.client, pr)
+}
 
-	help := m.theme.Styles.Help.Render(constants.HelpNavigation)
-
-	// Debug information
-	debug := ""
-	if m.debugMode {
-		debug = m.theme.Styles.Debug.Render(fmt.Sprintf("Debug: %s", m.debugInfo))
+// handleThreadsKey opens the comment threads of the selected pull request
+func (m *AppModel) handleThreadsKey() (tea.Model, tea.Cmd) {
+	if m.currentView != PRList && m.currentView != PRDetail {
+		return m, nil
+	}
+	pr := m.selectedPR()
+	if pr == nil {
+		return m, nil
 	}
 
-	return fmt.Sprintf("%s\n%s\n%s\n%s\n%s", title, status, list, help, debug)
+	if threadsView, ok := m.views[ThreadsView].(*views.ThreadsModel); ok {
+		threadsView.SetLoading(pr)
+		m.views[ThreadsView] = threadsView
+	}
+	m.pushView(ThreadsView)
+	m.markViewed(pr)
+	m.error = ""
+	m.notice = ""
+	return m, loadThreads(m.requests.begin(ThreadsView), m.client, pr)
 }
 
-// loadPullRequests fetches pull requests from GitHub API
-func loadPullRequests(cfg *config.Config, owner, repo string) tea.Cmd {
-	return func() tea.Msg {
-		// Create GitHub API client
-		client := api.NewClient(cfg)
-
-		// Create context with timeout
-		ctx, cancel := context.WithTimeout(context.Background(), constants.DefaultTimeout)
-		defer cancel()
-
-		// Extract repo name from full name
-		parts := strings.Split(repo, "/")
-		repoName := repo
-		if len(parts) == 2 {
-			repoName = parts[1]
-		}
-
-		// Fetch pull requests
-		githubPRs, err := client.GetPullRequests(ctx, owner, repoName)
-		if err != nil {
-			return prsLoadedMsg{
-				prs: nil,
-				err: err,
-			}
-		}
-
-		// Convert to our model
-		var prs []*models.PullRequest
-		for _, githubPR := range githubPRs {
-			pr := models.FromGitHubPR(githubPR, repo)
-			prs = append(prs, pr)
+// handleCommentKey opens the composer for a top-level comment, or for a reply when
+// a review thread is selected in the threads view
+func (m *AppModel) handleCommentKey() (tea.Model, tea.Cmd) {
+	draft := &models.Draft{Kind: models.DraftComment}
+	switch m.currentView {
+	case PRList, PRDetail:
+		draft.PR = m.selectedPR()
+	case ThreadsView:
+		threadsView, ok := m.views[ThreadsView].(*views.ThreadsModel)
+		if !ok {
+			return m, nil
 		}
-
-		return prsLoadedMsg{
-			prs: prs,
-			err: nil,
+		draft.PR = threadsView.GetPR()
+		if thread := threadsView.GetSelectedThread(); thread != nil && !thread.IsConversation() && len(thread.Comments) > 0 {
+			draft.Kind = models.DraftReply
+			draft.Thread = thread
+			draft.ReplyTo = thread.Comments[0].ID
 		}
 	}
+	return m.startCompose(draft)
 }
 
-// maskToken masks most of the token for security
-func maskToken(token string) string {
-	if len(token) <= 8 {
-		return "***"
+// handleReviewKey opens the composer for a review of the selected pull request
+func (m *AppModel) handleReviewKey() (tea.Model, tea.Cmd) {
+	draft := &models.Draft{Kind: models.DraftReview, Event: models.ReviewEventComment}
+	switch m.currentView {
+	case PRList, PRDetail, ThreadsView, FilesView, DiffView:
+		draft.PR = m.selectedPR()
 	}
-	return token[:4] + "..." + token[len(token)-4:]
+	return m.startCompose(draft)
 }
 
-// getPageInfo returns pagination information from the current view
-func (m AppModel) getPageInfo() string {
-	if view, exists := m.views[m.currentView]; exists {
-		switch m.currentView {
-		case OwnerSelection:
-			if ownerList, ok := view.(*views.OwnerListModel); ok {
-				return ownerList.GetPageInfo()
-			}
-		case RepoSelection:
-			if repoList, ok := view.(*views.RepoListModel); ok {
-				return repoList.GetPageInfo()
-			}
-		case PRList:
-			if prList, ok := view.(*views.PRListModel); ok {
-				return prList.GetPageInfo()
-			}
-		}
+// startCompose shows the composer for a draft on a pull request
+func (m *AppModel) startCompose(draft *models.Draft) (tea.Model, tea.Cmd) {
+	if draft.PR == nil {
+		return m, nil
 	}
-	return "Loading..."
+	compose, ok := m.views[ComposeView].(*views.ComposeModel)
+	if !ok {
+		return m, nil
+	}
+	cmd := compose.Start(draft)
+	m.views[ComposeView] = compose
+	m.pushView(ComposeView)
+	m.error = ""
+	m.notice = ""
+	return m, cmd
 }
 
-// groupRepositories groups repositories by owner (user/org)
-func (m *AppModel) groupRepositories(repos []string) {
-	m.repoGroups = make(map[string][]string)
-	for _, repo := range repos {
-		parts := strings.Split(repo, "/")
-		if len(parts) == 2 {
-			owner := parts[0]
-			m.repoGroups[owner] = append(m.repoGroups[owner], repo)
+// handleDraftPosted closes the composer after a successful post, refreshing the
+// threads it was opened from, or keeps the text for another try on failure
+func (m *AppModel) handleDraftPosted(msg draftPostedMsg) (tea.Model, tea.Cmd) {
+	compose, ok := m.views[ComposeView].(*views.ComposeModel)
+	if !ok || m.currentView != ComposeView || compose.GetDraft() == nil || compose.GetPR() != msg.draft.PR {
+		return m, nil
+	}
+	m.notice = ""
+	if msg.closed {
+		msg.draft.PR.State = models.PRStateClosed
+	}
+	if msg.err != nil && !msg.closed {
+		compose.SendFailed(msg.err)
+		m.views[ComposeView] = compose
+		m.error = msg.err.Error()
+		return m, nil
+	}
+
+	m.handleBackKey()
+	switch msg.draft.Kind {
+	case models.DraftReply:
+		m.notice = constants.MsgReplyPosted
+	case models.DraftReview:
+		m.notice = constants.MsgReviewPosted
+	case models.DraftClose:
+		m.notice = fmt.Sprintf("Closed #%d", msg.draft.PR.Number)
+		if msg.err != nil {
+			m.notice = ""
+			m.error = msg.err.Error()
 		}
+	default:
+		m.notice = constants.MsgCommentPosted
 	}
+	if m.currentView == ThreadsView {
+		if threadsView, ok := m.views[ThreadsView].(*views.ThreadsModel); ok {
+			threadsView.SetLoading(msg.draft.PR)
+			m.views[ThreadsView] = threadsView
+		}
+		return m, loadThreads(m.requests.begin(ThreadsView), m.client, msg.draft.PR)
+	}
+	return m, nil
 }
 
-// getReposForOwner returns repositories for a specific owner
-func (m AppModel) getReposForOwner(owner string) []string {
-	if repos, exists := m.repoGroups[owner]; exists {
-		return repos
+// handleDraftKey converts the selected PR to a draft or marks it ready for
+// review, updating it on screen before GitHub confirms
+func (m *AppModel) handleDraftKey() (tea.Model, tea.Cmd) {
+	if m.currentView != PRList && m.currentView != PRDetail {
+		return m, nil
 	}
-	return []string{}
+	pr := m.selectedPR()
+	if pr == nil {
+		return m, nil
+	}
+	if pr.State != models.PRStateOpen {
+		m.error = fmt.Sprintf("#%d is %s; only open pull requests can change draft status", pr.Number, pr.State)
+		return m, nil
+	}
+
+	prevDraft := pr.IsDraft
+	pr.IsDraft = !prevDraft
+	m.error = ""
+	notice := fmt.Sprintf("Marked #%d ready for review", pr.Number)
+	if pr.IsDraft {
+		notice = fmt.Sprintf("Converted #%d to draft", pr.Number)
+	}
+	return m, setDraft(m.client, pr, prevDraft, notice)
 }
 
-// loadRepositories fetches repositories from GitHub API
-func loadRepositories(cfg *config.Config) tea.Cmd {
-	return func() tea.Msg {
-		// Create GitHub API client
-		client := api.NewClient(cfg)
+// handleCloseKey closes an open PR (after offering a closing comment) or
+// reopens a closed one
+func (m *AppModel) handleCloseKey() (tea.Model, tea.Cmd) {
+	if m.currentView != PRList && m.currentView != PRDetail {
+		return m, nil
+	}
+	pr := m.selectedPR()
+	if pr == nil {
+		return m, nil
+	}
 
-		// Create context with timeout
+	switch pr.State {
+	case models.PRStateOpen:
+		return m.startCompose(&models.Draft{Kind: models.DraftClose, PR: pr})
+	case models.PRStateClosed:
+		pr.State = models.PRStateOpen
+		m.error = ""
+		return m, reopenPullRequest(m.client, pr)
+	}
+	m.error = fmt.Sprintf("#%d is merged and can't be reopened", pr.Number)
+	return m, nil
+}
+
+// handleMergeKey opens the merge dialog for the selected pull request
+func (m *AppModel) handleMergeKey() (tea.Model, tea.Cmd) {
+	if m.currentView != PRList && m.currentView != PRDetail {
+		return m, nil
+	}
+	pr := m.selectedPR()
+	if pr == nil {
+		return m, nil
+	}
+
+	if mergeView, ok := m.views[MergeView].(*views.MergeModel); ok {
+		mergeView.SetLoading(pr)
+		m.views[MergeView] = mergeView
+	}
+	m.pushView(MergeView)
+	m.error = ""
+	m.notice = ""
+	return m, loadMergeInfo(m.requests.begin(MergeView), m.client, pr)
+}
+
+// handleBulkKey opens the bulk action menu for the PRs selected in the list
+func (m *AppModel) handleBulkKey() (tea.Model, tea.Cmd) {
+	if m.currentView != PRList {
+		return m, nil
+	}
+	prList, ok := m.views[PRList].(*views.PRListModel)
+	if !ok {
+		return m, nil
+	}
+	prs := prList.GetSelectedPRs()
+	if len(prs) == 0 {
+		m.error = constants.ErrNothingSelected
+		return m, nil
+	}
+
+	if bulk, ok := m.views[BulkView].(*views.BulkModel); ok {
+		bulk.Start(prs)
+		m.views[BulkView] = bulk
+	}
+	m.pushView(BulkView)
+	m.error = ""
+	m.notice = ""
+	return m, nil
+}
+
+// handleBulkStepDone records one PR's result and starts the next PR, until all
+// are done or the user stopped the job
+func (m *AppModel) handleBulkStepDone(msg bulkStepDoneMsg) (tea.Model, tea.Cmd) {
+	job := m.bulk
+	if job == nil || job.id != msg.job {
+		return m, nil
+	}
+	bulk, ok := m.views[BulkView].(*views.BulkModel)
+	if !ok {
+		return m, nil
+	}
+
+	if msg.err == nil && !msg.autoMerge {
+		job.prs[msg.index].ApplyBulkAction(job.action, job.arg)
+	}
+	bulk.SetResult(msg.index, msg.err)
+
+	next := msg.index + 1
+	if next < len(job.prs) && !bulk.Stopped() {
+		m.views[BulkView] = bulk
+		return m, runBulkStep(m.client, job, next)
+	}
+
+	bulk.Finish()
+	m.views[BulkView] = bulk
+	m.bulk = nil
+	m.notice = fmt.Sprintf("%s finished", models.BulkActionLabel(job.action))
+	if job.action == models.BulkMerge || job.action == models.BulkClose {
+		// Merged and closed PRs drop out of the open PR list
+		return m, m.reloadPullRequests()
+	}
+	return m, nil
+}
+
+// handlePickerKey opens the multi-select picker for a field of the selected PR
+func (m *AppModel) handlePickerKey(field string) (tea.Model, tea.Cmd) {
+	if m.currentView != PRList && m.currentView != PRDetail {
+		return m, nil
+	}
+	pr := m.selectedPR()
+	if pr == nil {
+		return m, nil
+	}
+
+	if picker, ok := m.views[PickerView].(*views.PickerModel); ok {
+		picker.SetLoading(pr, field)
+		m.views[PickerView] = picker
+	}
+	m.pushView(PickerView)
+	m.error = ""
+	m.notice = ""
+	return m, loadPickerOptions(m.requests.begin(PickerView), m.client, pr.RepoName, field)
+}
+
+// handlePickerApply updates the PR right away and sends the change to GitHub;
+// fieldUpdatedMsg corrects it if GitHub refuses
+func (m *AppModel) handlePickerApply(msg views.PickerApplyMsg) (tea.Model, tea.Cmd) {
+	if m.currentView == PickerView {
+		m.handleBackKey()
+	}
+
+	add, remove := models.DiffValues(msg.Before, msg.After)
+	if len(add) == 0 && len(remove) == 0 {
+		return m, nil
+	}
+
+	msg.PR.SetFieldValues(msg.Field, msg.After)
+	if m.client.Offline() {
+		return m.queueChange(models.QueueFieldEdit(msg.PR, msg.Field, add, remove, time.Now()))
+	}
+	m.error = ""
+	m.notice = fmt.Sprintf("Updating %s of #%d...", msg.Field, msg.PR.Number)
+	return m, updateField(m.client, msg.PR, msg.Field, msg.Before, msg.After)
+}
+
+// queueChange keeps a write made while offline in the outbox, to be sent
+// once GitHub is reachable again and the user agrees
+func (m *AppModel) queueChange(change *models.QueuedChange) (tea.Model, tea.Cmd) {
+	m.notice = ""
+	if err := m.outbox.add(change); err != nil {
+		m.error = fmt.Sprintf("Queued %s, but couldn't save it: %v", change.Describe(), err)
+		return m, nil
+	}
+	m.error = ""
+	m.notice = fmt.Sprintf(constants.MsgQueued, change.Describe(), m.outbox.len())
+	return m, nil
+}
+
+// watchOutbox checks now and then whether GitHub is back while changes are
+// queued, and asks to send them once it is. The check is a request the
+// client's connectivity tracking lets through while offline; without queued
+// changes, the next load finds out instead.
+func (m *AppModel) watchOutbox(now time.Time) tea.Cmd {
+	if m.outbox.len() == 0 || m.sendingOutbox || m.config.Cache.Offline {
+		return nil
+	}
+	if m.client.Offline() {
+		m.outboxPrompted = false
+		if now.Sub(m.lastProbe) < api.ProbeInterval {
+			return nil
+		}
+		m.lastProbe = now
+		return probeGitHub(m.client)
+	}
+	// Don't take over keys that are being typed into a view
+	if capturer, ok := m.views[m.currentView].(views.InputCapturer); ok && capturer.CapturesInput() {
+		return nil
+	}
+	if !m.outboxPrompted {
+		m.outboxPrompted = true
+		m.confirmOutbox = true
+	}
+	return nil
+}
+
+// handleOutboxKey answers the prompt to send queued changes
+func (m *AppModel) handleOutboxKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case constants.KeyQuitAlt, constants.KeyQuit:
+		return m, tea.Quit
+	case "y", "Y", "enter":
+		m.confirmOutbox = false
+		m.sendingOutbox = true
+		m.error = ""
+		m.notice = fmt.Sprintf(constants.MsgSendingQueued, m.outbox.len())
+		return m, sendOutbox(m.client, m.outbox.pending())
+	case "n", "N", "esc":
+		m.confirmOutbox = false
+		m.notice = fmt.Sprintf(constants.MsgKeptQueued, m.outbox.len())
+	}
+	return m, nil
+}
+
+// handleOutboxSent drops the queued changes GitHub accepted
+func (m *AppModel) handleOutboxSent(msg outboxSentMsg) (tea.Model, tea.Cmd) {
+	m.sendingOutbox = false
+	m.notice = ""
+	m.error = ""
+	if err := m.outbox.remove(msg.sent); err != nil {
+		m.error = fmt.Sprintf("Sent %d queued changes, but couldn't update the outbox: %v", len(msg.sent), err)
+		return m, nil
+	}
+	if msg.err != nil {
+		m.error = fmt.Sprintf("Sent %d of %d queued changes; %v", len(msg.sent), msg.total, msg.err)
+		return m, nil
+	}
+	m.notice = fmt.Sprintf(constants.MsgSentQueued, len(msg.sent))
+	return m, nil
+}
+
+// handleMergeDone closes the merge dialog after a successful merge and refreshes
+// the PR list, or keeps the dialog open with GitHub's reason on failure
+func (m *AppModel) handleMergeDone(msg mergeDoneMsg) (tea.Model, tea.Cmd) {
+	mergeView, ok := m.views[MergeView].(*views.MergeModel)
+	if !ok || m.currentView != MergeView || mergeView.GetPR() != msg.pr {
+		return m, nil
+	}
+	m.notice = ""
+	if msg.err != nil {
+		mergeView.SetFailed(msg.err)
+		m.views[MergeView] = mergeView
+		m.error = msg.err.Error()
+		return m, nil
+	}
+
+	m.handleBackKey()
+	if msg.request.Auto {
+		m.notice = fmt.Sprintf("Auto-merge enabled for #%d", msg.pr.Number)
+		return m, nil
+	}
+
+	msg.pr.State = models.PRStateMerged
+	msg.pr.MergedAt = time.Now()
+	m.notice = fmt.Sprintf("Merged #%d", msg.pr.Number)
+	if msg.request.DeleteBranch && msg.branchErr == nil {
+		m.notice += fmt.Sprintf(" and deleted %s", msg.pr.HeadRef)
+	}
+	if msg.branchErr != nil {
+		m.error = msg.branchErr.Error()
+	}
+	return m, m.reloadPullRequests()
+}
+
+// selectedCheck returns the PR shown in the detail view and the check under the
+// cursor, setting an error when there is no check to select
+func (m *AppModel) selectedCheck() (*models.PullRequest, *models.Check) {
+	prDetail, ok := m.views[PRDetail].(*views.PRDetailModel)
+	if !ok || prDetail.GetPR() == nil {
+		return nil, nil
+	}
+
+	check := prDetail.GetSelectedCheck()
+	if check == nil {
+		m.error = constants.ErrNoCheckSelected
+	}
+	return prDetail.GetPR(), check
+}
+
+// handleRerunKey re-runs the failed jobs of the workflow run behind the selected
+// check (detail view) or the job whose log is open
+func (m *AppModel) handleRerunKey() (tea.Model, tea.Cmd) {
+	var pr *models.PullRequest
+	var jobID int64
+
+	switch m.currentView {
+	case PRDetail:
+		var check *models.Check
+		pr, check = m.selectedCheck()
+		if check == nil {
+			return m, nil
+		}
+		if !check.IsActionsJob() {
+			m.error = constants.ErrNoJobLog
+			return m, nil
+		}
+		jobID = check.ID
+	case LogView:
+		pr = m.selectedPR()
+		if logViewer, ok := m.views[LogView].(*views.LogViewerModel); ok {
+			jobID = logViewer.GetJobID()
+		}
+	}
+	if pr == nil || jobID == 0 {
+		return m, nil
+	}
+
+	m.error = ""
+	m.notice = constants.MsgRerunning
+	return m, rerunFailedJobs(m.client, pr.RepoName, jobID)
+}
+
+// handleOwnersLoaded lists the user and their organizations straight away and
+// starts streaming each owner's repositories, the owner the user started in
+// first. The owner list is usable from here on; owners fill in as they load.
+func (m *AppModel) handleOwnersLoaded(msg ownersLoadedMsg) (tea.Model, tea.Cmd) {
+	if !m.requests.isCurrent(OwnerSelection, msg.req) {
+		return m, nil
+	}
+	if msg.err != nil {
+		if m.currentView != PRList {
+			m.loading = false
+		}
+		m.error = msg.err.Error()
+		return m, nil
+	}
+	m.error = ""
+
+	owners := append([]string{msg.user}, msg.orgs...)
+	m.ownerPagers = make(map[string]*api.Pager[string], len(owners))
+	m.ownerQueue = nil
+	for _, owner := range owners {
+		m.ownerPagers[owner] = m.client.OwnerRepositories(owner, owner == msg.user)
+		if owner == m.selectedOwner {
+			m.ownerQueue = append([]string{owner}, m.ownerQueue...)
+		} else {
+			m.ownerQueue = append(m.ownerQueue, owner)
+		}
+	}
+
+	var spin tea.Cmd
+	if ownerList, ok := m.views[OwnerSelection].(*views.OwnerListModel); ok {
+		spin = ownerList.StartLoading(owners)
+		ownerList.SelectOwner(m.selectedOwner)
+		m.views[OwnerSelection] = ownerList
+	}
+	// A repository or PR list opened at startup keeps loading until its own
+	// data arrives, unless the owner it's for isn't one of the user's
+	if _, known := m.ownerPagers[m.selectedOwner]; m.currentView == OwnerSelection || (m.currentView == RepoSelection && !known) {
+		m.loading = false
+	}
+	return m, tea.Batch(spin, m.startOwnerLoads())
+}
+
+// startOwnerLoads starts streaming queued owners' repositories until
+// constants.MaxOwnerLoads owners are loading at once
+func (m *AppModel) startOwnerLoads() tea.Cmd {
+	var cmds []tea.Cmd
+	for m.ownerLoads < constants.MaxOwnerLoads && len(m.ownerQueue) > 0 {
+		owner := m.ownerQueue[0]
+		m.ownerQueue = m.ownerQueue[1:]
+		m.ownerLoads++
+		cmds = append(cmds, loadRepositories(m.requests.current(OwnerSelection), m.ownerPagers[owner], owner))
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleReposLoaded adds a page of an owner's repositories to the owner and
+// repository lists, keeping the cursors where they are, and fetches the
+// owner's next page. An owner that can't be listed (e.g. an organization
+// requiring SSO) is marked in the list rather than failing the whole load.
+func (m *AppModel) handleReposLoaded(msg reposLoadedMsg) (tea.Model, tea.Cmd) {
+	if !m.requests.isCurrent(OwnerSelection, msg.req) || m.ownerPagers[msg.owner] != msg.pager {
+		return m, nil
+	}
+	ownerList, ok := m.views[OwnerSelection].(*views.OwnerListModel)
+	if !ok {
+		return m, nil
+	}
+	if m.currentView == RepoSelection && msg.owner == m.selectedOwner {
+		m.loading = false
+	}
+
+	if msg.err != nil {
+		ownerList.FinishLoading(msg.owner, msg.err)
+		delete(m.ownerPagers, msg.owner)
+		m.ownerLoads--
+		return m, m.startOwnerLoads()
+	}
+	m.addRepositories(msg.repos) // Group repositories by owner
+
+	// Update owner list with data
+	owner := m.selectedOwner
+	if owner == "" {
+		owner = ownerList.GetSelectedOwner()
+	}
+	ownerList.SetData(m.repoGroups)
+	ownerList.SelectOwner(owner)
+	m.views[OwnerSelection] = ownerList
+
+	// Refresh the repo list beneath an already-selected owner
+	if m.selectedOwner == msg.owner {
+		if repoList, ok := m.views[RepoSelection].(*views.RepoListModel); ok {
+			repo := m.selectedRepo
+			if repo == "" {
+				repo = repoList.GetSelectedRepo()
+			}
+			repoList.SetData(m.selectedOwner, m.getReposForOwner(m.selectedOwner))
+			repoList.SelectRepo(repo)
+			m.views[RepoSelection] = repoList
+		}
+	}
+
+	if msg.more {
+		return m, loadRepositories(m.requests.current(OwnerSelection), msg.pager, msg.owner)
+	}
+	ownerList.FinishLoading(msg.owner, nil)
+	delete(m.ownerPagers, msg.owner)
+	m.ownerLoads--
+	return m, m.startOwnerLoads()
+}
+
+// handlePRsLoaded shows the first page of the PR list, or appends a further
+// page, and then fetches ahead if the user is already near the end of the
+// list. Pages for a repository or list state the user has since left are dropped.
+func (m *AppModel) handlePRsLoaded(msg prsLoadedMsg) (tea.Model, tea.Cmd) {
+	prList, ok := m.views[PRList].(*views.PRListModel)
+	if !ok || !m.requests.isCurrent(PRList, msg.req) {
+		return m, nil
+	}
+
+	if !msg.first {
+		m.prLoadingMore = false
+		if msg.err != nil {
+			m.error = msg.err.Error()
+			return m, nil
+		}
+		prList.AppendData(msg.prs)
+	} else if m.prRefreshing {
+		// A failed refresh leaves the list as it was
+		m.prRefreshing = false
+		m.lastRefresh = time.Now()
+		if msg.err != nil {
+			m.error = msg.err.Error()
+			return m, nil
+		}
+		prList.Refresh(msg.prs, msg.more)
+		m.rowsRequested = make(map[int]bool)
+	} else {
+		m.loading = false
+		m.lastRefresh = time.Now()
+		if msg.err != nil {
+			m.error = msg.err.Error()
+			return m, nil
+		}
+		m.error = ""
+		prList.SetData(m.selectedRepo, msg.prs)
+		m.rowsRequested = make(map[int]bool)
+	}
+
+	prList.SetHasMore(msg.more)
+	m.views[PRList] = prList
+	return m, tea.Batch(m.loadVisibleRowData(), m.loadMorePRs())
+}
+
+// updateCurrentView passes msg to the current view
+func (m *AppModel) updateCurrentView(msg tea.Msg) (tea.Model, tea.Cmd) {
+	view, exists := m.views[m.currentView]
+	if !exists {
+		return m, nil
+	}
+	updatedView, cmd := view.Update(msg)
+	m.views[m.currentView] = updatedView
+	return m, cmd
+}
+
+// handleStateKey cycles the PR list through open, closed, merged and all PRs
+func (m *AppModel) handleStateKey() (tea.Model, tea.Cmd) {
+	if m.currentView != PRList {
+		return m, nil
+	}
+	prList, ok := m.views[PRList].(*views.PRListModel)
+	if !ok {
+		return m, nil
+	}
+	prList.SetState(models.NextPRListState(prList.GetState()))
+	prList.SetData(m.selectedRepo, []*models.PullRequest{})
+	m.views[PRList] = prList
+	m.loading = true
+	m.error = ""
+	m.notice = ""
+	return m, m.reloadPullRequests()
+}
+
+// handleReadKey marks the PR under the cursor as read, or as unread again if
+// it already is
+func (m *AppModel) handleReadKey() (tea.Model, tea.Cmd) {
+	if m.currentView != PRList {
+		return m, nil
+	}
+	prList, ok := m.views[PRList].(*views.PRListModel)
+	if !ok {
+		return m, nil
+	}
+	pr := prList.GetSelectedPR()
+	if pr == nil {
+		return m, nil
+	}
+	var err error
+	if prList.GetUnread(pr).Unread {
+		prList.MarkViewed(pr)
+		err = m.seen.markRead(time.Now(), pr)
+		m.notice = fmt.Sprintf(constants.MsgMarkedRead, pr.Number)
+	} else {
+		err = m.seen.markUnread(pr)
+		m.notice = fmt.Sprintf(constants.MsgMarkedUnread, pr.Number)
+	}
+	m.error = ""
+	if err != nil {
+		m.error = fmt.Sprintf("%s: %v", constants.ErrSavingSeen, err)
+	}
+	return m, nil
+}
+
+// handleReadAllKey marks every loaded PR in the list as read
+func (m *AppModel) handleReadAllKey() (tea.Model, tea.Cmd) {
+	if m.currentView != PRList {
+		return m, nil
+	}
+	prList, ok := m.views[PRList].(*views.PRListModel)
+	if !ok {
+		return m, nil
+	}
+	prs := prList.GetPRs()
+	for _, pr := range prs {
+		prList.MarkViewed(pr)
+	}
+	m.error = ""
+	m.notice = fmt.Sprintf(constants.MsgMarkedAllRead, len(prs))
+	if err := m.seen.markRead(time.Now(), prs...); err != nil {
+		m.error = fmt.Sprintf("%s: %v", constants.ErrSavingSeen, err)
+	}
+	return m, nil
+}
+
+// newPRPager returns a pager over the selected repository's PRs in the list's
+// current state
+func (m *AppModel) newPRPager() *api.Pager[*models.PullRequest] {
+	state := models.PRStateOpen
+	if prList, ok := m.views[PRList].(*views.PRListModel); ok {
+		state = prList.GetState()
+	}
+	owner, repo, _ := strings.Cut(m.selectedRepo, "/")
+	return m.client.PullRequests(owner, repo, state)
+}
+
+// reloadPullRequests starts paging through the selected repository's PRs afresh
+func (m *AppModel) reloadPullRequests() tea.Cmd {
+	m.prPager = m.newPRPager()
+	m.prLoadingMore = false
+	m.prRefreshing = false
+	return loadPullRequests(m.requests.begin(PRList), m.prPager, true)
+}
+
+// autoRefresh reloads the PR list's first page once ui.auto_refresh has passed
+// since it last arrived, while the list is on screen and idle. It pauses while
+// a range is being selected, a prompt is open or GitHub is unreachable.
+func (m *AppModel) autoRefresh(now time.Time) tea.Cmd {
+	interval := m.config.UI.AutoRefresh
+	if interval <= 0 || m.currentView != PRList || m.prPager == nil || m.loading ||
+		m.prRefreshing || m.prLoadingMore || m.confirmOutbox || m.client.Offline() {
+		return nil
+	}
+	if prList, ok := m.views[PRList].(*views.PRListModel); !ok || prList.IsRangeActive() {
+		return nil
+	}
+	if now.Sub(m.lastRefresh) < interval {
+		return nil
+	}
+	cmd := m.reloadPullRequests()
+	m.prRefreshing = true
+	return cmd
+}
+
+// markViewed records a PR being opened as read and clears its new or changed
+// mark in the PR list
+func (m *AppModel) markViewed(pr *models.PullRequest) {
+	if prList, ok := m.views[PRList].(*views.PRListModel); ok {
+		prList.MarkViewed(pr)
+	}
+	if err := m.seen.markRead(time.Now(), pr); err != nil {
+		m.error = fmt.Sprintf("%s: %v", constants.ErrSavingSeen, err)
+	}
+}
+
+// tick schedules the next clock tick, every ui.refresh_rate
+func (m AppModel) tick() tea.Cmd {
+	rate := m.config.UI.RefreshRate
+	if rate <= 0 {
+		rate = constants.RefreshInterval
+	}
+	return tea.Tick(rate, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
+
+// loadMorePRs fetches the next page of the PR list once the user nears the
+// end of the loaded PRs, so history is only fetched as far as they scroll
+func (m *AppModel) loadMorePRs() tea.Cmd {
+	prList, ok := m.views[PRList].(*views.PRListModel)
+	if !ok || m.prPager == nil || !prList.HasMore() || m.prLoadingMore || !prList.IsNearEnd() {
+		return nil
+	}
+	m.prLoadingMore = true
+	return loadPullRequests(m.requests.current(PRList), m.prPager, false)
+}
+
+// loadVisibleRowData requests CI status and comment and thread counts for the PRs
+// on the current page of the PR list that haven't been requested yet
+func (m *AppModel) loadVisibleRowData() tea.Cmd {
+	prList, ok := m.views[PRList].(*views.PRListModel)
+	if !ok {
+		return nil
+	}
+
+	var pending []*models.PullRequest
+	for _, pr := range prList.GetVisiblePRs() {
+		if !m.rowsRequested[pr.Number] && pr.HeadSHA != "" {
+			m.rowsRequested[pr.Number] = true
+			pending = append(pending, pr)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	req := m.requests.current(PRList)
+	return tea.Batch(
+		loadCIStatuses(req, m.client, m.selectedRepo, pending),
+		loadActivity(req, m.client, m.selectedRepo, pending),
+	)
+}
+
+// prView is implemented by views that show a single pull request
+type prView interface {
+	GetPR() *models.PullRequest
+}
+
+// selectedPR returns the pull request under the cursor in the PR list, or the one
+// shown by the current view, setting an error when the list is active but empty
+func (m *AppModel) selectedPR() *models.PullRequest {
+	view := m.views[m.currentView]
+	switch m.currentView {
+	case PRList:
+		prList, ok := view.(*views.PRListModel)
+		if !ok {
+			return nil
+		}
+		pr := prList.GetSelectedPR()
+		if pr == nil {
+			m.error = constants.ErrNoPRSelected
+		}
+		return pr
+	case LogView:
+		// The log belongs to the PR open in the detail view
+		view = m.views[PRDetail]
+	}
+
+	if v, ok := view.(prView); ok {
+		return v.GetPR()
+	}
+	return nil
+}
+
+// handleCheckoutKey checks out the selected pull request into the local clone
+func (m *AppModel) handleCheckoutKey() (tea.Model, tea.Cmd) {
+	pr := m.selectedPR()
+	if pr == nil {
+		return m, nil
+	}
+
+	m.error = ""
+	m.notice = constants.MsgCheckingOut
+	return m, checkoutPullRequest(m.config, pr)
+}
+
+// pushView navigates to a view, remembering the current one for back navigation
+func (m *AppModel) pushView(next ViewMode) {
+	m.viewStack = append(m.viewStack, m.currentView)
+	m.currentView = next
+}
+
+// handleBrowserKey opens the selected pull request in the web browser
+func (m *AppModel) handleBrowserKey() (tea.Model, tea.Cmd) {
+	if m.currentView == LogView {
+		if logViewer, ok := m.views[LogView].(*views.LogViewerModel); ok && logViewer.GetURL() != "" {
+			return m, openInBrowser(m.config.External.Browser, logViewer.GetURL())
+		}
+		return m, nil
+	}
+
+	pr := m.selectedPR()
+	if pr == nil || pr.URL == "" {
+		return m, nil
+	}
+
+	return m, openInBrowser(m.config.External.Browser, pr.URL)
+}
+
+// handleBackKey handles the back key press for navigation
+func (m *AppModel) handleBackKey() (tea.Model, tea.Cmd) {
+	if len(m.viewStack) == 0 {
+		return m, nil
+	}
+
+	leaving := m.currentView
+	m.currentView = m.viewStack[len(m.viewStack)-1]
+	m.viewStack = m.viewStack[:len(m.viewStack)-1]
+
+	// Whatever the view was still loading is no longer wanted
+	m.requests.cancel(leaving)
+
+	switch leaving {
+	case RepoSelection:
+		m.selectedOwner = ""
+		m.selectedRepo = ""
+	case PRList:
+		m.selectedRepo = ""
+		m.notice = ""
+		m.rowsRequested = make(map[int]bool)
+		m.prPager = nil
+		m.prLoadingMore = false
+		m.prRefreshing = false
+		// Clear PR list data
+		if prList, ok := m.views[PRList].(*views.PRListModel); ok {
+			prList.SetData("", []*models.PullRequest{})
+			m.views[PRList] = prList
+		}
+	case PRDetail:
+		m.notice = ""
+		m.error = ""
+		if prDetail, ok := m.views[PRDetail].(*views.PRDetailModel); ok {
+			prDetail.SetPR(nil)
+			m.views[PRDetail] = prDetail
+		}
+	case BulkView:
+		// A finished job consumes the selection
+		bulk, ok := m.views[BulkView].(*views.BulkModel)
+		prList, listOK := m.views[PRList].(*views.PRListModel)
+		if ok && listOK && bulk.IsFinished() {
+			prList.ClearSelection()
+			m.views[PRList] = prList
+		}
+	}
+	return m, nil
+}
+
+// View renders the UI
+func (m AppModel) View() string {
+	if m.width == 0 {
+		return "Loading..."
+	}
+
+	title := m.theme.Styles.Title.Render("GitHub PR Dashboard")
+	if m.client.Offline() {
+		title += " " + m.theme.Styles.Warning.Render(fmt.Sprintf("OFFLINE (%d queued)", m.outbox.len()))
+	}
+
+	// Status line
+	status := ""
+	if m.confirmOutbox {
+		status = m.theme.Styles.Warning.Render(fmt.Sprintf("%s %s", m.theme.Icons.Info, fmt.Sprintf(constants.MsgSendQueued, m.outbox.len())))
+	} else if m.loading {
+		status = m.theme.Styles.Warning.Render(fmt.Sprintf("%s Loading repositories...", m.theme.Icons.Loading))
+	} else if m.error != "" {
+		status = m.theme.Styles.Error.Render(fmt.Sprintf("%s %s", m.theme.Icons.Error, m.error))
+	} else if m.notice != "" {
+		status = m.theme.Styles.Info.Render(fmt.Sprintf("%s %s", m.theme.Icons.Info, m.notice))
+	} else {
+		switch m.currentView {
+		case OwnerSelection:
+			orgCount := len(m.repoGroups)
+			status = m.theme.Styles.Success.Render(fmt.Sprintf("%s %s (%d organizations)",
+				m.theme.Icons.Success, m.getPageInfo(), orgCount))
+		case RepoSelection:
+			info := m.getPageInfo()
+			if ownerList, ok := m.views[OwnerSelection].(*views.OwnerListModel); ok && ownerList.IsOwnerLoading(m.selectedOwner) {
+				info += " (loading more...)"
+			}
+			status = m.theme.Styles.Success.Render(fmt.Sprintf("%s %s - %s",
+				m.theme.Icons.Success, info, m.selectedOwner))
+		case PRList:
+			status = m.theme.Styles.Success.Render(fmt.Sprintf("%s %s - %s",
+				m.theme.Icons.Success, m.getPageInfo(), m.selectedRepo))
+		case PRDetail:
+			status = m.theme.Styles.Success.Render(fmt.Sprintf("%s %s - %s",
+				m.theme.Icons.Success, m.getPageInfo(), m.selectedRepo))
+		case LogView:
+			status = m.theme.Styles.Success.Render(fmt.Sprintf("%s Job log - %s",
+				m.theme.Icons.Success, m.selectedRepo))
+		case ThreadsView, FilesView:
+			status = m.theme.Styles.Success.Render(fmt.Sprintf("%s %s - %s",
+				m.theme.Icons.Success, m.getPageInfo(), m.selectedRepo))
+		case DiffView:
+			status = m.theme.Styles.Success.Render(fmt.Sprintf("%s Diff - %s",
+				m.theme.Icons.Success, m.selectedRepo))
+		case ComposeView:
+			status = m.theme.Styles.Success.Render(fmt.Sprintf("%s Compose - %s",
+				m.theme.Icons.Success, m.selectedRepo))
+		case MergeView:
+			status = m.theme.Styles.Success.Render(fmt.Sprintf("%s Merge - %s",
+				m.theme.Icons.Success, m.selectedRepo))
+		case PickerView:
+			status = m.theme.Styles.Success.Render(fmt.Sprintf("%s %s - %s",
+				m.theme.Icons.Success, m.getPageInfo(), m.selectedRepo))
+		case BulkView:
+			status = m.theme.Styles.Success.Render(fmt.Sprintf("%s Bulk action - %s",
+				m.theme.Icons.Success, m.selectedRepo))
+		}
+	}
+
+	if age := m.dataAge(); age != "" && !m.confirmOutbox {
+		status += m.theme.Styles.Warning.Render(" • " + age)
+	}
+
+	// Get content from current view
+	list := ""
+	if view, exists := m.views[m.currentView]; exists {
+		list = view.View()
+	}
+
+	helpText := constants.HelpNavigation
+	switch m.currentView {
+	case PRList:
+		helpText = constants.HelpPRList
+	case PRDetail:
+		helpText = constants.HelpPRDetail
+	case ThreadsView:
+		helpText = constants.HelpThreads
+	case FilesView:
+		helpText = constants.HelpFiles
+	case DiffView:
+		helpText = constants.HelpDiff
+	case ComposeView:
+		helpText = constants.HelpCompose
+		if compose, ok := m.views[ComposeView].(*views.ComposeModel); ok {
+			if compose.IsConfirming() {
+				helpText = constants.HelpConfirm
+			} else if draft := compose.GetDraft(); draft != nil && draft.Kind == models.DraftReview {
+				helpText = constants.HelpReview
+			} else if draft != nil && draft.Kind == models.DraftClose {
+				helpText = constants.HelpClose
+			}
+		}
+	case PickerView:
+		helpText = constants.HelpPicker
+	case BulkView:
+		helpText = constants.HelpBulkInput
+		if bulk, ok := m.views[BulkView].(*views.BulkModel); ok {
+			switch {
+			case bulk.IsChoosing():
+				helpText = constants.HelpBulkMenu
+			case bulk.IsConfirming():
+				helpText = constants.HelpConfirm
+			case bulk.IsRunning():
+				helpText = constants.HelpBulkRun
+			case bulk.IsFinished():
+				helpText = constants.HelpBulkDone
+			}
+		}
+	case MergeView:
+		helpText = constants.HelpMergeBack
+		if mergeView, ok := m.views[MergeView].(*views.MergeModel); ok {
+			if mergeView.IsConfirming() {
+				helpText = constants.HelpConfirm
+			} else if mergeView.CapturesInput() {
+				helpText = constants.HelpMerge
+			}
+		}
+	case LogView:
+		helpText = constants.HelpLogView
+		if capturer, ok := m.views[LogView].(views.InputCapturer); ok && capturer.CapturesInput() {
+			helpText = constants.HelpLogSearch
+		}
+	}
+	if m.confirmOutbox {
+		helpText = constants.HelpConfirm
+	}
+	// The help line is indented by its style's margin
+	help := m.theme.Styles.Help.Render(fitHelp(helpText, m.width-2))
+
+	// Debug information
+	debug := ""
+	if m.debugMode {
+		debug = m.theme.Styles.Debug.Render(fmt.Sprintf("Debug: %s", m.debugInfo))
+	}
+
+	return fmt.Sprintf("%s\n%s\n%s\n%s\n%s", title, status, list, help, debug)
+}
+
+// fitHelp drops the last entries of a help line until it fits in width
+// columns, so the footer never wraps
+func fitHelp(text string, width int) string {
+	if lipgloss.Width(text) <= width {
+		return text
+	}
+	entries := strings.Split(text, constants.HelpSeparator)
+	for n := len(entries) - 1; n > 0; n-- {
+		fitted := strings.Join(entries[:n], constants.HelpSeparator) + constants.HelpSeparator + "…"
+		if lipgloss.Width(fitted) <= width {
+			return fitted
+		}
+	}
+	return "…"
+}
+
+// dataAge describes how old the current view's data is when some of it came
+// from the cache while offline (e.g. "cached 2h ago"), or returns ""
+func (m AppModel) dataAge() string {
+	// Views showing data another view loaded
+	sources := []ViewMode{m.currentView}
+	switch m.currentView {
+	case RepoSelection:
+		sources = []ViewMode{OwnerSelection}
+	case PRDetail:
+		sources = append(sources, PRList)
+	case DiffView:
+		sources = []ViewMode{FilesView}
+	}
+
+	var oldest time.Time
+	for _, view := range sources {
+		if since := m.requests.cachedSince(view); !since.IsZero() && (oldest.IsZero() || since.Before(oldest)) {
+			oldest = since
+		}
+	}
+	if oldest.IsZero() {
+		return ""
+	}
+	return "cached " + formatAge(time.Since(oldest))
+}
+
+// formatAge renders how long ago something happened, coarsely (e.g. 2h ago)
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+}
+
+// loadPullRequests fetches the next page of a PR list's pager
+func loadPullRequests(req *request, pager *api.Pager[*models.PullRequest], first bool) tea.Cmd {
+	return func() tea.Msg {
+		// Create context with timeout
+		ctx, cancel := context.WithTimeout(req.ctx, constants.DefaultTimeout)
+		defer cancel()
+
+		prs, err := pager.Next(ctx)
+		return prsLoadedMsg{
+			req:   req.id,
+			first: first,
+			more:  !pager.Done(),
+			prs:   prs,
+			err:   err,
+		}
+	}
+}
+
+// loadMergedBy fetches who merged a pull request, which listings leave out
+func loadMergedBy(req *request, client api.GitHub, pr *models.PullRequest) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(req.ctx, constants.DefaultTimeout)
+		defer cancel()
+
+		owner, repo, _ := strings.Cut(pr.RepoName, "/")
+		githubPR, err := client.GetPullRequest(ctx, owner, repo, pr.Number)
+		if err != nil {
+			return mergedByLoadedMsg{req: req.id, pr: pr, err: err}
+		}
+		return mergedByLoadedMsg{req: req.id, pr: pr, mergedBy: githubPR.GetMergedBy().GetLogin()}
+	}
+}
+
+// loadChecks fetches the CI checks of a pull request's head commit
+func loadChecks(req *request, client api.GitHub, pr *models.PullRequest) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(req.ctx, constants.DefaultTimeout)
+		defer cancel()
+
+		owner, repo, _ := strings.Cut(pr.RepoName, "/")
+		checks, err := client.GetChecks(ctx, owner, repo, pr.HeadSHA)
+		return checksLoadedMsg{
+			req:    req.id,
+			checks: checks,
+			err:    err,
+		}
+	}
+}
+
+// loadCIStatuses fetches the checks of several pull requests for the list's CI column.
+// PRs whose fetch fails are left without a status; the first error is reported.
+func loadCIStatuses(req *request, client api.GitHub, repoName string, prs []*models.PullRequest) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(req.ctx, constants.DefaultTimeout)
+		defer cancel()
+
+		owner, repo, _ := strings.Cut(repoName, "/")
+		msg := ciStatusesLoadedMsg{req: req.id, checks: make(map[int][]*models.Check)}
+		for _, pr := range prs {
+			checks, err := client.GetChecks(ctx, owner, repo, pr.HeadSHA)
+			if err != nil {
+				if msg.err == nil {
+					msg.err = err
+				}
+				continue
+			}
+			msg.checks[pr.Number] = checks
+		}
+		return msg
+	}
+}
+
+// loadThreads fetches a pull request's comments grouped into threads
+func loadThreads(req *request, client api.GitHub, pr *models.PullRequest) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(req.ctx, constants.DefaultTimeout)
+		defer cancel()
+
+		owner, repo, _ := strings.Cut(pr.RepoName, "/")
+		threads, err := client.GetThreads(ctx, owner, repo, pr.Number, pr.ID)
+		return threadsLoadedMsg{
+			req:     req.id,
+			threads: threads,
+			err:     err,
+		}
+	}
+}
+
+// loadFiles fetches the files changed by a pull request together with its review
+// threads, which the diff viewer shows inline
+func loadFiles(req *request, client api.GitHub, pr *models.PullRequest) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(req.ctx, constants.DefaultTimeout)
+		defer cancel()
+
+		owner, repo, _ := strings.Cut(pr.RepoName, "/")
+		msg := filesLoadedMsg{req: req.id}
+		msg.files, msg.err = client.GetPullRequestFiles(ctx, owner, repo, pr.Number)
+		if msg.err == nil {
+			msg.threads, msg.threadsErr = client.GetThreads(ctx, owner, repo, pr.Number, pr.ID)
+		}
+		return msg
+	}
+}
+
+// loadActivity fetches comment and unresolved review thread counts for the PR list
+func loadActivity(req *request, client api.GitHub, repoName string, prs []*models.PullRequest) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(req.ctx, constants.DefaultTimeout)
+		defer cancel()
+
+		numbers := make([]int, len(prs))
+		for i, pr := range prs {
+			numbers[i] = pr.Number
+		}
+
+		owner, repo, _ := strings.Cut(repoName, "/")
+		activity, err := client.GetActivity(ctx, owner, repo, numbers)
+		return activityLoadedMsg{
+			req:      req.id,
+			activity: activity,
+			err:      err,
+		}
+	}
+}
+
+// loadJobLog downloads the log of an Actions job
+func loadJobLog(req *request, client api.GitHub, repoName string, jobID int64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(req.ctx, constants.DefaultTimeout)
+		defer cancel()
+
+		owner, repo, _ := strings.Cut(repoName, "/")
+		log, err := client.GetJobLog(ctx, owner, repo, jobID)
+		return jobLogLoadedMsg{
+			req: req.id,
+			log: log,
+			err: err,
+		}
+	}
+}
+
+// rerunFailedJobs asks GitHub to re-run the failed jobs of a job's workflow run
+func rerunFailedJobs(client api.GitHub, repoName string, jobID int64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), constants.DefaultTimeout)
+		defer cancel()
+
+		owner, repo, _ := strings.Cut(repoName, "/")
+		if err := client.RerunFailedJobs(ctx, owner, repo, jobID); err != nil {
+			return statusMsg{err: err}
+		}
+		return statusMsg{notice: constants.MsgRerunStarted}
+	}
+}
+
+// postDraft sends a comment, reply or review to GitHub
+func postDraft(client api.GitHub, draft *models.Draft) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), constants.DefaultTimeout)
+		defer cancel()
+
+		owner, repo, _ := strings.Cut(draft.PR.RepoName, "/")
+		var err error
+		switch draft.Kind {
+		case models.DraftReply:
+			err = client.ReplyToReviewComment(ctx, owner, repo, draft.PR.Number, draft.ReplyTo, draft.Body)
+		case models.DraftReview:
+			err = client.SubmitReview(ctx, owner, repo, draft.PR.Number, draft.Event, draft.Body)
+		case models.DraftClose:
+			// Close first so a retry after a failed comment can't close twice
+			if err = client.ClosePullRequest(ctx, owner, repo, draft.PR.Number); err != nil {
+				break
+			}
+			msg := draftPostedMsg{draft: draft, closed: true}
+			if draft.Body != "" {
+				if err := client.CreateComment(ctx, owner, repo, draft.PR.Number, draft.Body); err != nil {
+					msg.err = fmt.Errorf("closed #%d but the comment failed: %w", draft.PR.Number, err)
+				}
+			}
+			return msg
+		default:
+			err = client.CreateComment(ctx, owner, repo, draft.PR.Number, draft.Body)
+		}
+		return draftPostedMsg{draft: draft, err: err}
+	}
+}
+
+// setDraft converts a PR to a draft or marks it ready, per pr.IsDraft
+func setDraft(client api.GitHub, pr *models.PullRequest, prevDraft bool, notice string) tea.Cmd {
+	draft := pr.IsDraft
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), constants.DefaultTimeout)
+		defer cancel()
+
+		return lifecycleDoneMsg{
+			pr:        pr,
+			notice:    notice,
+			prevDraft: prevDraft,
+			prevState: models.PRStateOpen,
+			err:       client.SetDraft(ctx, pr.NodeID, draft),
+		}
+	}
+}
+
+// reopenPullRequest reopens a closed PR
+func reopenPullRequest(client api.GitHub, pr *models.PullRequest) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), constants.DefaultTimeout)
+		defer cancel()
+
+		owner, repo, _ := strings.Cut(pr.RepoName, "/")
+		return lifecycleDoneMsg{
+			pr:        pr,
+			notice:    fmt.Sprintf("Reopened #%d", pr.Number),
+			prevDraft: pr.IsDraft,
+			prevState: models.PRStateClosed,
+			err:       client.ReopenPullRequest(ctx, owner, repo, pr.Number),
+		}
+	}
+}
+
+// loadMergeInfo fetches the repository's merge settings and the PR's mergeability
+func loadMergeInfo(req *request, client api.GitHub, pr *models.PullRequest) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(req.ctx, constants.DefaultTimeout)
+		defer cancel()
+
+		owner, repo, _ := strings.Cut(pr.RepoName, "/")
+		info, err := client.GetMergeInfo(ctx, owner, repo, pr.Number)
+		return mergeInfoLoadedMsg{
+			req:  req.id,
+			info: info,
+			err:  err,
+		}
+	}
+}
+
+// mergePullRequest merges a PR (or enables auto-merge) and deletes its head
+// branch when asked
+func mergePullRequest(client api.GitHub, pr *models.PullRequest, info *models.MergeInfo, req models.MergeRequest) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), constants.DefaultTimeout)
+		defer cancel()
+
+		msg := mergeDoneMsg{pr: pr, request: req}
+		if req.Auto {
+			msg.err = client.EnableAutoMerge(ctx, info.NodeID, req)
+			return msg
+		}
+
+		owner, repo, _ := strings.Cut(pr.RepoName, "/")
+		msg.err = client.MergePullRequest(ctx, owner, repo, pr.Number, info.HeadSHA, req)
+		if msg.err == nil && req.DeleteBranch {
+			msg.branchErr = client.DeleteBranch(ctx, owner, repo, pr.HeadRef)
+		}
+		return msg
+	}
+}
+
+// loadPickerOptions fetches the values a PR field can take in a repository
+func loadPickerOptions(req *request, client api.GitHub, repoName, field string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(req.ctx, constants.DefaultTimeout)
+		defer cancel()
+
+		owner, repo, _ := strings.Cut(repoName, "/")
+		options, err := client.GetFieldOptions(ctx, owner, repo, field)
+		return pickerOptionsLoadedMsg{
+			req:     req.id,
+			options: options,
+			err:     err,
+		}
+	}
+}
+
+// updateField sends the difference between a field's old and new values to
+// GitHub. If that fails, the PR is fetched again to learn which values it ended
+// up with.
+func updateField(client api.GitHub, pr *models.PullRequest, field string, before, after []string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), constants.DefaultTimeout)
+		defer cancel()
+
+		owner, repo, _ := strings.Cut(pr.RepoName, "/")
+		add, remove := models.DiffValues(before, after)
+		msg := fieldUpdatedMsg{
+			pr:     pr,
+			field:  field,
+			before: before,
+			after:  after,
+			err:    client.UpdateField(ctx, owner, repo, pr.Number, field, add, remove),
+		}
+		if msg.err != nil {
+			if githubPR, err := client.GetPullRequest(ctx, owner, repo, pr.Number); err == nil {
+				msg.current = models.FromGitHubPR(githubPR, pr.RepoName).FieldValues(field)
+			}
+		}
+		return msg
+	}
+}
+
+// runBulkStep applies a bulk job's action to the PR at index
+func runBulkStep(client api.GitHub, job *bulkJob, index int) tea.Cmd {
+	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), constants.DefaultTimeout)
 		defer cancel()
 
-		// Fetch repositories
-		repos, err := client.GetUserRepositories(ctx)
+		pr := job.prs[index]
+		msg := bulkStepDoneMsg{job: job.id, index: index}
+		msg.autoMerge, msg.err = applyBulkAction(ctx, client, job.action, job.arg, pr)
+		return msg
+	}
+}
+
+// applyBulkAction applies one bulk action to a PR. Merges use the repository's
+// first allowed method with GitHub's default message, and fall back to enabling
+// auto-merge when only pending checks are in the way.
+func applyBulkAction(ctx context.Context, client api.GitHub, action, arg string, pr *models.PullRequest) (autoMerge bool, err error) {
+	owner, repo, _ := strings.Cut(pr.RepoName, "/")
+	switch action {
+	case models.BulkApprove:
+		return false, client.SubmitReview(ctx, owner, repo, pr.Number, models.ReviewEventApprove, "")
+	case models.BulkClose:
+		return false, client.ClosePullRequest(ctx, owner, repo, pr.Number)
+	case models.BulkAddLabel:
+		return false, client.UpdateField(ctx, owner, repo, pr.Number, models.EditLabels, []string{arg}, nil)
+	case models.BulkRequestReviewer:
+		return false, client.UpdateField(ctx, owner, repo, pr.Number, models.EditReviewers, []string{arg}, nil)
+	case models.BulkComment:
+		return false, client.CreateComment(ctx, owner, repo, pr.Number, arg)
+	case models.BulkMerge:
+		info, err := client.GetMergeInfo(ctx, owner, repo, pr.Number)
 		if err != nil {
-			return reposLoadedMsg{
-				repos: nil,
-				err:   err,
+			return false, err
+		}
+		readiness := info.Readiness()
+		if readiness.Blocked() {
+			return false, errors.New(strings.Join(readiness.Blockers, " "))
+		}
+		req := models.MergeRequest{Method: info.Methods[0], Auto: readiness.CanAuto}
+		req.Title, req.Body = info.MergeMessage(pr, req.Method)
+		if req.Auto {
+			return true, client.EnableAutoMerge(ctx, info.NodeID, req)
+		}
+		return false, client.MergePullRequest(ctx, owner, repo, pr.Number, info.HeadSHA, req)
+	}
+	return false, fmt.Errorf("unknown bulk action %q", action)
+}
+
+// checkoutPullRequest fetches and checks out a pull request using local git
+func checkoutPullRequest(cfg *config.Config, pr *models.PullRequest) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), constants.DefaultTimeout)
+		defer cancel()
+
+		result, err := git.NewClient("").CheckoutPullRequest(ctx, git.CheckoutOptions{
+			RepoName:    pr.RepoName,
+			Number:      pr.Number,
+			Branch:      pr.CheckoutBranch(),
+			WorktreeDir: cfg.Git.WorktreeDir,
+		})
+
+		return checkoutDoneMsg{
+			pr:     pr,
+			result: result,
+			err:    err,
+		}
+	}
+}
+
+// openInBrowser opens a URL with the configured browser
+func openInBrowser(command, url string) tea.Cmd {
+	return func() tea.Msg {
+		if err := browser.Open(command, url); err != nil {
+			return statusMsg{err: err}
+		}
+		return statusMsg{notice: constants.MsgOpenedBrowser}
+	}
+}
+
+// getPageInfo returns pagination information from the current view
+func (m AppModel) getPageInfo() string {
+	if view, exists := m.views[m.currentView]; exists {
+		switch m.currentView {
+		case OwnerSelection:
+			if ownerList, ok := view.(*views.OwnerListModel); ok {
+				return ownerList.GetPageInfo()
+			}
+		case RepoSelection:
+			if repoList, ok := view.(*views.RepoListModel); ok {
+				return repoList.GetPageInfo()
+			}
+		case PRList:
+			if prList, ok := view.(*views.PRListModel); ok {
+				return prList.GetPageInfo()
+			}
+		case PRDetail:
+			if prDetail, ok := view.(*views.PRDetailModel); ok {
+				return prDetail.GetPageInfo()
+			}
+		case ThreadsView:
+			if threadsView, ok := view.(*views.ThreadsModel); ok {
+				return threadsView.GetPageInfo()
+			}
+		case FilesView:
+			if filesView, ok := view.(*views.FilesModel); ok {
+				return filesView.GetPageInfo()
 			}
+		case PickerView:
+			if picker, ok := view.(*views.PickerModel); ok {
+				return picker.GetPageInfo()
+			}
+		}
+	}
+	return "Loading..."
+}
+
+// groupRepositories groups repositories by owner (user/org)
+func (m *AppModel) groupRepositories(repos []string) {
+	m.repoGroups = make(map[string][]string)
+	m.addRepositories(repos)
+}
+
+// addRepositories adds repositories to their owners' groups
+func (m *AppModel) addRepositories(repos []string) {
+	for _, repo := range repos {
+		parts := strings.Split(repo, "/")
+		if len(parts) == 2 {
+			owner := parts[0]
+			m.repoGroups[owner] = append(m.repoGroups[owner], repo)
 		}
+	}
+}
+
+// getReposForOwner returns repositories for a specific owner
+func (m AppModel) getReposForOwner(owner string) []string {
+	if repos, exists := m.repoGroups[owner]; exists {
+		return repos
+	}
+	return []string{}
+}
+
+// loadOwners fetches the authenticated user and their organizations
+func loadOwners(req *request, client api.GitHub) tea.Cmd {
+	return func() tea.Msg {
+		// Create context with timeout
+		ctx, cancel := context.WithTimeout(req.ctx, constants.DefaultTimeout)
+		defer cancel()
+
+		user, orgs, err := client.GetOwners(ctx)
+		return ownersLoadedMsg{req: req.id, user: user, orgs: orgs, err: err}
+	}
+}
+
+// loadRepositories fetches the next page of an owner's repositories
+func loadRepositories(req *request, pager *api.Pager[string], owner string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(req.ctx, constants.DefaultTimeout)
+		defer cancel()
 
+		repos, err := pager.Next(ctx)
 		return reposLoadedMsg{
+			req:   req.id,
+			pager: pager,
+			owner: owner,
 			repos: repos,
-			err:   nil,
+			more:  err == nil && !pager.Done(),
+			err:   err,
 		}
 	}
 }
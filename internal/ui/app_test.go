@@ -1,15 +1,24 @@
 package ui
 
 import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v58/github"
+	"github.com/will-wright-eng/gh-nav/internal/api"
+	"github.com/will-wright-eng/gh-nav/internal/models"
 	"github.com/will-wright-eng/gh-nav/internal/ui/views"
 	"github.com/will-wright-eng/gh-nav/pkg/config"
 )
 
 func TestNewApp(t *testing.T) {
 	cfg := &config.Config{}
-	app := NewApp(cfg)
+	app := NewApp(cfg, &fakeGitHub{})
 
 	if app == nil {
 		t.Fatal("Expected app to be created")
@@ -35,31 +44,12 @@ func TestNewApp(t *testing.T) {
 		t.Error("Expected views to be initialized")
 	}
 
-	if len(app.views) != 3 {
-		t.Error("Expected 3 views to be initialized")
+	if len(app.views) != 12 {
+		t.Error("Expected 12 views to be initialized")
 	}
 }
 
-func TestMaskToken(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected string
-	}{
-		{"", "***"},
-		{"short", "***"},
-		{"gho_1234567890abcdef", "gho_...cdef"},
-		{"gho_abcdef1234567890", "gho_...7890"},
-	}
-
-	for _, test := range tests {
-		result := maskToken(test.input)
-		if result != test.expected {
-			t.Errorf("maskToken(%q) = %q, expected %q", test.input, result, test.expected)
-		}
-	}
-}
-
-func TestLoadRepositories(t *testing.T) {
+func TestLoadOwners(t *testing.T) {
 	// Load config to get token
 	cfg, err := config.Load()
 	if err != nil {
@@ -72,38 +62,58 @@ func TestLoadRepositories(t *testing.T) {
 	}
 
 	// Test that the command returns a message
-	cmd := loadRepositories(cfg)
+	cmd := loadOwners(newRequests().begin(OwnerSelection), api.NewClient(cfg))
 	if cmd == nil {
-		t.Fatal("Expected loadRepositories to return a command")
+		t.Fatal("Expected loadOwners to return a command")
 	}
 
 	// Execute the command
 	msg := cmd()
 
 	// Check that it returns the expected message type
-	reposMsg, ok := msg.(reposLoadedMsg)
+	ownersMsg, ok := msg.(ownersLoadedMsg)
 	if !ok {
-		t.Fatal("Expected reposLoadedMsg")
+		t.Fatal("Expected ownersLoadedMsg")
 	}
 
 	// With real API, we might get an error if token is invalid
-	// or we might get repositories if token is valid
-	if reposMsg.err != nil {
-		t.Logf("API call failed (this might be expected): %v", reposMsg.err)
+	// or we might get owners if token is valid
+	if ownersMsg.err != nil {
+		t.Logf("API call failed (this might be expected): %v", ownersMsg.err)
 		// Don't fail the test for API errors
 		return
 	}
 
-	if len(reposMsg.repos) == 0 {
-		t.Log("No repositories found - this might be normal")
-	} else {
-		t.Logf("Found %d repositories", len(reposMsg.repos))
+	t.Logf("Found user %s and %d organizations", ownersMsg.user, len(ownersMsg.orgs))
+}
+
+// loadRepos delivers repositories the way the loader does: the owners first,
+// then one page per owner
+func loadRepos(t *testing.T, app *AppModel, repos ...string) *AppModel {
+	t.Helper()
+	byOwner := make(map[string][]string)
+	var owners []string
+	for _, repo := range repos {
+		owner, _, _ := strings.Cut(repo, "/")
+		if _, seen := byOwner[owner]; !seen {
+			owners = append(owners, owner)
+		}
+		byOwner[owner] = append(byOwner[owner], repo)
 	}
+
+	req := app.requests.current(OwnerSelection).id
+	model, _ := app.Update(ownersLoadedMsg{req: req, user: owners[0], orgs: owners[1:]})
+	app = asApp(t, model)
+	for _, owner := range owners {
+		model, _ = app.Update(reposLoadedMsg{req: req, pager: app.ownerPagers[owner], owner: owner, repos: byOwner[owner]})
+		app = asApp(t, model)
+	}
+	return app
 }
 
 func TestPaginationHelpers(t *testing.T) {
 	cfg := &config.Config{}
-	app := NewApp(cfg)
+	app := NewApp(cfg, &fakeGitHub{})
 
 	// Test with no organizations
 	if app.getPageInfo() != "No organizations found" {
@@ -132,7 +142,7 @@ func TestPaginationHelpers(t *testing.T) {
 
 func TestGroupRepositories(t *testing.T) {
 	cfg := &config.Config{}
-	app := NewApp(cfg)
+	app := NewApp(cfg, &fakeGitHub{})
 
 	// Test grouping repositories
 	repos := []string{
@@ -171,3 +181,583 @@ func TestGroupRepositories(t *testing.T) {
 		t.Error("Expected org1/repo2 in org1 group")
 	}
 }
+
+// asApp unwraps the model returned by Update, which may be a value or a pointer
+func asApp(t *testing.T, model tea.Model) *AppModel {
+	t.Helper()
+	switch app := model.(type) {
+	case AppModel:
+		return &app
+	case *AppModel:
+		return app
+	}
+	t.Fatalf("Unexpected model type %T", model)
+	return nil
+}
+
+// fakeGitHub stands in for the API. Listings are empty, checks come from the
+// checks map, and methods it doesn't override panic through the nil interface.
+type fakeGitHub struct {
+	api.GitHub
+	checks   map[string][]*models.Check // by head SHA
+	offline  bool
+	comments []string // bodies of the comments created
+	labels   []string // labels of every PR
+	labelErr error    // fails label removals, after additions went through
+}
+
+func (f *fakeGitHub) UpdateField(ctx context.Context, owner, repo string, number int, field string, add, remove []string) error {
+	f.labels = append(f.labels, add...)
+	if len(remove) > 0 && f.labelErr != nil {
+		return f.labelErr
+	}
+	for _, label := range remove {
+		f.labels = slices.DeleteFunc(f.labels, func(l string) bool { return l == label })
+	}
+	return nil
+}
+
+func (f *fakeGitHub) GetPullRequest(ctx context.Context, owner, repo string, number int) (*github.PullRequest, error) {
+	pr := &github.PullRequest{Number: github.Int(number)}
+	for _, label := range f.labels {
+		pr.Labels = append(pr.Labels, &github.Label{Name: github.String(label)})
+	}
+	return pr, nil
+}
+
+func (f *fakeGitHub) Offline() bool {
+	return f.offline
+}
+
+func (f *fakeGitHub) CreateComment(ctx context.Context, owner, repo string, number int, body string) error {
+	f.comments = append(f.comments, body)
+	return nil
+}
+
+func (f *fakeGitHub) OwnerRepositories(owner string, isUser bool) *api.Pager[string] {
+	return api.NewPager(func(ctx context.Context, page int) ([]string, int, error) {
+		return nil, 0, nil
+	})
+}
+
+func (f *fakeGitHub) PullRequests(owner, repo, state string) *api.Pager[*models.PullRequest] {
+	return api.NewPager(func(ctx context.Context, page int) ([]*models.PullRequest, int, error) {
+		return nil, 0, nil
+	})
+}
+
+func (f *fakeGitHub) GetChecks(ctx context.Context, owner, repo, ref string) ([]*models.Check, error) {
+	return f.checks[ref], nil
+}
+
+func TestStartInRepository(t *testing.T) {
+	cfg := &config.Config{}
+	app := NewApp(cfg, &fakeGitHub{})
+
+	app.StartInRepository("org1/repo2")
+
+	if app.currentView != PRList {
+		t.Fatal("Expected to start in the PR list")
+	}
+	if app.selectedOwner != "org1" || app.selectedRepo != "org1/repo2" {
+		t.Errorf("Unexpected selection %s %s", app.selectedOwner, app.selectedRepo)
+	}
+
+	// Repositories arriving later populate the levels beneath the PR list
+	app = loadRepos(t, app, "org1/repo1", "org1/repo2", "org2/repo1")
+
+	model, _ := app.handleBackKey()
+	app = asApp(t, model)
+	if app.currentView != RepoSelection {
+		t.Fatal("Expected back to go to repository selection")
+	}
+	if repoList, ok := app.views[RepoSelection].(*views.RepoListModel); ok {
+		if repoList.GetSelectedRepo() != "org1/repo2" {
+			t.Errorf("Expected cursor on org1/repo2, got %s", repoList.GetSelectedRepo())
+		}
+	}
+
+	model, _ = app.handleBackKey()
+	app = asApp(t, model)
+	if app.currentView != OwnerSelection {
+		t.Fatal("Expected back to go to owner selection")
+	}
+
+	model, _ = app.handleBackKey()
+	app = asApp(t, model)
+	if app.currentView != OwnerSelection {
+		t.Error("Expected back at the top level to be a no-op")
+	}
+}
+
+func TestStartInOwner(t *testing.T) {
+	app := NewApp(&config.Config{}, &fakeGitHub{})
+	app.StartInOwner("org2")
+
+	app = loadRepos(t, app, "org1/repo1", "org2/repo1", "org2/repo2")
+
+	if app.currentView != RepoSelection {
+		t.Fatal("Expected to start in repository selection")
+	}
+	if app.loading {
+		t.Error("Expected loading to finish once repositories arrive")
+	}
+	if repoList, ok := app.views[RepoSelection].(*views.RepoListModel); ok {
+		if repoList.GetOwner() != "org2" || len(repoList.GetVisibleRepos()) != 2 {
+			t.Errorf("Expected org2's 2 repos, got %s %v", repoList.GetOwner(), repoList.GetVisibleRepos())
+		}
+	}
+}
+
+func TestFieldUpdateRollsBackOnError(t *testing.T) {
+	app := NewApp(&config.Config{}, &fakeGitHub{})
+	pr := &models.PullRequest{Number: 4, RepoName: "org1/repo1", Labels: []string{"bug"}}
+
+	// Applying a selection updates the PR before GitHub answers
+	model, cmd := app.Update(views.PickerApplyMsg{PR: pr, Field: models.EditLabels, Before: []string{"bug"}, After: []string{"bug", "deps"}})
+	app = asApp(t, model)
+	if cmd == nil {
+		t.Fatal("Expected the change to be sent")
+	}
+	if len(pr.Labels) != 2 {
+		t.Fatalf("Expected the optimistic update, got %v", pr.Labels)
+	}
+
+	model, _ = app.Update(fieldUpdatedMsg{pr: pr, field: models.EditLabels, before: []string{"bug"}, after: pr.Labels, err: errors.New("forbidden")})
+	app = asApp(t, model)
+	if len(pr.Labels) != 1 || pr.Labels[0] != "bug" {
+		t.Errorf("Expected labels rolled back, got %v", pr.Labels)
+	}
+	if app.error == "" {
+		t.Error("Expected the failure to be reported")
+	}
+}
+
+func TestFieldUpdatePartialFailure(t *testing.T) {
+	fake := &fakeGitHub{labels: []string{"bug"}, labelErr: errors.New("forbidden")}
+	app := NewApp(&config.Config{}, fake)
+	pr := &models.PullRequest{Number: 4, RepoName: "org1/repo1", Labels: []string{"bug"}}
+
+	// Adding "deps" goes through, removing "bug" doesn't: the PR shows what
+	// GitHub ended up with rather than the labels from before
+	model, cmd := app.Update(views.PickerApplyMsg{PR: pr, Field: models.EditLabels, Before: []string{"bug"}, After: []string{"deps"}})
+	app = asApp(t, model)
+	model, _ = app.Update(cmd())
+	app = asApp(t, model)
+	if !slices.Equal(pr.Labels, []string{"bug", "deps"}) {
+		t.Errorf("Expected the labels GitHub has, got %v", pr.Labels)
+	}
+	if app.error == "" {
+		t.Error("Expected the failure to be reported")
+	}
+
+	// A failure doesn't undo an edit made while it was in flight
+	pr.Labels = []string{"docs"}
+	model, _ = app.Update(fieldUpdatedMsg{pr: pr, field: models.EditLabels, before: []string{"bug"}, after: []string{"bug", "deps"}, err: errors.New("forbidden")})
+	app = asApp(t, model)
+	if !slices.Equal(pr.Labels, []string{"docs"}) {
+		t.Errorf("Expected the later edit kept, got %v", pr.Labels)
+	}
+}
+
+func TestBulkJobRunsOnePRAtATime(t *testing.T) {
+	app := NewApp(&config.Config{}, &fakeGitHub{})
+	prs := []*models.PullRequest{
+		{Number: 1, RepoName: "org1/repo1"},
+		{Number: 2, RepoName: "org1/repo1"},
+	}
+	bulk := app.views[BulkView].(*views.BulkModel)
+	bulk.Start(prs)
+	bulk.Update(tea.KeyMsg{Type: tea.KeyDown}) // merge
+	bulk.Update(tea.KeyMsg{Type: tea.KeyDown}) // close
+	bulk.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	bulk.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+
+	model, cmd := app.Update(views.BulkRunMsg{Action: models.BulkClose, PRs: prs})
+	app = asApp(t, model)
+	if cmd == nil || app.bulk == nil {
+		t.Fatal("Expected the first step to start")
+	}
+	job := app.bulk.id
+
+	// A result from an older job is ignored
+	model, _ = app.Update(bulkStepDoneMsg{job: job - 1, index: 0})
+	app = asApp(t, model)
+	if prs[0].State == "closed" {
+		t.Fatal("Expected a stale result to be dropped")
+	}
+
+	model, cmd = app.Update(bulkStepDoneMsg{job: job, index: 0})
+	app = asApp(t, model)
+	if prs[0].State != "closed" || cmd == nil {
+		t.Fatal("Expected #1 closed and #2 started")
+	}
+
+	model, _ = app.Update(bulkStepDoneMsg{job: job, index: 1, err: errors.New("forbidden")})
+	app = asApp(t, model)
+	if prs[1].State == "closed" {
+		t.Error("Expected a failed PR to keep its state")
+	}
+	if app.bulk != nil || !bulk.IsFinished() {
+		t.Error("Expected the job to finish")
+	}
+}
+
+func TestDraftToggleRollsBackOnError(t *testing.T) {
+	app := NewApp(&config.Config{}, &fakeGitHub{})
+	pr := &models.PullRequest{Number: 7, RepoName: "org1/repo1", State: "open", IsDraft: true}
+	prList := app.views[PRList].(*views.PRListModel)
+	prList.SetData("org1/repo1", []*models.PullRequest{pr})
+	app.currentView = PRList
+
+	model, cmd := app.handleDraftKey()
+	app = asApp(t, model)
+	if cmd == nil || pr.IsDraft {
+		t.Fatal("Expected the PR marked ready before GitHub answers")
+	}
+
+	model, _ = app.Update(lifecycleDoneMsg{pr: pr, prevDraft: true, prevState: "open", err: errors.New("forbidden")})
+	app = asApp(t, model)
+	if !pr.IsDraft || app.error == "" {
+		t.Error("Expected the draft status restored and the failure reported")
+	}
+}
+
+func TestCloseKeyReopensClosedPR(t *testing.T) {
+	app := NewApp(&config.Config{}, &fakeGitHub{})
+	pr := &models.PullRequest{Number: 8, RepoName: "org1/repo1", State: "closed"}
+	prList := app.views[PRList].(*views.PRListModel)
+	prList.SetData("org1/repo1", []*models.PullRequest{pr})
+	app.currentView = PRList
+
+	model, cmd := app.handleCloseKey()
+	app = asApp(t, model)
+	if cmd == nil || pr.State != "open" {
+		t.Fatal("Expected the PR reopened before GitHub answers")
+	}
+
+	model, _ = app.Update(lifecycleDoneMsg{pr: pr, notice: "Reopened #8", prevState: "closed"})
+	app = asApp(t, model)
+	if pr.State != "open" || app.notice != "Reopened #8" {
+		t.Errorf("Expected the reopen confirmed, got %s %q", pr.State, app.notice)
+	}
+
+	// Open PRs go to the comment box first
+	model, _ = app.handleCloseKey()
+	app = asApp(t, model)
+	if app.currentView != ComposeView {
+		t.Error("Expected closing to open the comment box")
+	}
+}
+
+func TestPRListPagesLazily(t *testing.T) {
+	app := NewApp(&config.Config{}, &fakeGitHub{})
+	app.StartInRepository("org1/repo1")
+	req := app.requests.current(PRList).id
+
+	page := func(from, count int) []*models.PullRequest {
+		var prs []*models.PullRequest
+		for i := 0; i < count; i++ {
+			prs = append(prs, &models.PullRequest{Number: from + i, RepoName: "org1/repo1"})
+		}
+		return prs
+	}
+
+	// A first page that fits on screen means the user is already near the end
+	model, cmd := app.Update(prsLoadedMsg{req: req, first: true, more: true, prs: page(1, 5)})
+	app = asApp(t, model)
+	if !app.prLoadingMore || cmd == nil {
+		t.Fatal("Expected the second page to be requested")
+	}
+
+	// Pages from an earlier request are dropped
+	model, _ = app.Update(prsLoadedMsg{req: req - 1, prs: page(100, 5)})
+	app = asApp(t, model)
+	prList := app.views[PRList].(*views.PRListModel)
+	if prList.GetPRCount() != 5 {
+		t.Fatalf("Expected a stale page to be dropped, got %d PRs", prList.GetPRCount())
+	}
+
+	model, _ = app.Update(prsLoadedMsg{req: req, prs: page(6, 20)})
+	app = asApp(t, model)
+	if prList.GetPRCount() != 25 || prList.HasMore() || app.prLoadingMore {
+		t.Errorf("Expected the last page appended, got %d PRs", prList.GetPRCount())
+	}
+}
+
+func TestStateKeyCyclesPRList(t *testing.T) {
+	app := NewApp(&config.Config{}, &fakeGitHub{})
+	app.StartInRepository("org1/repo1")
+
+	model, cmd := app.handleStateKey()
+	app = asApp(t, model)
+	prList := app.views[PRList].(*views.PRListModel)
+	if prList.GetState() != models.PRStateClosed || cmd == nil || !app.loading {
+		t.Errorf("Expected closed PRs to be loading, got %s", prList.GetState())
+	}
+	if prList.GetPageInfo() != "No closed pull requests found" {
+		t.Errorf("Unexpected page info %q", prList.GetPageInfo())
+	}
+}
+
+func TestStateKeyTogglesSideBySideInDiff(t *testing.T) {
+	app := NewApp(&config.Config{}, &fakeGitHub{})
+	app.StartInRepository("org1/repo1")
+	model, _ := app.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	app = asApp(t, model)
+	pr := &models.PullRequest{Number: 1, RepoName: "org1/repo1"}
+	diff := app.views[DiffView].(*views.DiffModel)
+	diff.SetFiles(pr, []*models.FileChange{{Filename: "main.go", Patch: "@@ -1 +1 @@\n-a\n+b"}}, nil, 0)
+	app.pushView(DiffView)
+
+	model, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	app = asApp(t, model)
+	if !diff.IsSideBySide() {
+		t.Error("Expected s to turn on side-by-side in the diff view")
+	}
+	if prList := app.views[PRList].(*views.PRListModel); prList.GetState() != models.PRStateOpen {
+		t.Errorf("Expected the PR list to stay on open PRs, got %s", prList.GetState())
+	}
+}
+
+func TestRepositoriesStreamInPerOwner(t *testing.T) {
+	app := NewApp(&config.Config{}, &fakeGitHub{})
+
+	// Owners are listed, and navigable, before any of their repositories arrive
+	req := app.requests.current(OwnerSelection).id
+	model, cmd := app.Update(ownersLoadedMsg{req: req, user: "me", orgs: []string{"org1", "org2", "org3", "org4", "org5"}})
+	app = asApp(t, model)
+	ownerList := app.views[OwnerSelection].(*views.OwnerListModel)
+	if app.loading || cmd == nil || len(ownerList.GetVisibleOwners()) != 6 {
+		t.Fatal("Expected the owners listed with their repositories loading")
+	}
+	if app.ownerLoads != 4 || len(app.ownerQueue) != 2 {
+		t.Errorf("Expected 4 owners loading and 2 queued, got %d and %d", app.ownerLoads, len(app.ownerQueue))
+	}
+
+	// The cursor stays on the owner the user moved to while pages arrive
+	ownerList.SelectOwner("org2")
+	pager := app.ownerPagers["org2"]
+	model, cmd = app.Update(reposLoadedMsg{req: req, pager: pager, owner: "org2", repos: []string{"org2/repo1"}, more: true})
+	app = asApp(t, model)
+	if cmd == nil || !ownerList.IsOwnerLoading("org2") {
+		t.Fatal("Expected org2's next page to be requested")
+	}
+	model, _ = app.Update(reposLoadedMsg{req: req, pager: pager, owner: "org2", repos: []string{"org2/repo2"}})
+	app = asApp(t, model)
+	if ownerList.GetSelectedOwner() != "org2" || ownerList.GetRepoCount("org2") != 2 || ownerList.IsOwnerLoading("org2") {
+		t.Errorf("Expected org2 loaded with 2 repos, got %s", ownerList.GetSelectedOwner())
+	}
+	if app.ownerLoads != 4 || len(app.ownerQueue) != 1 {
+		t.Errorf("Expected a queued owner to start, got %d loading and %d queued", app.ownerLoads, len(app.ownerQueue))
+	}
+
+	// An owner that can't be listed stops loading without failing the rest
+	model, _ = app.Update(reposLoadedMsg{req: req, pager: app.ownerPagers["org1"], owner: "org1", err: errors.New("SSO required")})
+	app = asApp(t, model)
+	if ownerList.IsOwnerLoading("org1") || app.error != "" {
+		t.Error("Expected org1 marked as failed without an error banner")
+	}
+}
+
+func TestLeavingAViewCancelsItsLoads(t *testing.T) {
+	app := NewApp(&config.Config{}, &fakeGitHub{})
+	app.StartInRepository("org1/repo1")
+	req := app.requests.current(PRList)
+
+	model, _ := app.handleBackKey()
+	app = asApp(t, model)
+	if req.ctx.Err() == nil {
+		t.Fatal("Expected the PR list's loads to be cancelled on leaving it")
+	}
+
+	// A page for the repository the user left doesn't land in the next one
+	app.StartInRepository("org1/repo2")
+	app.reloadPullRequests()
+	model, _ = app.Update(prsLoadedMsg{req: req.id, first: true, prs: []*models.PullRequest{{Number: 1, RepoName: "org1/repo1"}}})
+	app = asApp(t, model)
+	if prList := app.views[PRList].(*views.PRListModel); prList.GetPRCount() != 0 || !app.loading {
+		t.Errorf("Expected the stale page to be dropped, got %d PRs", prList.GetPRCount())
+	}
+}
+
+func TestPRDetailLoadsChecksFromClient(t *testing.T) {
+	fake := &fakeGitHub{checks: map[string][]*models.Check{
+		"abc123": {{Name: "build", Status: "completed", Conclusion: "success"}},
+	}}
+	app := NewApp(&config.Config{}, fake)
+	app.StartInRepository("org1/repo1")
+	pr := &models.PullRequest{Number: 1, RepoName: "org1/repo1", HeadSHA: "abc123"}
+
+	msg := loadChecks(app.requests.begin(PRDetail), app.client, pr)()
+	checks, ok := msg.(checksLoadedMsg)
+	if !ok || checks.err != nil || len(checks.checks) != 1 || checks.checks[0].Name != "build" {
+		t.Fatalf("Expected the fake's checks, got %#v", msg)
+	}
+}
+
+func TestOfflineWritesAreQueuedUntilConfirmed(t *testing.T) {
+	fake := &fakeGitHub{offline: true}
+	cfg := &config.Config{State: config.StateConfig{Dir: t.TempDir()}}
+	app := NewApp(cfg, fake)
+	app.StartInRepository("acme/api")
+	model, _ := app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app = asApp(t, model)
+	pr := &models.PullRequest{Number: 142, RepoName: "acme/api"}
+
+	model, cmd := app.Update(views.ComposeSubmitMsg{Draft: &models.Draft{Kind: models.DraftComment, PR: pr, Body: "LGTM"}})
+	app = asApp(t, model)
+	if cmd != nil || app.outbox.len() != 1 || len(fake.comments) != 0 {
+		t.Fatalf("Expected the comment queued rather than sent, got %d queued", app.outbox.len())
+	}
+	if !strings.Contains(app.notice, "queued comment on acme/api#142") {
+		t.Errorf("Unexpected notice %q", app.notice)
+	}
+	if !strings.Contains(app.View(), "OFFLINE (1 queued)") {
+		t.Error("Expected the title to show the app is offline")
+	}
+
+	// The queue survives a restart
+	if restarted := NewApp(cfg, fake); restarted.outbox.len() != 1 {
+		t.Fatalf("Expected the queued comment to be saved, got %d", restarted.outbox.len())
+	}
+
+	// Nothing is sent when GitHub comes back until the user agrees
+	fake.offline = false
+	model, _ = app.Update(tickMsg(time.Now()))
+	app = asApp(t, model)
+	if !app.confirmOutbox || !strings.Contains(app.View(), "send 1 queued changes") {
+		t.Fatal("Expected to be asked to send the queued comment")
+	}
+	model, cmd = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	app = asApp(t, model)
+	if cmd == nil {
+		t.Fatal("Expected the queued comment to be sent")
+	}
+	model, _ = app.Update(cmd())
+	app = asApp(t, model)
+	if len(fake.comments) != 1 || fake.comments[0] != "LGTM" || app.outbox.len() != 0 {
+		t.Errorf("Expected the comment sent and the outbox emptied, got %v", fake.comments)
+	}
+	if restarted := NewApp(cfg, fake); restarted.outbox.len() != 0 {
+		t.Error("Expected the sent comment to be dropped from the saved outbox")
+	}
+}
+
+func TestOutboxOnlySendsChangesForTheHost(t *testing.T) {
+	fake := &fakeGitHub{offline: true}
+	dir := t.TempDir()
+	enterprise := &config.Config{GitHub: config.GitHubConfig{Host: "ghe.example.com"}, State: config.StateConfig{Dir: dir}}
+	app := NewApp(enterprise, fake)
+	pr := &models.PullRequest{Number: 7, RepoName: "corp/tools"}
+	app.Update(views.ComposeSubmitMsg{Draft: &models.Draft{Kind: models.DraftComment, PR: pr, Body: "from GHE"}})
+
+	// A session on github.com neither offers nor sends the enterprise change
+	fake.offline = false
+	cfg := &config.Config{State: config.StateConfig{Dir: dir}}
+	app = NewApp(cfg, fake)
+	if app.outbox.len() != 0 || len(app.outbox.pending()) != 0 {
+		t.Fatalf("Expected no changes queued for github.com, got %d", app.outbox.len())
+	}
+	model, _ := app.Update(tickMsg(time.Now()))
+	if asApp(t, model).confirmOutbox {
+		t.Error("Expected no prompt to send another host's changes")
+	}
+
+	// The enterprise session still has it, and sends it
+	app = NewApp(enterprise, fake)
+	if app.outbox.len() != 1 {
+		t.Fatalf("Expected the change still queued for ghe.example.com, got %d", app.outbox.len())
+	}
+	model, cmd := app.handleOutboxKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	app = asApp(t, model)
+	model, _ = app.Update(cmd())
+	if len(fake.comments) != 1 || asApp(t, model).outbox.len() != 0 {
+		t.Errorf("Expected the enterprise change sent, got %v", fake.comments)
+	}
+}
+
+func TestPRListAutoRefresh(t *testing.T) {
+	cfg := &config.Config{UI: config.UIConfig{AutoRefresh: time.Minute}}
+	app := NewApp(cfg, &fakeGitHub{})
+	app.StartInRepository("org1/repo1")
+	prs := []*models.PullRequest{{Number: 1, RepoName: "org1/repo1"}, {Number: 2, RepoName: "org1/repo1"}}
+	model, _ := app.Update(prsLoadedMsg{req: app.requests.current(PRList).id, first: true, prs: prs})
+	app = asApp(t, model)
+
+	model, _ = app.Update(tickMsg(time.Now().Add(30 * time.Second)))
+	app = asApp(t, model)
+	if app.prRefreshing {
+		t.Fatal("Expected no refresh before the interval has passed")
+	}
+
+	// Rows aren't reshuffled under a range selection
+	prList := app.views[PRList].(*views.PRListModel)
+	prList.ToggleRange()
+	model, _ = app.Update(tickMsg(time.Now().Add(2 * time.Minute)))
+	app = asApp(t, model)
+	if app.prRefreshing {
+		t.Fatal("Expected the refresh to pause during a range selection")
+	}
+	prList.ToggleRange()
+
+	model, _ = app.Update(tickMsg(time.Now().Add(2 * time.Minute)))
+	app = asApp(t, model)
+	if !app.prRefreshing || app.loading {
+		t.Fatal("Expected a background refresh once the interval has passed")
+	}
+	// The first page of a longer listing: #2 may just have moved further down
+	fresh := []*models.PullRequest{{Number: 3, RepoName: "org1/repo1"}, {Number: 1, RepoName: "org1/repo1"}}
+	model, _ = app.Update(prsLoadedMsg{req: app.requests.current(PRList).id, first: true, more: true, prs: fresh})
+	app = asApp(t, model)
+	if app.prRefreshing || prList.GetPRCount() != 3 || prList.GetChange(fresh[0]) != "new" {
+		t.Errorf("Expected #3 merged into the list and marked new, got %d PRs", prList.GetPRCount())
+	}
+	if selected := prList.GetSelectedPR(); selected == nil || selected.Number != 1 {
+		t.Errorf("Expected the cursor to stay on #1, got %v", selected)
+	}
+}
+
+func TestMarkReadAndUnread(t *testing.T) {
+	cfg := &config.Config{State: config.StateConfig{Dir: t.TempDir()}}
+	app := NewApp(cfg, &fakeGitHub{})
+	app.StartInRepository("org1/repo1")
+	prs := []*models.PullRequest{{Number: 1, RepoName: "org1/repo1"}, {Number: 2, RepoName: "org1/repo1"}}
+	model, _ := app.Update(prsLoadedMsg{req: app.requests.current(PRList).id, first: true, prs: prs})
+	app = asApp(t, model)
+	prList := app.views[PRList].(*views.PRListModel)
+	if prList.UnreadCount() != 2 {
+		t.Fatalf("Expected both PRs unread at first, got %d", prList.UnreadCount())
+	}
+
+	model, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	app = asApp(t, model)
+	if prList.GetUnread(prs[0]).Unread || app.notice != "Marked #1 as read" {
+		t.Fatalf("Expected #1 marked read, got notice %q", app.notice)
+	}
+	if restarted := NewApp(cfg, &fakeGitHub{}); restarted.seen.get(prs[0]) == nil {
+		t.Error("Expected #1 to be remembered as read after a restart")
+	}
+	other := &config.Config{GitHub: config.GitHubConfig{Host: "ghe.example.com"}, State: cfg.State}
+	if restarted := NewApp(other, &fakeGitHub{}); restarted.seen.get(prs[0]) != nil {
+		t.Error("Expected org1/repo1#1 on another host to stay unread")
+	}
+
+	model, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	app = asApp(t, model)
+	if !prList.GetUnread(prs[0]).Unread {
+		t.Error("Expected pressing u again to mark #1 unread")
+	}
+
+	model, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("U")})
+	app = asApp(t, model)
+	if prList.UnreadCount() != 0 || app.notice != "Marked 2 pull requests as read" {
+		t.Errorf("Expected every PR marked read, got %d unread", prList.UnreadCount())
+	}
+
+	// New commits since make a PR unread again
+	prs[1].HeadSHA = "new"
+	if got := prList.GetUnread(prs[1]); !got.Unread || !got.NewCommits {
+		t.Errorf("Expected #2 unread with new commits, got %+v", got)
+	}
+}
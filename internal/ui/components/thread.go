@@ -0,0 +1,130 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/will-wright-eng/gh-nav/internal/models"
+	"github.com/will-wright-eng/gh-nav/internal/output"
+	"github.com/will-wright-eng/gh-nav/internal/ui/theme"
+)
+
+// ThreadOptions controls how a thread is rendered
+type ThreadOptions struct {
+	Selected bool      // draw the selection marker
+	Expanded bool      // show all comments; collapsed threads render as one line
+	Width    int       // available width, used to wrap comment bodies
+	Now      time.Time // reference time for relative timestamps
+}
+
+// ThreadLocation describes where a thread is anchored: file and line range for
+// review threads, "Conversation" for the top-level thread
+func ThreadLocation(thread *models.Thread) string {
+	if thread.IsConversation() {
+		return "Conversation"
+	}
+	switch {
+	case thread.Line == 0:
+		return thread.FilePath
+	case thread.StartLine != 0 && thread.StartLine != thread.Line:
+		return fmt.Sprintf("%s:%d-%d", thread.FilePath, thread.StartLine, thread.Line)
+	}
+	return fmt.Sprintf("%s:%d", thread.FilePath, thread.Line)
+}
+
+// RenderThread renders a conversation thread: a header line with its location and
+// state, followed by the comments when expanded
+func RenderThread(t *theme.Theme, thread *models.Thread, opts ThreadOptions) string {
+	cursor := " "
+	headerStyle := t.Styles.Unselected.Copy()
+	if opts.Selected {
+		cursor = ">"
+		headerStyle = t.Styles.Selected.Copy()
+	}
+
+	marker := t.Icons.Collapsed
+	if opts.Expanded {
+		marker = t.Icons.Expanded
+	}
+
+	var state []string
+	if thread.Resolved {
+		state = append(state, t.Icons.Resolved+" resolved")
+	}
+	if thread.Outdated {
+		state = append(state, "outdated")
+	}
+	state = append(state, pluralize(len(thread.Comments), "comment"))
+	if last := lastComment(thread); last != nil {
+		state = append(state, fmt.Sprintf("last by %s %s", last.Author, output.TimeAgo(last.CreatedAt, opts.Now)))
+	}
+
+	header := headerStyle.Bold(!thread.Resolved).Render(fmt.Sprintf("%s %s %s %s", cursor, marker, t.Icons.Thread, ThreadLocation(thread)))
+	header += " " + lipgloss.NewStyle().Foreground(lipgloss.Color(t.Colors.Muted)).Render(strings.Join(state, " • "))
+	if !opts.Expanded {
+		return header + "\n"
+	}
+
+	var b strings.Builder
+	b.WriteString(header + "\n")
+	for _, comment := range thread.Comments {
+		b.WriteString(renderComment(t, comment, opts))
+	}
+	return b.String()
+}
+
+// renderComment renders one comment with an author line and an indented body
+func renderComment(t *theme.Theme, comment *models.Comment, opts ThreadOptions) string {
+	author := lipgloss.NewStyle().Bold(true).Render(comment.Author)
+	meta := output.TimeAgo(comment.CreatedAt, opts.Now)
+	if verdict := reviewVerdict(t, comment.ReviewState); verdict != "" {
+		meta = verdict + " • " + meta
+	}
+	line := fmt.Sprintf("%s %s", author, lipgloss.NewStyle().Foreground(lipgloss.Color(t.Colors.Muted)).Render(meta))
+
+	bodyWidth := opts.Width - 10
+	if bodyWidth < 20 {
+		bodyWidth = 20
+	}
+	body := strings.TrimSpace(strings.ReplaceAll(comment.Body, "\r\n", "\n"))
+	if body == "" {
+		body = lipgloss.NewStyle().Italic(true).Foreground(lipgloss.Color(t.Colors.Muted)).Render("(no comment)")
+	}
+
+	indent := lipgloss.NewStyle().MarginLeft(6)
+	return indent.Render(line) + "\n" + indent.Copy().MarginLeft(8).Width(bodyWidth).Render(body) + "\n\n"
+}
+
+// reviewVerdict renders a review state as colored text
+func reviewVerdict(t *theme.Theme, state string) string {
+	switch state {
+	case "APPROVED":
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(t.Colors.Success)).Render("approved")
+	case "CHANGES_REQUESTED":
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(t.Colors.Error)).Render("requested changes")
+	case "COMMENTED":
+		return "reviewed"
+	}
+	return ""
+}
+
+// lastComment returns the most recent comment of a thread
+func lastComment(thread *models.Thread) *models.Comment {
+	var last *models.Comment
+	for _, comment := range thread.Comments {
+		if last == nil || comment.CreatedAt.After(last.CreatedAt) {
+			last = comment
+		}
+	}
+	return last
+}
+
+// pluralize formats a count with a singular or plural noun
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/will-wright-eng/gh-nav/internal/api"
+	"github.com/will-wright-eng/gh-nav/internal/constants"
+	"github.com/will-wright-eng/gh-nav/internal/models"
+)
+
+// outboxFile is where queued changes are kept, in the cache directory
+const outboxFile = "outbox.json"
+
+// outbox holds the writes made while offline until the user sends them. It is
+// saved after every change, so queued work survives a restart; without a path
+// it only lives in memory.
+//
+// The file is shared by every GitHub host, but only the changes for host are
+// offered and sent; the rest wait for a session on their own host.
+type outbox struct {
+	path    string
+	host    string
+	lastID  int
+	changes []*models.QueuedChange
+}
+
+// loadOutbox reads the outbox saved in dir, if any, for a session on host
+func loadOutbox(dir, host string) (*outbox, error) {
+	o := &outbox{host: stateHost(host)}
+	if dir == "" {
+		return o, nil
+	}
+	o.path = filepath.Join(dir, outboxFile)
+	if err := readState(o.path, &o.changes); err != nil {
+		return o, err
+	}
+	for _, c := range o.changes {
+		o.lastID = max(o.lastID, c.ID)
+	}
+	return o, nil
+}
+
+// len returns the number of changes queued for the host
+func (o *outbox) len() int {
+	return len(o.pending())
+}
+
+// pending returns the changes queued for the host, oldest first
+func (o *outbox) pending() []*models.QueuedChange {
+	var changes []*models.QueuedChange
+	for _, c := range o.changes {
+		if stateHost(c.Host) == o.host {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// add queues a change for the host and saves the outbox
+func (o *outbox) add(change *models.QueuedChange) error {
+	o.lastID++
+	change.ID = o.lastID
+	change.Host = o.host
+	o.changes = append(o.changes, change)
+	return o.save()
+}
+
+// remove drops the changes with the given IDs, once sent, and saves the outbox
+func (o *outbox) remove(ids []int) error {
+	sent := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		sent[id] = true
+	}
+	kept := o.changes[:0]
+	for _, c := range o.changes {
+		if !sent[c.ID] {
+			kept = append(kept, c)
+		}
+	}
+	o.changes = kept
+	return o.save()
+}
+
+// save writes the outbox through a temporary file, removing it once empty
+func (o *outbox) save() error {
+	if o.path == "" {
+		return nil
+	}
+	if len(o.changes) == 0 {
+		if err := os.Remove(o.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	return writeState(o.path, o.changes)
+}
+
+// sendOutbox sends queued changes to GitHub in the order they were made. A
+// change GitHub refuses stays queued and the rest are still sent; losing the
+// network again stops the run.
+func sendOutbox(client api.GitHub, changes []*models.QueuedChange) tea.Cmd {
+	return func() tea.Msg {
+		msg := outboxSentMsg{total: len(changes)}
+		for _, change := range changes {
+			err := sendChange(client, change)
+			if err == nil {
+				msg.sent = append(msg.sent, change.ID)
+				continue
+			}
+			if msg.err == nil {
+				msg.err = fmt.Errorf("%s: %w", change.Describe(), err)
+			}
+			if api.IsOffline(err) {
+				break
+			}
+		}
+		return msg
+	}
+}
+
+// sendChange sends one queued change
+func sendChange(client api.GitHub, change *models.QueuedChange) error {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.DefaultTimeout)
+	defer cancel()
+
+	owner, repo, _ := strings.Cut(change.Repo, "/")
+	switch change.Kind {
+	case models.QueuedField:
+		return client.UpdateField(ctx, owner, repo, change.Number, change.Field, change.Add, change.Remove)
+	case models.DraftReply:
+		return client.ReplyToReviewComment(ctx, owner, repo, change.Number, change.ReplyTo, change.Body)
+	case models.DraftReview:
+		return client.SubmitReview(ctx, owner, repo, change.Number, change.Event, change.Body)
+	}
+	return client.CreateComment(ctx, owner, repo, change.Number, change.Body)
+}
+
+// probeGitHub makes a request that tells the client whether GitHub is
+// reachable again; the result shows in client.Offline()
+func probeGitHub(client api.GitHub) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), constants.DefaultTimeout)
+		defer cancel()
+		_, _ = client.GetRateLimits(ctx)
+		return nil
+	}
+}
@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"context"
+	"time"
+
+	"github.com/will-wright-eng/gh-nav/internal/api"
+)
+
+// request is a view's in-flight loads: each load runs under the request's
+// context and tags its result with the request ID. Responses answered from
+// the cache while offline report their age to age.
+type request struct {
+	id     int
+	ctx    context.Context
+	cancel context.CancelFunc
+	age    *api.Staleness
+}
+
+// requests tracks the current request of each view. Leaving a view, or
+// starting to load something else into it, cancels its request, so results
+// that arrive afterwards carry an ID that is no longer current and are dropped.
+type requests struct {
+	lastID int
+	active map[ViewMode]*request
+}
+
+// newRequests creates an empty request tracker
+func newRequests() *requests {
+	return &requests{active: make(map[ViewMode]*request)}
+}
+
+// begin cancels the view's current request and starts a new one
+func (r *requests) begin(view ViewMode) *request {
+	r.cancel(view)
+	r.lastID++
+	ctx, age := api.WithStaleness(context.Background())
+	ctx, cancel := context.WithCancel(ctx)
+	req := &request{id: r.lastID, ctx: ctx, cancel: cancel, age: age}
+	r.active[view] = req
+	return req
+}
+
+// current returns the view's request, starting one if there is none; further
+// loads for data already on screen (e.g. the next page) join it
+func (r *requests) current(view ViewMode) *request {
+	if req, ok := r.active[view]; ok {
+		return req
+	}
+	return r.begin(view)
+}
+
+// isCurrent reports whether a result tagged with id belongs to the view's current request
+func (r *requests) isCurrent(view ViewMode, id int) bool {
+	req, ok := r.active[view]
+	return ok && req.id == id
+}
+
+// cachedSince returns when the oldest cached response the view's current
+// request was answered with was stored, or the zero time if all of it is fresh
+func (r *requests) cachedSince(view ViewMode) time.Time {
+	if req, ok := r.active[view]; ok {
+		return req.age.Oldest()
+	}
+	return time.Time{}
+}
+
+// cancel stops the view's loads; their results will be dropped
+func (r *requests) cancel(view ViewMode) {
+	if req, ok := r.active[view]; ok {
+		req.cancel()
+		delete(r.active, view)
+	}
+}
+
+// cancelAll stops every view's loads
+func (r *requests) cancelAll() {
+	for view := range r.active {
+		r.cancel(view)
+	}
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/will-wright-eng/gh-nav/internal/models"
+)
+
+// seenFile is where read pull requests are remembered, in the state directory
+const seenFile = "seen.json"
+
+// seenStore remembers when the user last read each pull request, keyed by
+// host/owner/repo#number as the file is shared by every GitHub host. It is
+// saved after every change; without a path it only lives in memory.
+type seenStore struct {
+	path string
+	host string
+	prs  map[string]*models.Seen
+}
+
+// loadSeen reads the read pull requests saved in dir, if any, for a session on
+// host
+func loadSeen(dir, host string) (*seenStore, error) {
+	s := &seenStore{host: stateHost(host), prs: make(map[string]*models.Seen)}
+	if dir == "" {
+		return s, nil
+	}
+	s.path = filepath.Join(dir, seenFile)
+	if err := readState(s.path, &s.prs); err != nil {
+		return s, err
+	}
+	if s.prs == nil {
+		s.prs = make(map[string]*models.Seen)
+	}
+	return s, nil
+}
+
+// key identifies a PR of the host across repositories
+func (s *seenStore) key(pr *models.PullRequest) string {
+	return fmt.Sprintf("%s/%s#%d", s.host, pr.RepoName, pr.Number)
+}
+
+// get returns when pr was last read, or nil if it never was
+func (s *seenStore) get(pr *models.PullRequest) *models.Seen {
+	return s.prs[s.key(pr)]
+}
+
+// markRead records prs as read at now and saves the store
+func (s *seenStore) markRead(now time.Time, prs ...*models.PullRequest) error {
+	for _, pr := range prs {
+		s.prs[s.key(pr)] = models.MarkSeen(pr, now)
+	}
+	return s.save()
+}
+
+// markUnread forgets that pr was read and saves the store
+func (s *seenStore) markUnread(pr *models.PullRequest) error {
+	delete(s.prs, s.key(pr))
+	return s.save()
+}
+
+// save writes the store, if it has a path
+func (s *seenStore) save() error {
+	if s.path == "" {
+		return nil
+	}
+	return writeState(s.path, s.prs)
+}
@@ -0,0 +1,407 @@
+package ui
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/will-wright-eng/gh-nav/internal/models"
+	"github.com/will-wright-eng/gh-nav/internal/ui/views"
+	"github.com/will-wright-eng/gh-nav/pkg/config"
+)
+
+// Snapshot tests render the app after a script of messages and compare the
+// screen with golden files in testdata/snapshots, once with colors and once
+// without. After an intended change to a view, rewrite the golden files with
+//
+//	go test ./internal/ui -run TestSnapshots -update
+//
+// and review the diff.
+var update = flag.Bool("update", false, "rewrite the golden files of snapshot tests")
+
+// snapshotSizes are the terminal sizes every snapshot is rendered at
+var snapshotSizes = []struct{ width, height int }{
+	{80, 24},
+	{120, 40},
+}
+
+// snapshotNow is the clock views measure ages and durations against
+var snapshotNow = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+// ansiEscape matches the escape sequences lipgloss emits
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*[a-zA-Z]")
+
+// harness drives an AppModel the way Bubble Tea would, except that commands
+// are dropped: tests deliver the messages loaders would send themselves
+type harness struct {
+	t   *testing.T
+	app *AppModel
+}
+
+func newHarness(t *testing.T, width, height int) *harness {
+	t.Helper()
+	h := &harness{t: t, app: NewApp(&config.Config{}, &fakeGitHub{})}
+	h.app.seen.prs = snapshotSeen()
+	for _, view := range h.app.views {
+		if clocked, ok := view.(views.Clocked); ok {
+			clocked.SetClock(func() time.Time { return snapshotNow })
+		}
+	}
+	h.send(tea.WindowSizeMsg{Width: width, Height: height})
+	return h
+}
+
+// send delivers messages to the app
+func (h *harness) send(msgs ...tea.Msg) {
+	h.t.Helper()
+	for _, msg := range msgs {
+		model, _ := h.app.Update(msg)
+		h.app = asApp(h.t, model)
+	}
+}
+
+// keys presses keys, given by name ("enter", "backspace") or as the character typed
+func (h *harness) keys(keys ...string) {
+	h.t.Helper()
+	special := map[string]tea.KeyType{
+		"enter":     tea.KeyEnter,
+		"backspace": tea.KeyBackspace,
+		"esc":       tea.KeyEsc,
+		"tab":       tea.KeyTab,
+		"up":        tea.KeyUp,
+		"down":      tea.KeyDown,
+	}
+	for _, key := range keys {
+		switch keyType, ok := special[key]; {
+		case ok:
+			h.send(tea.KeyMsg{Type: keyType})
+		case key == " ":
+			h.send(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(key)})
+		default:
+			h.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		}
+	}
+}
+
+// req is the ID of the request a view's loaders would answer
+func (h *harness) req(view ViewMode) int {
+	return h.app.requests.current(view).id
+}
+
+// The steps below take the app one screen further each, building on each other
+
+func ownersLoaded(h *harness) {
+	h.app = loadRepos(h.t, h.app, "octocat/dotfiles", "acme/api", "acme/web", "widgets-inc/widgets")
+}
+
+func reposShown(h *harness) {
+	ownersLoaded(h)
+	h.keys("enter") // acme
+}
+
+func prListOpened(h *harness) {
+	reposShown(h)
+	h.keys("enter") // acme/api
+}
+
+// prsDelivered shows the PRs before their CI status and activity arrive
+func prsDelivered(h *harness) {
+	prListOpened(h)
+	h.send(prsLoadedMsg{req: h.req(PRList), first: true, prs: snapshotPRs()})
+}
+
+func prListLoaded(h *harness) {
+	prsDelivered(h)
+	h.send(ciStatusesLoadedMsg{req: h.req(PRList), checks: map[int][]*models.Check{
+		142: snapshotChecks(models.CIStatusFailure),
+		139: snapshotChecks(models.CIStatusSuccess),
+		137: snapshotChecks(models.CIStatusPending),
+	}})
+	h.send(activityLoadedMsg{req: h.req(PRList), activity: map[int]*models.Activity{
+		142: {UnresolvedThreads: 1, Comments: 3},
+		139: {Comments: 0},
+		137: {Comments: 0},
+	}})
+}
+
+// prDetailOpened opens #142 before the list has loaded its checks, so the
+// detail view loads them
+func prDetailOpened(h *harness) {
+	prsDelivered(h)
+	h.keys("enter") // #142
+}
+
+func prDetailLoaded(h *harness) {
+	prDetailOpened(h)
+	h.send(checksLoadedMsg{req: h.req(PRDetail), checks: snapshotChecks(models.CIStatusFailure)})
+}
+
+func logOpened(h *harness) {
+	prDetailLoaded(h)
+	h.keys("j", "enter") // the failing test job
+}
+
+func threadsOpened(h *harness) {
+	prListLoaded(h)
+	h.keys("t")
+}
+
+func filesLoaded(h *harness) {
+	prListLoaded(h)
+	h.keys("f")
+	h.send(filesLoadedMsg{req: h.req(FilesView), files: snapshotFiles(), threads: snapshotThreads()})
+}
+
+func TestSnapshots(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps func(h *harness)
+	}{
+		{"owners-loading", func(h *harness) {}},
+		{"owners-error", func(h *harness) {
+			h.send(ownersLoadedMsg{req: h.req(OwnerSelection), err: errors.New("401 Bad credentials")})
+		}},
+		{"owners", ownersLoaded},
+		{"repos", reposShown},
+		{"pr-list-loading", prListOpened},
+		{"pr-list-error", func(h *harness) {
+			prListOpened(h)
+			h.send(prsLoadedMsg{req: h.req(PRList), first: true, err: errors.New("404 Not Found")})
+		}},
+		{"pr-list", prListLoaded},
+		{"pr-list-refreshed", func(h *harness) {
+			prListLoaded(h)
+			h.app.config.UI.AutoRefresh = time.Minute
+			h.send(tickMsg(time.Now().Add(time.Hour)))
+			h.send(prsLoadedMsg{req: h.req(PRList), first: true, prs: refreshedPRs()})
+		}},
+		{"pr-list-empty", func(h *harness) {
+			prListOpened(h)
+			h.send(prsLoadedMsg{req: h.req(PRList), first: true})
+		}},
+		{"pr-detail-loading", prDetailOpened},
+		{"pr-detail", prDetailLoaded},
+		{"pr-detail-error", func(h *harness) {
+			prDetailOpened(h)
+			h.send(checksLoadedMsg{req: h.req(PRDetail), err: errors.New("502 Bad Gateway")})
+		}},
+		{"log-loading", logOpened},
+		{"log", func(h *harness) {
+			logOpened(h)
+			h.send(jobLogLoadedMsg{req: h.req(LogView), log: snapshotLog()})
+		}},
+		{"log-error", func(h *harness) {
+			logOpened(h)
+			h.send(jobLogLoadedMsg{req: h.req(LogView), err: errors.New("410 Gone")})
+		}},
+		{"threads-loading", threadsOpened},
+		{"threads", func(h *harness) {
+			threadsOpened(h)
+			h.send(threadsLoadedMsg{req: h.req(ThreadsView), threads: snapshotThreads()})
+		}},
+		{"files", filesLoaded},
+		{"diff", func(h *harness) {
+			filesLoaded(h)
+			h.keys("enter")
+		}},
+		{"compose", func(h *harness) {
+			prListLoaded(h)
+			h.keys("C")
+		}},
+		{"merge", func(h *harness) {
+			prListLoaded(h)
+			h.keys("j", "m") // #139
+			h.send(mergeInfoLoadedMsg{req: h.req(MergeView), info: &models.MergeInfo{
+				Title: "Request only the OAuth scopes we use", State: "OPEN",
+				Methods: []string{"merge", "squash", "rebase"}, AutoMergeAllowed: true,
+				Mergeable: "MERGEABLE", MergeStateStatus: "CLEAN", ReviewDecision: "APPROVED", ChecksState: "SUCCESS",
+			}})
+		}},
+		{"picker", func(h *harness) {
+			prListLoaded(h)
+			h.keys("L")
+			h.send(pickerOptionsLoadedMsg{req: h.req(PickerView), options: []string{"bug", "enhancement", "security"}})
+		}},
+		{"bulk", func(h *harness) {
+			prListLoaded(h)
+			h.keys(" ", "j", " ", "x")
+		}},
+	}
+
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	lipgloss.SetHasDarkBackground(true)
+	defer lipgloss.SetColorProfile(profile)
+
+	for _, test := range tests {
+		for _, size := range snapshotSizes {
+			name := fmt.Sprintf("%s-%dx%d", test.name, size.width, size.height)
+			t.Run(name, func(t *testing.T) {
+				h := newHarness(t, size.width, size.height)
+				test.steps(h)
+
+				raw := h.app.View()
+				plain := ansiEscape.ReplaceAllString(raw, "")
+				assertFits(t, plain, size.width)
+				assertGolden(t, name+".ansi", raw)
+				assertGolden(t, name+".txt", plain)
+			})
+		}
+	}
+}
+
+// assertFits fails if a line of screen is wider than the terminal, which would
+// wrap and push the rest of the screen down
+func assertFits(t *testing.T, screen string, width int) {
+	t.Helper()
+	for i, line := range strings.Split(screen, "\n") {
+		if w := lipgloss.Width(line); w > width {
+			t.Errorf("Line %d is %d columns wide, more than the terminal's %d: %q", i+1, w, width, line)
+		}
+	}
+}
+
+// assertGolden compares got with testdata/snapshots/name, or rewrites the file with -update
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", "snapshots", name)
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Missing golden file (run with -update to create it): %v", err)
+	}
+	if string(want) != got {
+		t.Errorf("%s doesn't match the golden file (run with -update to accept):\n--- want\n%s\n--- got\n%s", name, want, got)
+	}
+}
+
+func snapshotPRs() []*models.PullRequest {
+	yes, no := true, false
+	return []*models.PullRequest{
+		{
+			ID: 1142, Number: 142, Title: "Add sliding-window rate limiter", State: models.PRStateOpen, RepoName: "acme/api",
+			Author: "mona", CreatedAt: snapshotNow.Add(-72 * time.Hour), UpdatedAt: snapshotNow.Add(-20 * time.Minute),
+			Labels: []string{"enhancement"}, Assignees: []string{"mona"}, Reviewers: []string{"octocat", "acme/backend"},
+			ReviewStatus: "changes_requested", Mergeable: &no, Comments: 1, Commits: 3, Additions: 9, Deletions: 1,
+			HeadRef: "rate-limiter", HeadRepo: "acme/api", BaseRef: "main", HeadSHA: "a1b2c3d",
+		},
+		{
+			ID: 1139, Number: 139, Title: "Request only the OAuth scopes we use", State: models.PRStateOpen, RepoName: "acme/api",
+			Author: "hubot", CreatedAt: snapshotNow.Add(-120 * time.Hour), UpdatedAt: snapshotNow.Add(-4 * time.Hour),
+			Labels: []string{"security"}, Reviewers: []string{"octocat"},
+			ReviewStatus: "approved", Mergeable: &yes, Commits: 1, Additions: 1, Deletions: 1,
+			HeadRef: "oauth-scopes", HeadRepo: "acme/api", BaseRef: "main", HeadSHA: "b2c3d4e",
+		},
+		{
+			ID: 1137, Number: 137, Title: "WIP: structured error responses", State: models.PRStateOpen, RepoName: "acme/api",
+			Author: "octocat", CreatedAt: snapshotNow.Add(-144 * time.Hour), UpdatedAt: snapshotNow.Add(-24 * time.Hour),
+			ReviewStatus: "pending", IsDraft: true, Commits: 2, Additions: 2,
+			HeadRef: "docs/errors", HeadRepo: "acme/api", BaseRef: "main", HeadSHA: "c3d4e5f",
+		},
+	}
+}
+
+// refreshedPRs is snapshotPRs a while later: #143 was opened and #139 pushed to
+func refreshedPRs() []*models.PullRequest {
+	prs := snapshotPRs()
+	prs[1].HeadSHA, prs[1].UpdatedAt = "d4e5f6a", snapshotNow
+	opened := &models.PullRequest{
+		ID: 1143, Number: 143, Title: "Bump go-github to v58", State: models.PRStateOpen, RepoName: "acme/api",
+		Author: "hubot", CreatedAt: snapshotNow, UpdatedAt: snapshotNow, ReviewStatus: "pending",
+		HeadRef: "deps/go-github", HeadRepo: "acme/api", BaseRef: "main", HeadSHA: "e5f6a7b",
+	}
+	return append([]*models.PullRequest{opened, prs[1]}, prs[0], prs[2])
+}
+
+// snapshotSeen has #142 read before its last two comments and #137 read since
+// its last activity; #139 was never read
+func snapshotSeen() map[string]*models.Seen {
+	return map[string]*models.Seen{
+		"github.com/acme/api#142": {ReadAt: snapshotNow.Add(-time.Hour), UpdatedAt: snapshotNow.Add(-time.Hour), HeadSHA: "a1b2c3d", Comments: 1},
+		"github.com/acme/api#137": {ReadAt: snapshotNow.Add(-2 * time.Hour), UpdatedAt: snapshotNow.Add(-24 * time.Hour), HeadSHA: "c3d4e5f", Comments: -1},
+	}
+}
+
+func snapshotChecks(status string) []*models.Check {
+	started := snapshotNow.Add(-30 * time.Minute)
+	checks := []*models.Check{
+		{ID: 11, Name: "lint", Kind: models.CheckKindRun, App: "github-actions", Status: "completed", Conclusion: "success",
+			StartedAt: started, CompletedAt: started.Add(48 * time.Second)},
+		{ID: 12, Name: "test", Kind: models.CheckKindRun, App: "github-actions", Status: "completed", Conclusion: "success",
+			StartedAt: started, CompletedAt: started.Add(3*time.Minute + 12*time.Second)},
+	}
+	switch status {
+	case models.CIStatusFailure:
+		checks[1].Conclusion = "failure"
+	case models.CIStatusPending:
+		checks[1].Status, checks[1].Conclusion, checks[1].CompletedAt = "in_progress", "", time.Time{}
+	}
+	return checks
+}
+
+func snapshotLog() *models.JobLog {
+	return &models.JobLog{
+		JobID: 12, Name: "test", Status: "completed", Conclusion: "failure",
+		Steps: []*models.JobStep{
+			{Number: 1, Name: "Set up job", Status: "completed", Conclusion: "success"},
+			{Number: 2, Name: "Run go test ./...", Status: "completed", Conclusion: "failure"},
+		},
+		Lines: models.ParseJobLog(`2024-05-01T11:30:00.0000000Z ##[group]Run go test ./...
+2024-05-01T11:30:01.0000000Z go test ./...
+2024-05-01T11:30:02.0000000Z ##[endgroup]
+2024-05-01T11:30:05.0000000Z ok   github.com/acme/api/internal/auth  0.012s
+2024-05-01T11:30:06.0000000Z --- FAIL: TestRateLimiter (0.00s)
+2024-05-01T11:30:06.0000000Z     limiter_test.go:42: expected 429 after 10 requests, got 200
+2024-05-01T11:30:06.0000000Z FAIL github.com/acme/api/internal/limiter  0.004s
+2024-05-01T11:30:07.0000000Z ##[error]Process completed with exit code 1.
+`),
+	}
+}
+
+func snapshotThreads() []*models.Thread {
+	return models.GroupThreads([]*models.Comment{
+		{ID: 1, Author: "hubot", Type: models.CommentTypeComment, Body: "Coverage dropped by 1.2% on this branch.",
+			CreatedAt: snapshotNow.Add(-3 * time.Hour)},
+		{ID: 2, Author: "linus", Type: models.CommentTypeReview, ReviewState: "CHANGES_REQUESTED",
+			Body: "The window never slides; see inline comment.", CreatedAt: snapshotNow.Add(-2 * time.Hour)},
+		{ID: 9001, Author: "linus", Type: models.CommentTypeReviewComment, FilePath: "internal/limiter/limiter.go", Line: 14,
+			Body: "`window` is stored but never used in Allow.", CreatedAt: snapshotNow.Add(-2 * time.Hour)},
+		{ID: 9002, Author: "mona", Type: models.CommentTypeReviewComment, FilePath: "internal/limiter/limiter.go", Line: 14,
+			InReplyTo: 9001, Body: "Good catch, fixing.", CreatedAt: snapshotNow.Add(-time.Hour)},
+	}, map[int64]models.ThreadState{9001: {NodeID: "PRRT_9001", Line: 14}})
+}
+
+func snapshotFiles() []*models.FileChange {
+	patch := `@@ -10,7 +10,8 @@ type Limiter struct {
+ 	mu       sync.Mutex
+ 	requests map[string]int
+-	limit    int
++	limit    int
++	window   time.Duration
+ }
+
+ func (l *Limiter) Allow(key string) bool {`
+	return []*models.FileChange{
+		{Filename: "internal/limiter/limiter.go", Status: "modified", Additions: 2, Deletions: 1, Patch: patch, Hunks: models.ParsePatch(patch)},
+		{Filename: "internal/limiter/limiter_test.go", Status: "added", Additions: 3, Patch: "@@ -0,0 +1,3 @@\n+package limiter\n+\n+// TODO: cover the window edges",
+			Hunks: models.ParsePatch("@@ -0,0 +1,3 @@\n+package limiter\n+\n+// TODO: cover the window edges")},
+	}
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/will-wright-eng/gh-nav/pkg/config"
+)
+
+// stateDir returns where the app keeps state between runs (queued changes,
+// read PRs), or "" to keep it in memory (e.g. --demo)
+func stateDir(cfg *config.Config) string {
+	return cfg.State.Dir
+}
+
+// stateHost names the GitHub host state is kept for, so one state directory can
+// serve several hosts
+func stateHost(host string) string {
+	if host == "" {
+		return config.DefaultHost
+	}
+	return strings.ToLower(host)
+}
+
+// readState decodes the JSON file at path into v, leaving v alone if the file
+// doesn't exist yet
+func readState(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeState writes v as JSON to path through a temporary file, so a crash
+// never leaves half a file
+func writeState(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".state-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
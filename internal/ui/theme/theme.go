@@ -16,6 +16,12 @@ type ColorPalette struct {
 	Muted      string
 	Background string
 	Text       string
+
+	// Diff colors
+	DiffAdded   string
+	DiffRemoved string
+	DiffHunk    string
+	LineNumber  string
 }
 
 // StylePalette defines common styles
@@ -42,10 +48,22 @@ type IconSet struct {
 	Draft        string
 	Approved     string
 	Changes      string
+	Closed       string
+	Merged       string
 	Loading      string
 	Error        string
 	Success      string
 	Info         string
+	CISuccess    string
+	CIFailure    string
+	CIPending    string
+	CINeutral    string
+	CINone       string
+	CIUnknown    string
+	Thread       string
+	Resolved     string
+	Collapsed    string
+	Expanded     string
 }
 
 // Theme represents the complete UI theme
@@ -67,6 +85,11 @@ var DefaultTheme = Theme{
 		Muted:      constants.ColorMuted,     // Dark gray
 		Background: "#000000",                // Black
 		Text:       constants.ColorText,      // Light gray
+
+		DiffAdded:   constants.ColorDiffAdded,   // Green
+		DiffRemoved: constants.ColorDiffRemoved, // Red
+		DiffHunk:    constants.ColorDiffHunk,    // Purple
+		LineNumber:  constants.ColorLineNumber,  // Dim gray
 	},
 	Styles: StylePalette{
 		Title: lipgloss.NewStyle().
@@ -112,10 +135,22 @@ var DefaultTheme = Theme{
 		Draft:        constants.IconDraft,
 		Approved:     constants.IconApproved,
 		Changes:      constants.IconChanges,
+		Closed:       constants.IconClosed,
+		Merged:       constants.IconMerged,
 		Loading:      constants.IconLoading,
 		Error:        constants.IconError,
 		Success:      constants.IconSuccess,
 		Info:         constants.IconInfo,
+		CISuccess:    constants.IconCISuccess,
+		CIFailure:    constants.IconCIFailure,
+		CIPending:    constants.IconCIPending,
+		CINeutral:    constants.IconCINeutral,
+		CINone:       constants.IconCINone,
+		CIUnknown:    constants.IconCIUnknown,
+		Thread:       constants.IconThread,
+		Resolved:     constants.IconResolved,
+		Collapsed:    constants.IconCollapsed,
+		Expanded:     constants.IconExpanded,
 	},
 }
 
@@ -162,3 +197,21 @@ func (t *Theme) GetPRStatusIcon(isDraft bool, reviewStatus string) string {
 		return t.Icons.Open
 	}
 }
+
+// GetCIStatusIcon returns the appropriate icon for a rolled-up CI status
+func (t *Theme) GetCIStatusIcon(ciStatus string) string {
+	switch ciStatus {
+	case "success":
+		return t.Icons.CISuccess
+	case "failure":
+		return t.Icons.CIFailure
+	case "pending":
+		return t.Icons.CIPending
+	case "neutral":
+		return t.Icons.CINeutral
+	case "none":
+		return t.Icons.CINone
+	default:
+		return t.Icons.CIUnknown
+	}
+}
@@ -0,0 +1,286 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/will-wright-eng/gh-nav/internal/constants"
+	"github.com/will-wright-eng/gh-nav/internal/models"
+	"github.com/will-wright-eng/gh-nav/internal/ui/theme"
+)
+
+// Bulk action stages
+const (
+	bulkChoosing = iota
+	bulkInput
+	bulkPreview
+	bulkRunning
+	bulkDone
+)
+
+// BulkRunMsg is sent when the user confirms a bulk action
+type BulkRunMsg struct {
+	Action string
+	Arg    string // label, reviewer or comment body
+	PRs    []*models.PullRequest
+}
+
+// BulkCancelMsg is sent when the user leaves the bulk menu without running anything
+type BulkCancelMsg struct{}
+
+// BulkModel picks an action for several PRs, previews them, and shows progress
+// and a per-PR summary while the action runs
+type BulkModel struct {
+	BaseView
+	theme   *theme.Theme
+	prs     []*models.PullRequest
+	stage   int
+	action  string
+	input   textinput.Model
+	spinner spinner.Model
+	results []error // one per PR, valid for indexes below done
+	done    int
+	stopped bool
+}
+
+// NewBulk creates a new bulk action view
+func NewBulk(pageSize int, t *theme.Theme) *BulkModel {
+	input := textinput.New()
+	input.CharLimit = 0
+
+	return &BulkModel{
+		BaseView: NewBaseView(pageSize),
+		theme:    t,
+		input:    input,
+		spinner:  spinner.New(spinner.WithSpinner(spinner.Dot)),
+	}
+}
+
+// Start opens the action menu for the selected PRs
+func (b *BulkModel) Start(prs []*models.PullRequest) {
+	b.prs = prs
+	b.stage = bulkChoosing
+	b.action = ""
+	b.results = nil
+	b.done = 0
+	b.stopped = false
+	b.page = 0
+	b.cursor = 0
+	b.input.Reset()
+	b.input.Blur()
+}
+
+// GetPRs returns the PRs the action applies to
+func (b *BulkModel) GetPRs() []*models.PullRequest {
+	return b.prs
+}
+
+// IsConfirming reports whether the view is showing the preview and waiting for a yes/no answer
+func (b *BulkModel) IsConfirming() bool {
+	return b.stage == bulkPreview
+}
+
+// IsChoosing reports whether the action menu is shown
+func (b *BulkModel) IsChoosing() bool {
+	return b.stage == bulkChoosing
+}
+
+// IsRunning reports whether the action is still being applied
+func (b *BulkModel) IsRunning() bool {
+	return b.stage == bulkRunning
+}
+
+// IsFinished reports whether the action has run (or been stopped)
+func (b *BulkModel) IsFinished() bool {
+	return b.stage == bulkDone
+}
+
+// Stopped reports whether the user asked to stop the running action
+func (b *BulkModel) Stopped() bool {
+	return b.stopped
+}
+
+// SetResult records the outcome for the PR at index, finishing after the last one
+func (b *BulkModel) SetResult(index int, err error) {
+	if index != b.done || b.stage != bulkRunning {
+		return
+	}
+	b.results = append(b.results, err)
+	b.done++
+	if b.done == len(b.prs) {
+		b.stage = bulkDone
+	}
+}
+
+// Finish ends the run early, e.g. after the user stopped it
+func (b *BulkModel) Finish() {
+	b.stage = bulkDone
+}
+
+// CapturesInput reports whether the view handles every key itself; once the
+// action has finished the usual navigation keys apply
+func (b *BulkModel) CapturesInput() bool {
+	return b.prs != nil && b.stage != bulkDone
+}
+
+// Update handles messages and updates the view
+func (b *BulkModel) Update(msg tea.Msg) (View, tea.Cmd) {
+	keyMsg, isKey := msg.(tea.KeyMsg)
+	if !isKey {
+		var cmd tea.Cmd
+		switch b.stage {
+		case bulkRunning:
+			b.spinner, cmd = b.spinner.Update(msg)
+		case bulkInput:
+			b.input, cmd = b.input.Update(msg)
+		}
+		return b, cmd
+	}
+
+	switch b.stage {
+	case bulkChoosing:
+		switch keyMsg.String() {
+		case "up", "k":
+			b.MoveCursorUp(len(models.BulkActions))
+		case "down", "j":
+			b.MoveCursorDown(len(models.BulkActions))
+		case "enter":
+			b.action = models.BulkActions[b.cursor]
+			if models.BulkActionPrompt(b.action) != "" {
+				b.stage = bulkInput
+				b.input.Placeholder = models.BulkActionPrompt(b.action)
+				return b, b.input.Focus()
+			}
+			b.stage = bulkPreview
+		case "esc", "b":
+			return b, func() tea.Msg { return BulkCancelMsg{} }
+		}
+	case bulkInput:
+		switch keyMsg.String() {
+		case "enter":
+			if strings.TrimSpace(b.input.Value()) == "" {
+				return b, nil
+			}
+			b.input.Blur()
+			b.stage = bulkPreview
+		case "esc":
+			b.input.Reset()
+			b.input.Blur()
+			b.stage = bulkChoosing
+		default:
+			var cmd tea.Cmd
+			b.input, cmd = b.input.Update(keyMsg)
+			return b, cmd
+		}
+	case bulkPreview:
+		switch keyMsg.String() {
+		case "y", "Y":
+			b.stage = bulkRunning
+			run := BulkRunMsg{Action: b.action, Arg: strings.TrimSpace(b.input.Value()), PRs: b.prs}
+			return b, tea.Batch(b.spinner.Tick, func() tea.Msg { return run })
+		case "n", "N", "esc":
+			b.stage = bulkChoosing
+		}
+	case bulkRunning:
+		// PRs not yet processed are skipped once the current one finishes
+		if keyMsg.String() == "esc" {
+			b.stopped = true
+		}
+	}
+	return b, nil
+}
+
+// View renders the menu, preview, progress or summary
+func (b *BulkModel) View() string {
+	if b.width == 0 {
+		return "Loading..."
+	}
+	if b.prs == nil {
+		return ""
+	}
+
+	margin := b.theme.Styles.Margin
+	var s strings.Builder
+	heading := fmt.Sprintf("Bulk action on %d pull requests", len(b.prs))
+	if b.action != "" {
+		heading = fmt.Sprintf("%s %d pull requests", models.BulkActionLabel(b.action), len(b.prs))
+	}
+	s.WriteString(margin.Copy().Bold(true).Render(heading) + "\n\n")
+
+	switch b.stage {
+	case bulkChoosing:
+		for i, action := range models.BulkActions {
+			cursor := " "
+			style := b.theme.Styles.Unselected
+			if b.cursor == i {
+				cursor = ">"
+				style = b.theme.Styles.Selected
+			}
+			s.WriteString(style.Render(fmt.Sprintf("%s %s", cursor, models.BulkActionLabel(action))) + "\n")
+		}
+	case bulkInput:
+		s.WriteString(margin.Render(models.BulkActionPrompt(b.action)+":") + "\n")
+		s.WriteString(margin.Render(b.input.View()) + "\n")
+	case bulkPreview:
+		if arg := strings.TrimSpace(b.input.Value()); arg != "" {
+			s.WriteString(margin.Render(fmt.Sprintf("%s: %s", models.BulkActionPrompt(b.action), arg)) + "\n\n")
+		}
+		s.WriteString(margin.Render("Affected pull requests:") + "\n")
+		for _, pr := range b.prs {
+			s.WriteString(margin.Render(fmt.Sprintf("  %s#%d %s", pr.RepoName, pr.Number, pr.Title)) + "\n")
+		}
+		s.WriteString("\n" + b.theme.Styles.Warning.Render(fmt.Sprintf("%s these %d pull requests? (y/n)",
+			models.BulkActionLabel(b.action), len(b.prs))) + "\n")
+	case bulkRunning, bulkDone:
+		s.WriteString(b.viewProgress())
+	}
+	return s.String()
+}
+
+// viewProgress renders the progress bar and per-PR results
+func (b *BulkModel) viewProgress() string {
+	margin := b.theme.Styles.Margin
+	var s strings.Builder
+
+	width := 30
+	filled := width * b.done / len(b.prs)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+	status := fmt.Sprintf("%s %d/%d", bar, b.done, len(b.prs))
+	if b.stage == bulkRunning {
+		status = b.spinner.View() + " " + status
+		if b.stopped {
+			status += " (stopping...)"
+		}
+	}
+	s.WriteString(margin.Render(status) + "\n\n")
+
+	success := lipgloss.NewStyle().Foreground(lipgloss.Color(b.theme.Colors.Success))
+	failure := lipgloss.NewStyle().Foreground(lipgloss.Color(b.theme.Colors.Error))
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color(b.theme.Colors.Muted))
+	failed := 0
+	for i, pr := range b.prs {
+		name := fmt.Sprintf("%s#%d %s", pr.RepoName, pr.Number, pr.Title)
+		switch {
+		case i < b.done && b.results[i] == nil:
+			s.WriteString(margin.Render(success.Render(constants.IconResolved)+" "+name) + "\n")
+		case i < b.done:
+			failed++
+			s.WriteString(margin.Render(failure.Render("✗")+" "+name) + "\n")
+			s.WriteString(margin.Render("    "+failure.Render(b.results[i].Error())) + "\n")
+		case b.stage == bulkDone:
+			s.WriteString(margin.Render(muted.Render("- "+name+" (skipped)")) + "\n")
+		default:
+			s.WriteString(margin.Render(muted.Render("· "+name)) + "\n")
+		}
+	}
+
+	if b.stage == bulkDone {
+		s.WriteString("\n" + margin.Render(fmt.Sprintf("%d succeeded, %d failed, %d skipped",
+			b.done-failed, failed, len(b.prs)-b.done)) + "\n")
+	}
+	return s.String()
+}
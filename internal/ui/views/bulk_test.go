@@ -0,0 +1,68 @@
+package views
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/will-wright-eng/gh-nav/internal/models"
+	"github.com/will-wright-eng/gh-nav/internal/ui/theme"
+)
+
+func TestBulkLabelFlow(t *testing.T) {
+	bulk := NewBulk(10, &theme.DefaultTheme)
+	bulk.SetSize(100, 40)
+	bulk.Start(testPRs(3))
+
+	// Move to "Add label" and enter a value
+	for bulk.cursor < 3 {
+		bulk.Update(keyRunes("j"))
+	}
+	bulk.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	bulk.Update(keyRunes("deps"))
+	bulk.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if !bulk.IsConfirming() {
+		t.Fatal("Expected a preview before running")
+	}
+	if !strings.Contains(bulk.View(), "org/repo#3") {
+		t.Error("Expected the preview to list the affected PRs")
+	}
+
+	_, cmd := bulk.Update(keyRunes("y"))
+	if cmd == nil || !bulk.IsRunning() {
+		t.Fatal("Expected y to start the action")
+	}
+
+	bulk.SetResult(0, nil)
+	bulk.SetResult(1, errors.New("label not found"))
+	bulk.SetResult(2, nil)
+	if !bulk.IsFinished() {
+		t.Fatal("Expected the action to finish after the last PR")
+	}
+	if view := bulk.View(); !strings.Contains(view, "2 succeeded, 1 failed, 0 skipped") || !strings.Contains(view, "label not found") {
+		t.Errorf("Expected a per-PR summary, got:\n%s", view)
+	}
+}
+
+func TestBulkStop(t *testing.T) {
+	bulk := NewBulk(10, &theme.DefaultTheme)
+	bulk.SetSize(100, 40)
+	bulk.Start(testPRs(3))
+	bulk.Update(tea.KeyMsg{Type: tea.KeyEnter}) // approve needs no input
+	bulk.Update(keyRunes("y"))
+
+	bulk.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !bulk.Stopped() {
+		t.Fatal("Expected esc to stop the run")
+	}
+	bulk.SetResult(0, nil)
+	bulk.Finish()
+	if !strings.Contains(bulk.View(), "1 succeeded, 0 failed, 2 skipped") {
+		t.Errorf("Expected skipped PRs in the summary, got:\n%s", bulk.View())
+	}
+	if models.BulkActions[0] != models.BulkApprove {
+		t.Error("Expected approve to be the first action")
+	}
+}
@@ -0,0 +1,296 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/will-wright-eng/gh-nav/internal/constants"
+	"github.com/will-wright-eng/gh-nav/internal/editor"
+	"github.com/will-wright-eng/gh-nav/internal/models"
+	"github.com/will-wright-eng/gh-nav/internal/ui/components"
+	"github.com/will-wright-eng/gh-nav/internal/ui/theme"
+)
+
+// Compose stages
+const (
+	composeEditing    = iota
+	composeConfirming // asking before sending
+	composeDiscarding // asking before throwing the draft away
+	composeSending
+)
+
+// ComposeSubmitMsg is sent when the user confirms a draft
+type ComposeSubmitMsg struct {
+	Draft *models.Draft
+}
+
+// ComposeCancelMsg is sent when the user leaves the composer without sending
+type ComposeCancelMsg struct{}
+
+// editorFinishedMsg is sent when the external editor exits
+type editorFinishedMsg struct {
+	path string
+	err  error
+}
+
+// ComposeModel is a multi-line editor for comments, replies and reviews, with an
+// option to edit in $EDITOR and a confirmation step before anything is sent
+type ComposeModel struct {
+	BaseView
+	theme    *theme.Theme
+	editor   string // external editor command
+	draft    *models.Draft
+	textarea textarea.Model
+	stage    int
+	problem  string // validation or editor error shown under the text area
+}
+
+// NewCompose creates a new composer; editorCommand is the external editor to use
+func NewCompose(t *theme.Theme, editorCommand string) *ComposeModel {
+	ta := textarea.New()
+	ta.Placeholder = "Leave a comment (Markdown supported)"
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 0
+
+	return &ComposeModel{
+		BaseView: NewBaseView(constants.DefaultPageSize),
+		theme:    t,
+		editor:   editorCommand,
+		textarea: ta,
+	}
+}
+
+// Start begins composing a draft and focuses the text area
+func (c *ComposeModel) Start(draft *models.Draft) tea.Cmd {
+	c.draft = draft
+	c.stage = composeEditing
+	c.problem = ""
+	c.textarea.Reset()
+	c.textarea.SetValue(draft.Body)
+	return c.textarea.Focus()
+}
+
+// GetDraft returns the draft being composed
+func (c *ComposeModel) GetDraft() *models.Draft {
+	return c.draft
+}
+
+// GetPR returns the pull request the draft is for
+func (c *ComposeModel) GetPR() *models.PullRequest {
+	if c.draft == nil {
+		return nil
+	}
+	return c.draft.PR
+}
+
+// SendFailed returns to editing after the API rejected the draft, keeping the text
+func (c *ComposeModel) SendFailed(err error) {
+	c.stage = composeEditing
+	c.problem = err.Error()
+}
+
+// IsConfirming reports whether the composer is waiting for a yes/no answer
+func (c *ComposeModel) IsConfirming() bool {
+	return c.stage == composeConfirming || c.stage == composeDiscarding
+}
+
+// CapturesInput reports that the composer takes all keyboard input
+func (c *ComposeModel) CapturesInput() bool {
+	return c.draft != nil
+}
+
+// SetSize updates the view dimensions and resizes the text area
+func (c *ComposeModel) SetSize(width, height int) {
+	c.BaseView.SetSize(width, height)
+	c.textarea.SetWidth(width - 6)
+	textHeight := height - 14
+	if textHeight < 3 {
+		textHeight = 3
+	}
+	c.textarea.SetHeight(textHeight)
+}
+
+// Update handles messages and updates the view
+func (c *ComposeModel) Update(msg tea.Msg) (View, tea.Cmd) {
+	if c.draft == nil {
+		return c, nil
+	}
+
+	switch msg := msg.(type) {
+	case editorFinishedMsg:
+		body, err := editor.ReadDraft(msg.path)
+		if msg.err != nil {
+			c.problem = fmt.Sprintf("editor failed: %v", msg.err)
+		} else if err != nil {
+			c.problem = err.Error()
+		} else {
+			c.textarea.SetValue(body)
+			c.problem = ""
+		}
+		return c, c.textarea.Focus()
+	case tea.KeyMsg:
+		switch c.stage {
+		case composeConfirming:
+			switch msg.String() {
+			case "y", "Y", "enter":
+				c.stage = composeSending
+				draft := *c.draft
+				return c, func() tea.Msg { return ComposeSubmitMsg{Draft: &draft} }
+			case "n", "N", "esc":
+				c.stage = composeEditing
+				return c, c.textarea.Focus()
+			}
+			return c, nil
+		case composeDiscarding:
+			switch msg.String() {
+			case "y", "Y", "enter":
+				return c, func() tea.Msg { return ComposeCancelMsg{} }
+			case "n", "N", "esc":
+				c.stage = composeEditing
+				return c, c.textarea.Focus()
+			}
+			return c, nil
+		case composeSending:
+			return c, nil
+		}
+		return c.updateEditing(msg)
+	}
+
+	// Cursor blinking and other text area messages
+	var cmd tea.Cmd
+	c.textarea, cmd = c.textarea.Update(msg)
+	return c, cmd
+}
+
+// updateEditing handles keys while the text area is active
+func (c *ComposeModel) updateEditing(msg tea.KeyMsg) (View, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+s":
+		c.draft.Body = strings.TrimSpace(c.textarea.Value())
+		if err := c.draft.Validate(); err != nil {
+			c.problem = err.Error()
+			return c, nil
+		}
+		c.problem = ""
+		c.stage = composeConfirming
+		c.textarea.Blur()
+		return c, nil
+	case "esc":
+		if strings.TrimSpace(c.textarea.Value()) == "" {
+			return c, func() tea.Msg { return ComposeCancelMsg{} }
+		}
+		c.stage = composeDiscarding
+		c.textarea.Blur()
+		return c, nil
+	case "ctrl+e":
+		path, err := editor.WriteDraft(c.textarea.Value())
+		if err != nil {
+			c.problem = err.Error()
+			return c, nil
+		}
+		return c, tea.ExecProcess(editor.Command(c.editor, path), func(err error) tea.Msg {
+			return editorFinishedMsg{path: path, err: err}
+		})
+	case "tab":
+		if c.draft.Kind == models.DraftReview {
+			c.draft.Event = nextReviewEvent(c.draft.Event)
+			return c, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	c.textarea, cmd = c.textarea.Update(msg)
+	return c, cmd
+}
+
+// nextReviewEvent cycles through the review events
+func nextReviewEvent(event string) string {
+	for i, e := range models.ReviewEvents {
+		if e == event {
+			return models.ReviewEvents[(i+1)%len(models.ReviewEvents)]
+		}
+	}
+	return models.ReviewEvents[0]
+}
+
+// View renders the composer
+func (c *ComposeModel) View() string {
+	if c.width == 0 {
+		return "Loading..."
+	}
+	if c.draft == nil {
+		return ""
+	}
+
+	margin := c.theme.Styles.Margin
+	var b strings.Builder
+	b.WriteString(margin.Copy().Bold(true).Render(c.heading()) + "\n")
+	if c.draft.Kind == models.DraftReview {
+		selected := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(c.theme.Colors.Primary))
+		var events []string
+		for _, event := range models.ReviewEvents {
+			label := models.ReviewEventLabel(event)
+			if event == c.draft.Event {
+				label = selected.Render("[" + label + "]")
+			}
+			events = append(events, label)
+		}
+		b.WriteString(margin.Render("Review: "+strings.Join(events, "  ")) + "\n")
+	}
+	b.WriteString("\n")
+
+	if c.stage == composeEditing {
+		b.WriteString(margin.Render(c.textarea.View()) + "\n")
+	} else {
+		// Show the final text as it will be sent
+		body := c.draft.Body
+		if body == "" {
+			body = "(no comment)"
+		}
+		b.WriteString(margin.Copy().Width(c.width-6).Render(body) + "\n")
+	}
+
+	switch c.stage {
+	case composeConfirming:
+		b.WriteString("\n" + c.theme.Styles.Warning.Render(c.confirmPrompt()) + "\n")
+	case composeDiscarding:
+		b.WriteString("\n" + c.theme.Styles.Warning.Render("Discard this draft? (y/n)") + "\n")
+	case composeSending:
+		b.WriteString("\n" + c.theme.Styles.Info.Render("Sending...") + "\n")
+	}
+	if c.problem != "" {
+		b.WriteString(c.theme.Styles.Error.Render(c.problem) + "\n")
+	}
+	return b.String()
+}
+
+// heading describes what is being written
+func (c *ComposeModel) heading() string {
+	pr := c.draft.PR
+	switch c.draft.Kind {
+	case models.DraftReply:
+		if c.draft.Thread != nil {
+			return fmt.Sprintf("Reply on #%d at %s", pr.Number, components.ThreadLocation(c.draft.Thread))
+		}
+		return fmt.Sprintf("Reply on #%d", pr.Number)
+	case models.DraftReview:
+		return fmt.Sprintf("Review #%d %s", pr.Number, pr.Title)
+	case models.DraftClose:
+		return fmt.Sprintf("Close #%d %s (comment optional)", pr.Number, pr.Title)
+	}
+	return fmt.Sprintf("Comment on #%d %s", pr.Number, pr.Title)
+}
+
+// confirmPrompt asks whether to go ahead with the draft
+func (c *ComposeModel) confirmPrompt() string {
+	if c.draft.Kind == models.DraftClose {
+		if c.draft.Body == "" {
+			return fmt.Sprintf("Close #%d without a comment? (y/n)", c.draft.PR.Number)
+		}
+		return fmt.Sprintf("Comment on and close #%d? (y/n)", c.draft.PR.Number)
+	}
+	return fmt.Sprintf("Send this %s to GitHub? (y/n)", c.draft.Kind)
+}
@@ -0,0 +1,91 @@
+package views
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/will-wright-eng/gh-nav/internal/models"
+	"github.com/will-wright-eng/gh-nav/internal/ui/theme"
+)
+
+func newTestCompose(draft *models.Draft) *ComposeModel {
+	compose := NewCompose(&theme.DefaultTheme, "")
+	compose.SetSize(100, 40)
+	compose.Start(draft)
+	return compose
+}
+
+func TestComposeConfirmsBeforeSending(t *testing.T) {
+	pr := &models.PullRequest{Number: 7, RepoName: "owner/repo"}
+	compose := newTestCompose(&models.Draft{Kind: models.DraftComment, PR: pr})
+
+	compose.Update(keyRunes("Looks good"))
+	if _, cmd := compose.Update(tea.KeyMsg{Type: tea.KeyCtrlS}); cmd != nil {
+		t.Fatal("Expected ctrl+s to ask for confirmation, not send")
+	}
+	if !compose.IsConfirming() {
+		t.Fatal("Expected the composer to be confirming")
+	}
+
+	_, cmd := compose.Update(keyRunes("y"))
+	if cmd == nil {
+		t.Fatal("Expected y to send the draft")
+	}
+	submit, ok := cmd().(ComposeSubmitMsg)
+	if !ok {
+		t.Fatalf("Expected ComposeSubmitMsg, got %T", cmd())
+	}
+	if submit.Draft.Body != "Looks good" || submit.Draft.PR != pr {
+		t.Errorf("Unexpected draft %+v", submit.Draft)
+	}
+}
+
+func TestComposeRejectsEmptyComment(t *testing.T) {
+	compose := newTestCompose(&models.Draft{Kind: models.DraftComment, PR: &models.PullRequest{}})
+
+	compose.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if compose.IsConfirming() {
+		t.Error("Expected an empty comment not to reach confirmation")
+	}
+	if compose.problem == "" {
+		t.Error("Expected a validation message")
+	}
+}
+
+func TestComposeDiscardNeedsConfirmation(t *testing.T) {
+	compose := newTestCompose(&models.Draft{Kind: models.DraftComment, PR: &models.PullRequest{}})
+	compose.Update(keyRunes("half a thought"))
+
+	compose.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !compose.IsConfirming() {
+		t.Fatal("Expected esc to ask before discarding text")
+	}
+	compose.Update(keyRunes("n"))
+	if compose.IsConfirming() || compose.textarea.Value() != "half a thought" {
+		t.Error("Expected n to return to editing with the text kept")
+	}
+
+	compose.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	_, cmd := compose.Update(keyRunes("y"))
+	if cmd == nil {
+		t.Fatal("Expected y to discard the draft")
+	}
+	if _, ok := cmd().(ComposeCancelMsg); !ok {
+		t.Error("Expected ComposeCancelMsg")
+	}
+}
+
+func TestComposeCyclesReviewEvent(t *testing.T) {
+	compose := newTestCompose(&models.Draft{Kind: models.DraftReview, PR: &models.PullRequest{}, Event: models.ReviewEventComment})
+
+	compose.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if compose.GetDraft().Event != models.ReviewEventApprove {
+		t.Errorf("Expected APPROVE after one tab, got %s", compose.GetDraft().Event)
+	}
+
+	// An approval may be sent without a body
+	compose.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if !compose.IsConfirming() {
+		t.Error("Expected an empty approval to reach confirmation")
+	}
+}
@@ -0,0 +1,445 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/will-wright-eng/gh-nav/internal/constants"
+	"github.com/will-wright-eng/gh-nav/internal/models"
+	"github.com/will-wright-eng/gh-nav/internal/ui/theme"
+)
+
+// diffChromeLines is the number of screen lines used around the diff by the app
+// and the viewer's own header
+const diffChromeLines = 8
+
+// diffRow is one rendered screen line of a diff
+type diffRow struct {
+	text string
+	hunk bool // the row is a hunk header, used for hunk navigation
+}
+
+// diffPair is one row of a side-by-side diff; either side may be missing
+type diffPair struct {
+	left, right *models.DiffLine
+}
+
+// DiffModel is a pager for the diff of one changed file, with review threads shown
+// below the lines they comment on
+type DiffModel struct {
+	BaseView
+	theme      *theme.Theme
+	pr         *models.PullRequest
+	files      []*models.FileChange
+	threads    []*models.Thread
+	index      int   // file being shown
+	offset     int   // index of the first visible row
+	sideBySide *bool // nil follows the terminal width
+}
+
+// NewDiff creates a new diff viewer
+func NewDiff(t *theme.Theme) *DiffModel {
+	return &DiffModel{
+		BaseView: NewBaseView(constants.DefaultPageSize),
+		theme:    t,
+	}
+}
+
+// SetFiles sets the PR's changed files and review threads and shows the file at index
+func (d *DiffModel) SetFiles(pr *models.PullRequest, files []*models.FileChange, threads []*models.Thread, index int) {
+	d.pr = pr
+	d.files = files
+	d.threads = threads
+	d.showFile(index)
+}
+
+// GetPR returns the pull request whose diff is shown
+func (d *DiffModel) GetPR() *models.PullRequest {
+	return d.pr
+}
+
+// GetFile returns the file being shown
+func (d *DiffModel) GetFile() *models.FileChange {
+	if d.index < 0 || d.index >= len(d.files) {
+		return nil
+	}
+	return d.files[d.index]
+}
+
+// showFile switches to another file and scrolls to its top
+func (d *DiffModel) showFile(index int) {
+	if index < 0 || index >= len(d.files) {
+		return
+	}
+	d.index = index
+	d.offset = 0
+}
+
+// IsSideBySide reports whether the diff is rendered in two columns
+func (d *DiffModel) IsSideBySide() bool {
+	if d.sideBySide != nil {
+		return *d.sideBySide
+	}
+	return d.width >= constants.SideBySideMinWidth
+}
+
+// ToggleSideBySide switches between unified and side-by-side rendering
+func (d *DiffModel) ToggleSideBySide() {
+	sideBySide := !d.IsSideBySide()
+	d.sideBySide = &sideBySide
+	d.offset = 0
+}
+
+// visibleRows returns how many rows fit on screen
+func (d *DiffModel) visibleRows() int {
+	if d.height-diffChromeLines < 5 {
+		return 5
+	}
+	return d.height - diffChromeLines
+}
+
+// scroll moves the view by delta rows, clamped to the diff
+func (d *DiffModel) scroll(delta, total int) {
+	d.offset += delta
+	if maxOffset := total - d.visibleRows(); d.offset > maxOffset {
+		d.offset = maxOffset
+	}
+	if d.offset < 0 {
+		d.offset = 0
+	}
+}
+
+// jumpToHunk scrolls to the next (forward) or previous hunk header
+func (d *DiffModel) jumpToHunk(rows []diffRow, forward bool) {
+	if forward {
+		for i := d.offset + 1; i < len(rows); i++ {
+			if rows[i].hunk {
+				d.offset = 0
+				d.scroll(i, len(rows))
+				return
+			}
+		}
+		return
+	}
+	for i := d.offset - 1; i >= 0; i-- {
+		if rows[i].hunk {
+			d.offset = i
+			return
+		}
+	}
+}
+
+// Update handles messages and updates the view
+func (d *DiffModel) Update(msg tea.Msg) (View, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return d, nil
+	}
+
+	rows := d.rows()
+	page := d.visibleRows()
+	switch keyMsg.String() {
+	case "up", "k":
+		d.scroll(-1, len(rows))
+	case "down", "j":
+		d.scroll(1, len(rows))
+	case "left", "h", "pgup":
+		d.scroll(-page, len(rows))
+	case "right", "l", "pgdown", " ":
+		d.scroll(page, len(rows))
+	case "g", "home":
+		d.offset = 0
+	case "G", "end":
+		d.scroll(len(rows), len(rows))
+	case "n":
+		d.jumpToHunk(rows, true)
+	case "N":
+		d.jumpToHunk(rows, false)
+	case "]":
+		d.showFile(d.index + 1)
+	case "[":
+		d.showFile(d.index - 1)
+	case "s":
+		d.ToggleSideBySide()
+	}
+	return d, nil
+}
+
+// View renders the visible rows of the diff
+func (d *DiffModel) View() string {
+	if d.width == 0 {
+		return "Loading..."
+	}
+
+	file := d.GetFile()
+	if file == nil {
+		return ""
+	}
+
+	rows := d.rows()
+	end := d.offset + d.visibleRows()
+	if end > len(rows) {
+		end = len(rows)
+	}
+
+	mode := "unified"
+	if d.IsSideBySide() {
+		mode = "side-by-side"
+	}
+	added := lipgloss.NewStyle().Foreground(lipgloss.Color(d.theme.Colors.DiffAdded))
+	removed := lipgloss.NewStyle().Foreground(lipgloss.Color(d.theme.Colors.DiffRemoved))
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color(d.theme.Colors.Muted))
+
+	var b strings.Builder
+	b.WriteString(d.theme.Styles.Margin.Copy().Bold(true).Render(file.DisplayName()) + "\n")
+	b.WriteString(d.theme.Styles.Margin.Render(fmt.Sprintf("%s %s %s",
+		added.Render(fmt.Sprintf("+%d", file.Additions)),
+		removed.Render(fmt.Sprintf("-%d", file.Deletions)),
+		muted.Render(fmt.Sprintf("• file %d/%d • %s • rows %d-%d of %d",
+			d.index+1, len(d.files), mode, d.offset+1, end, len(rows))))) + "\n")
+
+	for _, row := range rows[d.offset:end] {
+		b.WriteString("  " + row.text + "\n")
+	}
+	return b.String()
+}
+
+// rows renders the current file into screen rows
+func (d *DiffModel) rows() []diffRow {
+	file := d.GetFile()
+	if file == nil {
+		return nil
+	}
+	if !file.HasPatch() {
+		return []diffRow{{text: lipgloss.NewStyle().Foreground(lipgloss.Color(d.theme.Colors.Muted)).
+			Render("Binary file, or diff too large to display")}}
+	}
+
+	threads := d.fileThreads(file.Filename)
+	shown := make(map[string]bool)
+	var rows []diffRow
+
+	// threadsAt returns the rows of threads anchored on a line, once each
+	threadsAt := func(side string, line int) []diffRow {
+		var out []diffRow
+		for _, thread := range threads {
+			if line == 0 || thread.Line != line || threadSide(thread) != side || shown[thread.ID] {
+				continue
+			}
+			shown[thread.ID] = true
+			out = append(out, d.threadRows(thread)...)
+		}
+		return out
+	}
+
+	hunkStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(d.theme.Colors.DiffHunk))
+	for _, hunk := range file.Hunks {
+		rows = append(rows, diffRow{text: hunkStyle.Render(truncateRunes(hunk.Header, d.width-4)), hunk: true})
+
+		if d.IsSideBySide() {
+			for _, pair := range pairLines(hunk.Lines) {
+				rows = append(rows, diffRow{text: d.renderPair(pair)})
+				if pair.left != nil {
+					rows = append(rows, threadsAt("LEFT", pair.left.OldLine)...)
+				}
+				if pair.right != nil {
+					rows = append(rows, threadsAt("RIGHT", pair.right.NewLine)...)
+				}
+			}
+			continue
+		}
+
+		for i := range hunk.Lines {
+			line := &hunk.Lines[i]
+			rows = append(rows, diffRow{text: d.renderUnified(line)})
+			rows = append(rows, threadsAt("LEFT", line.OldLine)...)
+			rows = append(rows, threadsAt("RIGHT", line.NewLine)...)
+		}
+	}
+
+	// Outdated threads whose lines are no longer part of the diff
+	var outdated []diffRow
+	for _, thread := range threads {
+		if !shown[thread.ID] {
+			outdated = append(outdated, d.threadRows(thread)...)
+		}
+	}
+	if len(outdated) > 0 {
+		rows = append(rows, diffRow{text: hunkStyle.Render("Comments on lines outside the diff"), hunk: true})
+		rows = append(rows, outdated...)
+	}
+	return rows
+}
+
+// fileThreads returns the review threads on a file
+func (d *DiffModel) fileThreads(filename string) []*models.Thread {
+	var threads []*models.Thread
+	for _, thread := range d.threads {
+		if !thread.IsConversation() && thread.FilePath == filename {
+			threads = append(threads, thread)
+		}
+	}
+	return threads
+}
+
+// threadSide returns the diff side a thread is anchored to (RIGHT unless the
+// first comment is on a removed line)
+func threadSide(thread *models.Thread) string {
+	if len(thread.Comments) > 0 && thread.Comments[0].Side == "LEFT" {
+		return "LEFT"
+	}
+	return "RIGHT"
+}
+
+// lineStyle returns the style for a diff line kind
+func (d *DiffModel) lineStyle(kind string) lipgloss.Style {
+	switch kind {
+	case models.DiffLineAdded:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(d.theme.Colors.DiffAdded))
+	case models.DiffLineRemoved:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(d.theme.Colors.DiffRemoved))
+	case models.DiffLineNote:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(d.theme.Colors.Muted)).Italic(true)
+	}
+	return lipgloss.NewStyle()
+}
+
+// lineNumber renders a line number column, blank for 0
+func (d *DiffModel) lineNumber(n int) string {
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color(d.theme.Colors.LineNumber))
+	if n == 0 {
+		return style.Render("    ")
+	}
+	return style.Render(fmt.Sprintf("%4d", n))
+}
+
+// renderUnified renders a line with old and new line numbers and a +/- marker
+func (d *DiffModel) renderUnified(line *models.DiffLine) string {
+	marker := " "
+	switch line.Kind {
+	case models.DiffLineAdded:
+		marker = "+"
+	case models.DiffLineRemoved:
+		marker = "-"
+	case models.DiffLineNote:
+		marker = ""
+	}
+
+	text := truncateRunes(marker+expandTabs(line.Text), d.width-14)
+	return fmt.Sprintf("%s %s %s", d.lineNumber(line.OldLine), d.lineNumber(line.NewLine), d.lineStyle(line.Kind).Render(text))
+}
+
+// renderPair renders a side-by-side row: old file on the left, new on the right
+func (d *DiffModel) renderPair(pair diffPair) string {
+	half := (d.width - 7) / 2
+	textWidth := half - 5
+	if textWidth < 10 {
+		textWidth = 10
+	}
+
+	cell := func(line *models.DiffLine, number int) string {
+		if line == nil {
+			return d.lineNumber(0) + " " + strings.Repeat(" ", textWidth)
+		}
+		text := padRunes(truncateRunes(expandTabs(line.Text), textWidth), textWidth)
+		return d.lineNumber(number) + " " + d.lineStyle(line.Kind).Render(text)
+	}
+
+	var left, right string
+	if pair.left != nil {
+		left = cell(pair.left, pair.left.OldLine)
+	} else {
+		left = cell(nil, 0)
+	}
+	if pair.right != nil {
+		right = cell(pair.right, pair.right.NewLine)
+	} else {
+		right = cell(nil, 0)
+	}
+	separator := lipgloss.NewStyle().Foreground(lipgloss.Color(d.theme.Colors.LineNumber)).Render("│")
+	return left + " " + separator + " " + right
+}
+
+// threadRows renders a review thread as indented rows beneath its line. Resolved
+// threads are collapsed into a single row.
+func (d *DiffModel) threadRows(thread *models.Thread) []diffRow {
+	border := lipgloss.NewStyle().Foreground(lipgloss.Color(d.theme.Colors.Info)).Render("│")
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color(d.theme.Colors.Muted))
+	width := d.width - 16
+
+	if thread.Resolved {
+		author := ""
+		if len(thread.Comments) > 0 {
+			author = " by " + thread.Comments[0].Author
+		}
+		text := fmt.Sprintf("%s resolved thread%s • %d comments", d.theme.Icons.Resolved, author, len(thread.Comments))
+		return []diffRow{{text: fmt.Sprintf("          %s %s", border, muted.Render(truncateRunes(text, width)))}}
+	}
+
+	var rows []diffRow
+	for _, comment := range thread.Comments {
+		author := lipgloss.NewStyle().Bold(true).Render(comment.Author)
+		rows = append(rows, diffRow{text: fmt.Sprintf("          %s %s %s", border, d.theme.Icons.Thread, author)})
+		for _, line := range strings.Split(strings.TrimSpace(strings.ReplaceAll(comment.Body, "\r\n", "\n")), "\n") {
+			rows = append(rows, diffRow{text: fmt.Sprintf("          %s    %s", border, truncateRunes(expandTabs(line), width))})
+		}
+	}
+	return rows
+}
+
+// pairLines lines up a hunk for side-by-side display: context lines appear on
+// both sides, and each run of removals is paired with the additions after it
+func pairLines(lines []models.DiffLine) []diffPair {
+	var pairs []diffPair
+	for i := 0; i < len(lines); {
+		line := &lines[i]
+		switch line.Kind {
+		case models.DiffLineContext:
+			pairs = append(pairs, diffPair{left: line, right: line})
+			i++
+			continue
+		case models.DiffLineNote:
+			i++
+			continue
+		}
+
+		var removed, added []*models.DiffLine
+		for ; i < len(lines) && (lines[i].Kind == models.DiffLineRemoved || lines[i].Kind == models.DiffLineNote); i++ {
+			if lines[i].Kind == models.DiffLineRemoved {
+				removed = append(removed, &lines[i])
+			}
+		}
+		for ; i < len(lines) && (lines[i].Kind == models.DiffLineAdded || lines[i].Kind == models.DiffLineNote); i++ {
+			if lines[i].Kind == models.DiffLineAdded {
+				added = append(added, &lines[i])
+			}
+		}
+
+		for k := 0; k < len(removed) || k < len(added); k++ {
+			var pair diffPair
+			if k < len(removed) {
+				pair.left = removed[k]
+			}
+			if k < len(added) {
+				pair.right = added[k]
+			}
+			pairs = append(pairs, pair)
+		}
+	}
+	return pairs
+}
+
+// expandTabs replaces tabs so columns line up
+func expandTabs(s string) string {
+	return strings.ReplaceAll(s, "\t", "    ")
+}
+
+// padRunes pads s with spaces to width runes
+func padRunes(s string, width int) string {
+	if n := len([]rune(s)); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
@@ -0,0 +1,73 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/will-wright-eng/gh-nav/internal/models"
+	"github.com/will-wright-eng/gh-nav/internal/ui/theme"
+)
+
+func TestPairLines(t *testing.T) {
+	lines := models.ParsePatch("@@ -1,3 +1,3 @@\n a\n-b\n-c\n+B\n d")[0].Lines
+	pairs := pairLines(lines)
+
+	if len(pairs) != 4 {
+		t.Fatalf("Expected 4 rows, got %d", len(pairs))
+	}
+	if pairs[1].left.Text != "b" || pairs[1].right.Text != "B" {
+		t.Errorf("Expected the first removal paired with the addition, got %+v", pairs[1])
+	}
+	if pairs[2].left.Text != "c" || pairs[2].right != nil {
+		t.Errorf("Expected the second removal alone on the left, got %+v", pairs[2])
+	}
+}
+
+func TestDiffInterleavesComments(t *testing.T) {
+	file := &models.FileChange{Filename: "main.go", Hunks: models.ParsePatch("@@ -1,2 +1,2 @@\n a\n-b\n+B")}
+	threads := []*models.Thread{
+		{ID: "1", FilePath: "main.go", Line: 2, Comments: []*models.Comment{{Author: "alice", Body: "why?", Side: "RIGHT"}}},
+		{ID: "2", FilePath: "main.go", Line: 40, Comments: []*models.Comment{{Author: "bob", Body: "old", Side: "RIGHT"}}},
+		{ID: "3", FilePath: "other.go", Line: 2, Comments: []*models.Comment{{Author: "carol", Body: "elsewhere"}}},
+	}
+
+	diff := NewDiff(&theme.DefaultTheme)
+	diff.SetSize(100, 40)
+	diff.SetFiles(nil, []*models.FileChange{file}, threads, 0)
+
+	var texts []string
+	for _, row := range diff.rows() {
+		texts = append(texts, row.text)
+	}
+	out := strings.Join(texts, "\n")
+
+	added := strings.Index(out, "+B")
+	comment := strings.Index(out, "why?")
+	if added < 0 || comment < added {
+		t.Errorf("Expected the comment right after the added line:\n%s", out)
+	}
+	if !strings.Contains(out, "outside the diff") || !strings.Contains(out, "old") {
+		t.Errorf("Expected the comment on a line outside the diff to be listed at the end:\n%s", out)
+	}
+	if strings.Contains(out, "elsewhere") {
+		t.Error("Expected comments on other files to be left out")
+	}
+}
+
+func TestDiffSideBySide(t *testing.T) {
+	diff := NewDiff(&theme.DefaultTheme)
+	diff.SetSize(100, 40)
+	if diff.IsSideBySide() {
+		t.Error("Expected unified mode on a narrow terminal")
+	}
+
+	diff.SetSize(200, 40)
+	if !diff.IsSideBySide() {
+		t.Error("Expected side-by-side mode on a wide terminal")
+	}
+
+	diff.ToggleSideBySide()
+	if diff.IsSideBySide() {
+		t.Error("Expected the toggle to override the width")
+	}
+}
@@ -0,0 +1,186 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/will-wright-eng/gh-nav/internal/constants"
+	"github.com/will-wright-eng/gh-nav/internal/models"
+	"github.com/will-wright-eng/gh-nav/internal/ui/theme"
+)
+
+// FilesModel lists the files changed by a pull request
+type FilesModel struct {
+	BaseView
+	theme   *theme.Theme
+	pr      *models.PullRequest
+	files   []*models.FileChange
+	threads []*models.Thread
+	loading bool
+}
+
+// NewFiles creates a new changed-files view
+func NewFiles(pageSize int, t *theme.Theme) *FilesModel {
+	return &FilesModel{
+		BaseView: NewBaseView(pageSize),
+		theme:    t,
+	}
+}
+
+// SetLoading clears the view while a PR's files are fetched
+func (f *FilesModel) SetLoading(pr *models.PullRequest) {
+	f.pr = pr
+	f.files = nil
+	f.threads = nil
+	f.loading = true
+	f.page = 0
+	f.cursor = 0
+}
+
+// SetFiles shows the loaded files and the review threads to interleave in diffs
+func (f *FilesModel) SetFiles(files []*models.FileChange, threads []*models.Thread) {
+	f.files = files
+	f.threads = threads
+	f.loading = false
+}
+
+// SetFailed stops the loading message after a failed fetch
+func (f *FilesModel) SetFailed() {
+	f.loading = false
+}
+
+// GetPR returns the pull request whose files are listed
+func (f *FilesModel) GetPR() *models.PullRequest {
+	return f.pr
+}
+
+// GetFiles returns all changed files
+func (f *FilesModel) GetFiles() []*models.FileChange {
+	return f.files
+}
+
+// GetThreads returns the PR's review threads
+func (f *FilesModel) GetThreads() []*models.Thread {
+	return f.threads
+}
+
+// GetVisibleFiles returns the files on the current page
+func (f *FilesModel) GetVisibleFiles() []*models.FileChange {
+	start, end := f.GetVisibleRange(len(f.files))
+	if start >= len(f.files) {
+		return []*models.FileChange{}
+	}
+	return f.files[start:end]
+}
+
+// GetSelectedIndex returns the index of the selected file in GetFiles, or -1
+func (f *FilesModel) GetSelectedIndex() int {
+	if f.cursor >= len(f.GetVisibleFiles()) {
+		return -1
+	}
+	return f.page*f.pageSize + f.cursor
+}
+
+// threadCount returns the number of review threads on a file
+func (f *FilesModel) threadCount(filename string) int {
+	count := 0
+	for _, thread := range f.threads {
+		if thread.FilePath == filename {
+			count++
+		}
+	}
+	return count
+}
+
+// Update handles messages and updates the view
+func (f *FilesModel) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			f.MoveCursorUp(len(f.GetVisibleFiles()))
+		case "down", "j":
+			f.MoveCursorDown(len(f.GetVisibleFiles()))
+		case "left", "h":
+			f.PreviousPage()
+		case "right", "l":
+			f.NextPage(len(f.files))
+		case "g":
+			f.GoToFirstPage()
+		case "G":
+			f.GoToLastPage(len(f.files))
+		}
+	}
+	return f, nil
+}
+
+// View renders the changed files on the current page
+func (f *FilesModel) View() string {
+	if f.width == 0 {
+		return "Loading..."
+	}
+
+	margin := lipgloss.NewStyle().MarginLeft(2)
+	var b strings.Builder
+	if f.pr != nil {
+		b.WriteString(margin.Copy().Bold(true).Render(fmt.Sprintf("#%d %s", f.pr.Number, f.pr.Title)) + "\n")
+	}
+	if f.loading {
+		b.WriteString(margin.Render(constants.HelpLoading) + "\n")
+		return b.String()
+	}
+
+	added := lipgloss.NewStyle().Foreground(lipgloss.Color(f.theme.Colors.DiffAdded))
+	removed := lipgloss.NewStyle().Foreground(lipgloss.Color(f.theme.Colors.DiffRemoved))
+
+	additions, deletions := 0, 0
+	for _, file := range f.files {
+		additions += file.Additions
+		deletions += file.Deletions
+	}
+	b.WriteString(margin.Render(fmt.Sprintf("%s • %s %s", f.GetPageInfo(),
+		added.Render(fmt.Sprintf("+%d", additions)), removed.Render(fmt.Sprintf("-%d", deletions)))) + "\n\n")
+
+	for i, file := range f.GetVisibleFiles() {
+		cursor := " "
+		style := f.theme.Styles.Unselected
+		if f.cursor == i {
+			cursor = ">"
+			style = f.theme.Styles.Selected
+		}
+
+		comments := ""
+		if count := f.threadCount(file.Filename); count > 0 {
+			comments = fmt.Sprintf(" %s%d", f.theme.Icons.Thread, count)
+		}
+
+		b.WriteString(style.Render(fmt.Sprintf("%s %s %s", cursor, fileStatusLetter(file.Status), file.DisplayName())) +
+			" " + added.Render(fmt.Sprintf("+%d", file.Additions)) +
+			" " + removed.Render(fmt.Sprintf("-%d", file.Deletions)) + comments + "\n")
+	}
+	return b.String()
+}
+
+// GetPageInfo returns pagination information
+func (f *FilesModel) GetPageInfo() string {
+	return f.BaseView.GetPageInfo(len(f.files), "files")
+}
+
+// fileStatusLetter abbreviates a file status the way git does
+func fileStatusLetter(status string) string {
+	switch status {
+	case "added":
+		return "A"
+	case "removed":
+		return "D"
+	case "renamed":
+		return "R"
+	case "copied":
+		return "C"
+	case "unchanged":
+		return " "
+	}
+	return "M"
+}
@@ -0,0 +1,363 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/will-wright-eng/gh-nav/internal/constants"
+	"github.com/will-wright-eng/gh-nav/internal/models"
+)
+
+// logChromeLines is the number of screen lines used around the log by the app
+// (title, status, help, debug) and the viewer's own header
+const logChromeLines = 8
+
+// logFocusContext is how many lines are kept above a focused line
+const logFocusContext = 2
+
+// LogViewerModel is a scrollable pager for an Actions job log with search and
+// error highlighting
+type LogViewerModel struct {
+	BaseView
+	jobID   int64
+	url     string
+	log     *models.JobLog
+	title   string
+	loading bool
+	offset  int // index of the first visible line
+
+	// Search state
+	searching bool   // typing a query
+	input     string // query being typed
+	query     string // last submitted query
+	matches   []int  // indexes of lines containing the query
+	match     int    // current entry in matches
+}
+
+// NewLogViewer creates a new log viewer
+func NewLogViewer() *LogViewerModel {
+	return &LogViewerModel{
+		BaseView: NewBaseView(constants.DefaultPageSize),
+	}
+}
+
+// SetLoading clears the viewer and shows a loading message for a job
+func (l *LogViewerModel) SetLoading(jobID int64, title, url string) {
+	l.jobID = jobID
+	l.url = url
+	l.log = nil
+	l.title = title
+	l.loading = true
+	l.offset = 0
+	l.searching = false
+	l.input = ""
+	l.query = ""
+	l.matches = nil
+	l.match = 0
+}
+
+// SetLog shows a downloaded log, scrolled to the failing step
+func (l *LogViewerModel) SetLog(log *models.JobLog) {
+	l.log = log
+	l.loading = false
+	if log.Name != "" {
+		l.title = log.Name
+	}
+	l.scrollTo(log.FocusLine())
+}
+
+// SetFailed stops the loading message after a failed download
+func (l *LogViewerModel) SetFailed() {
+	l.loading = false
+}
+
+// GetLog returns the log being shown
+func (l *LogViewerModel) GetLog() *models.JobLog {
+	return l.log
+}
+
+// GetJobID returns the ID of the job whose log is shown or loading
+func (l *LogViewerModel) GetJobID() int64 {
+	return l.jobID
+}
+
+// GetURL returns the web URL of the job
+func (l *LogViewerModel) GetURL() string {
+	if l.log != nil && l.log.URL != "" {
+		return l.log.URL
+	}
+	return l.url
+}
+
+// CapturesInput reports whether the viewer is taking keyboard input for a search
+func (l *LogViewerModel) CapturesInput() bool {
+	return l.searching
+}
+
+// lineCount returns the number of log lines
+func (l *LogViewerModel) lineCount() int {
+	if l.log == nil {
+		return 0
+	}
+	return len(l.log.Lines)
+}
+
+// visibleLines returns how many log lines fit on screen
+func (l *LogViewerModel) visibleLines() int {
+	if l.height-logChromeLines < 5 {
+		return 5
+	}
+	return l.height - logChromeLines
+}
+
+// scroll moves the view by delta lines, clamped to the log
+func (l *LogViewerModel) scroll(delta int) {
+	l.offset += delta
+	maxOffset := l.lineCount() - l.visibleLines()
+	if l.offset > maxOffset {
+		l.offset = maxOffset
+	}
+	if l.offset < 0 {
+		l.offset = 0
+	}
+}
+
+// scrollTo positions line i near the top of the screen
+func (l *LogViewerModel) scrollTo(i int) {
+	l.offset = 0
+	l.scroll(i - logFocusContext)
+}
+
+// jumpToError scrolls to the next (forward) or previous error line
+func (l *LogViewerModel) jumpToError(forward bool) {
+	if l.log == nil {
+		return
+	}
+	current := l.offset + logFocusContext
+	errorLines := l.log.ErrorLines()
+	if forward {
+		for _, i := range errorLines {
+			if i > current {
+				l.scrollTo(i)
+				return
+			}
+		}
+		return
+	}
+	for j := len(errorLines) - 1; j >= 0; j-- {
+		if errorLines[j] < current {
+			l.scrollTo(errorLines[j])
+			return
+		}
+	}
+}
+
+// search finds all lines containing the query (case-insensitive) and jumps to
+// the first match at or below the current position
+func (l *LogViewerModel) search(query string) {
+	l.query = query
+	l.matches = nil
+	l.match = 0
+	if query == "" || l.log == nil {
+		return
+	}
+
+	needle := strings.ToLower(query)
+	for i, line := range l.log.Lines {
+		if strings.Contains(strings.ToLower(line.Text), needle) {
+			l.matches = append(l.matches, i)
+		}
+	}
+
+	for j, i := range l.matches {
+		if i >= l.offset {
+			l.match = j
+			l.scrollTo(i)
+			return
+		}
+	}
+	if len(l.matches) > 0 {
+		l.scrollTo(l.matches[0])
+	}
+}
+
+// nextMatch moves to the next or previous search match, wrapping around
+func (l *LogViewerModel) nextMatch(forward bool) {
+	if len(l.matches) == 0 {
+		return
+	}
+	if forward {
+		l.match = (l.match + 1) % len(l.matches)
+	} else {
+		l.match = (l.match - 1 + len(l.matches)) % len(l.matches)
+	}
+	l.scrollTo(l.matches[l.match])
+}
+
+// Update handles messages and updates the view
+func (l *LogViewerModel) Update(msg tea.Msg) (View, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return l, nil
+	}
+
+	if l.searching {
+		switch keyMsg.Type {
+		case tea.KeyEnter:
+			l.searching = false
+			l.search(l.input)
+		case tea.KeyEsc:
+			l.searching = false
+		case tea.KeyBackspace:
+			if runes := []rune(l.input); len(runes) > 0 {
+				l.input = string(runes[:len(runes)-1])
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			l.input += string(keyMsg.Runes)
+		}
+		return l, nil
+	}
+
+	page := l.visibleLines()
+	switch keyMsg.String() {
+	case "up", "k":
+		l.scroll(-1)
+	case "down", "j":
+		l.scroll(1)
+	case "left", "h", "pgup":
+		l.scroll(-page)
+	case "right", "l", "pgdown", " ":
+		l.scroll(page)
+	case "g", "home":
+		l.offset = 0
+	case "G", "end":
+		l.scroll(l.lineCount())
+	case "/":
+		l.searching = true
+		l.input = ""
+	case "n":
+		l.nextMatch(true)
+	case "N":
+		l.nextMatch(false)
+	case "e":
+		l.jumpToError(true)
+	case "E":
+		l.jumpToError(false)
+	}
+	return l, nil
+}
+
+// View renders the visible part of the log
+func (l *LogViewerModel) View() string {
+	if l.width == 0 {
+		return "Loading..."
+	}
+
+	margin := lipgloss.NewStyle().MarginLeft(2)
+	var b strings.Builder
+	b.WriteString(margin.Copy().Bold(true).Render(l.title) + "\n")
+
+	if l.loading {
+		b.WriteString(margin.Render(constants.MsgLoadingLog) + "\n")
+		return b.String()
+	}
+	if l.log == nil {
+		return b.String()
+	}
+
+	b.WriteString(margin.Copy().Foreground(lipgloss.Color(constants.ColorMuted)).Render(l.summary()) + "\n")
+
+	lineNumber := lipgloss.NewStyle().Foreground(lipgloss.Color(constants.ColorSecondary))
+	width := l.width - 10
+	end := l.offset + l.visibleLines()
+	if end > len(l.log.Lines) {
+		end = len(l.log.Lines)
+	}
+	for i := l.offset; i < end; i++ {
+		line := l.log.Lines[i]
+		text := l.renderLine(line, truncateRunes(line.Text, width))
+		b.WriteString(fmt.Sprintf("  %s %s\n", lineNumber.Render(fmt.Sprintf("%5d", i+1)), text))
+	}
+
+	if l.searching {
+		b.WriteString(margin.Render("/"+l.input+"█") + "\n")
+	}
+	return b.String()
+}
+
+// summary describes the job result, the failed step and the position in the log
+func (l *LogViewerModel) summary() string {
+	parts := []string{l.log.Conclusion}
+	if l.log.Conclusion == "" {
+		parts[0] = l.log.Status
+	}
+	if step := l.log.FailedStep(); step != nil {
+		parts = append(parts, fmt.Sprintf("failed step: %s", step.Name))
+	}
+
+	end := l.offset + l.visibleLines()
+	if end > len(l.log.Lines) {
+		end = len(l.log.Lines)
+	}
+	parts = append(parts, fmt.Sprintf("lines %d-%d of %d", l.offset+1, end, len(l.log.Lines)))
+
+	if l.query != "" {
+		if len(l.matches) == 0 {
+			parts = append(parts, fmt.Sprintf("no matches for %q", l.query))
+		} else {
+			parts = append(parts, fmt.Sprintf("match %d/%d for %q", l.match+1, len(l.matches), l.query))
+		}
+	}
+	return strings.Join(parts, " • ")
+}
+
+// renderLine styles a log line by kind and highlights search matches
+func (l *LogViewerModel) renderLine(line models.LogLine, text string) string {
+	style := lipgloss.NewStyle()
+	switch line.Kind {
+	case models.LogLineError:
+		style = style.Foreground(lipgloss.Color(constants.ColorError))
+	case models.LogLineWarning:
+		style = style.Foreground(lipgloss.Color(constants.ColorWarning))
+	case models.LogLineGroup:
+		style = style.Bold(true).Foreground(lipgloss.Color(constants.ColorInfo))
+		text = "▸ " + text
+	case models.LogLineCommand:
+		style = style.Foreground(lipgloss.Color(constants.ColorMuted))
+	}
+
+	if l.query == "" {
+		return style.Render(text)
+	}
+
+	// Render the text in segments so matches get a highlighted background
+	highlight := style.Copy().Reverse(true)
+	lower := strings.ToLower(text)
+	needle := strings.ToLower(l.query)
+	var b strings.Builder
+	for {
+		i := strings.Index(lower, needle)
+		if i < 0 || len(lower) != len(text) {
+			b.WriteString(style.Render(text))
+			return b.String()
+		}
+		b.WriteString(style.Render(text[:i]))
+		b.WriteString(highlight.Render(text[i : i+len(needle)]))
+		text = text[i+len(needle):]
+		lower = lower[i+len(needle):]
+	}
+}
+
+// truncateRunes shortens s to at most max runes
+func truncateRunes(s string, max int) string {
+	if max <= 0 {
+		return s
+	}
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max-1]) + "…"
+}
@@ -0,0 +1,81 @@
+package views
+
+import (
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/will-wright-eng/gh-nav/internal/models"
+)
+
+// testLog builds a 100-line log with an error on line 60
+func testLog() *models.JobLog {
+	log := &models.JobLog{Name: "test"}
+	for i := 0; i < 100; i++ {
+		line := models.LogLine{Kind: models.LogLineNormal, Text: fmt.Sprintf("line %d", i)}
+		if i == 50 {
+			line = models.LogLine{Kind: models.LogLineGroup, Text: "Run go test"}
+		}
+		if i == 60 {
+			line = models.LogLine{Kind: models.LogLineError, Text: "Error: boom", Annotation: true}
+		}
+		log.Lines = append(log.Lines, line)
+	}
+	return log
+}
+
+func keyRunes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestLogViewerFocusesFailure(t *testing.T) {
+	viewer := NewLogViewer()
+	viewer.SetSize(80, 28) // 20 visible lines
+	viewer.SetLoading(1, "test", "")
+	viewer.SetLog(testLog())
+
+	if viewer.offset != 50-logFocusContext {
+		t.Errorf("Expected offset %d, got %d", 50-logFocusContext, viewer.offset)
+	}
+
+	viewer.Update(keyRunes("G"))
+	if viewer.offset != 80 {
+		t.Errorf("Expected offset 80 at the bottom, got %d", viewer.offset)
+	}
+
+	viewer.Update(keyRunes("E"))
+	if viewer.offset != 60-logFocusContext {
+		t.Errorf("Expected previous error to be focused, got offset %d", viewer.offset)
+	}
+}
+
+func TestLogViewerSearch(t *testing.T) {
+	viewer := NewLogViewer()
+	viewer.SetSize(80, 28)
+	viewer.SetLoading(1, "test", "")
+	viewer.SetLog(testLog())
+	viewer.Update(keyRunes("g"))
+
+	viewer.Update(keyRunes("/"))
+	if !viewer.CapturesInput() {
+		t.Fatal("Expected the viewer to capture input while searching")
+	}
+	viewer.Update(keyRunes("line 9"))
+	viewer.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if viewer.CapturesInput() {
+		t.Error("Expected enter to end the search input")
+	}
+	// "line 9" and "line 90".."line 99"
+	if len(viewer.matches) != 11 {
+		t.Fatalf("Expected 11 matches, got %d", len(viewer.matches))
+	}
+	if viewer.offset != 9-logFocusContext {
+		t.Errorf("Expected first match to be focused, got offset %d", viewer.offset)
+	}
+
+	viewer.Update(keyRunes("N"))
+	if viewer.matches[viewer.match] != 99 {
+		t.Errorf("Expected N to wrap to the last match, got line %d", viewer.matches[viewer.match])
+	}
+}
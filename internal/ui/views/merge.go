@@ -0,0 +1,397 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/will-wright-eng/gh-nav/internal/constants"
+	"github.com/will-wright-eng/gh-nav/internal/models"
+	"github.com/will-wright-eng/gh-nav/internal/ui/theme"
+)
+
+// Merge dialog stages
+const (
+	mergeLoading = iota
+	mergeBlocked
+	mergeEditing
+	mergeConfirming
+	mergeSending
+)
+
+// Merge form fields, in tab order
+const (
+	mergeFieldMethod = iota
+	mergeFieldTitle
+	mergeFieldBody
+	mergeFieldDelete
+)
+
+// MergeSubmitMsg is sent when the user confirms a merge
+type MergeSubmitMsg struct {
+	PR      *models.PullRequest
+	Info    *models.MergeInfo
+	Request models.MergeRequest
+}
+
+// MergeCancelMsg is sent when the user leaves the merge dialog without merging
+type MergeCancelMsg struct{}
+
+// MergeModel lets the user pick a merge method and commit message, and explains
+// why a merge isn't possible when GitHub would reject it
+type MergeModel struct {
+	BaseView
+	theme     *theme.Theme
+	pr        *models.PullRequest
+	info      *models.MergeInfo
+	readiness models.MergeReadiness
+	stage     int
+	problem   string
+
+	method       int // index into info.Methods
+	deleteBranch bool
+	focus        int
+	title        textinput.Model
+	body         textarea.Model
+}
+
+// NewMerge creates a new merge dialog
+func NewMerge(t *theme.Theme) *MergeModel {
+	title := textinput.New()
+	title.Prompt = ""
+	title.CharLimit = 0
+
+	body := textarea.New()
+	body.ShowLineNumbers = false
+	body.CharLimit = 0
+	body.SetHeight(6)
+
+	return &MergeModel{
+		BaseView: NewBaseView(constants.DefaultPageSize),
+		theme:    t,
+		title:    title,
+		body:     body,
+	}
+}
+
+// SetLoading shows the dialog for a PR while its merge status is fetched
+func (m *MergeModel) SetLoading(pr *models.PullRequest) {
+	m.pr = pr
+	m.info = nil
+	m.readiness = models.MergeReadiness{}
+	m.stage = mergeLoading
+	m.problem = ""
+}
+
+// SetInfo fills in the form from GitHub's merge status, or lists what blocks the merge
+func (m *MergeModel) SetInfo(info *models.MergeInfo) tea.Cmd {
+	m.info = info
+	m.readiness = info.Readiness()
+	if m.readiness.Blocked() {
+		m.stage = mergeBlocked
+		return nil
+	}
+
+	m.stage = mergeEditing
+	m.method = 0
+	m.deleteBranch = false
+	title, body := info.MergeMessage(m.pr, m.currentMethod())
+	m.title.SetValue(title)
+	m.body.SetValue(body)
+	return m.setFocus(mergeFieldMethod)
+}
+
+// SetFailed shows why the merge status couldn't be loaded, or the merge failed
+func (m *MergeModel) SetFailed(err error) {
+	if m.stage == mergeLoading {
+		m.stage = mergeBlocked
+	} else {
+		m.stage = mergeEditing
+	}
+	m.problem = err.Error()
+}
+
+// GetPR returns the pull request being merged
+func (m *MergeModel) GetPR() *models.PullRequest {
+	return m.pr
+}
+
+// IsConfirming reports whether the dialog is waiting for a yes/no answer
+func (m *MergeModel) IsConfirming() bool {
+	return m.stage == mergeConfirming
+}
+
+// CapturesInput reports whether the form is taking keyboard input; while loading
+// or showing blockers the usual navigation keys apply
+func (m *MergeModel) CapturesInput() bool {
+	return m.stage == mergeEditing || m.stage == mergeConfirming || m.stage == mergeSending
+}
+
+// SetSize updates the view dimensions and resizes the text fields
+func (m *MergeModel) SetSize(width, height int) {
+	m.BaseView.SetSize(width, height)
+	m.title.Width = width - 8
+	m.body.SetWidth(width - 6)
+}
+
+// currentMethod returns the selected merge method
+func (m *MergeModel) currentMethod() string {
+	if m.info == nil || len(m.info.Methods) == 0 {
+		return ""
+	}
+	return m.info.Methods[m.method]
+}
+
+// canDeleteBranch reports whether the dialog should offer to delete the head
+// branch: only when it's in the base repository (not for forks, nor when the
+// head repository is gone and the name could match an unrelated base branch),
+// not when GitHub deletes it anyway, and not for auto-merge (which completes
+// later, without us)
+func (m *MergeModel) canDeleteBranch() bool {
+	return m.pr.IsSameRepository() && m.pr.HeadRef != "" && !m.info.DeleteBranchOnMerge && !m.readiness.CanAuto
+}
+
+// hasMessage reports whether the selected method creates a commit with a message
+func (m *MergeModel) hasMessage() bool {
+	return m.currentMethod() != models.MergeMethodRebase
+}
+
+// fields returns the form fields available for the selected method
+func (m *MergeModel) fields() []int {
+	fields := []int{mergeFieldMethod}
+	if m.hasMessage() {
+		fields = append(fields, mergeFieldTitle, mergeFieldBody)
+	}
+	if m.canDeleteBranch() {
+		fields = append(fields, mergeFieldDelete)
+	}
+	return fields
+}
+
+// setFocus moves keyboard focus to a field
+func (m *MergeModel) setFocus(field int) tea.Cmd {
+	m.focus = field
+	m.title.Blur()
+	m.body.Blur()
+	switch field {
+	case mergeFieldTitle:
+		return m.title.Focus()
+	case mergeFieldBody:
+		return m.body.Focus()
+	}
+	return nil
+}
+
+// moveFocus moves focus forward or back through the available fields
+func (m *MergeModel) moveFocus(delta int) tea.Cmd {
+	fields := m.fields()
+	current := 0
+	for i, field := range fields {
+		if field == m.focus {
+			current = i
+		}
+	}
+	next := (current + delta + len(fields)) % len(fields)
+	return m.setFocus(fields[next])
+}
+
+// cycleMethod selects the next or previous allowed method, replacing the commit
+// message with the new method's default unless the user edited it
+func (m *MergeModel) cycleMethod(delta int) {
+	methods := m.info.Methods
+	oldTitle, oldBody := m.info.MergeMessage(m.pr, m.currentMethod())
+	m.method = (m.method + delta + len(methods)) % len(methods)
+
+	if m.title.Value() == oldTitle && m.body.Value() == oldBody {
+		title, body := m.info.MergeMessage(m.pr, m.currentMethod())
+		m.title.SetValue(title)
+		m.body.SetValue(body)
+	}
+}
+
+// request returns the merge the user asked for
+func (m *MergeModel) request() models.MergeRequest {
+	req := models.MergeRequest{
+		Method:       m.currentMethod(),
+		DeleteBranch: m.deleteBranch && m.canDeleteBranch(),
+		Auto:         m.readiness.CanAuto,
+	}
+	if m.hasMessage() {
+		req.Title = strings.TrimSpace(m.title.Value())
+		req.Body = strings.TrimSpace(m.body.Value())
+	}
+	return req
+}
+
+// Update handles messages and updates the view
+func (m *MergeModel) Update(msg tea.Msg) (View, tea.Cmd) {
+	keyMsg, isKey := msg.(tea.KeyMsg)
+	if !isKey {
+		// Cursor blinking in the focused text field
+		var cmd tea.Cmd
+		if m.focus == mergeFieldTitle {
+			m.title, cmd = m.title.Update(msg)
+		} else if m.focus == mergeFieldBody {
+			m.body, cmd = m.body.Update(msg)
+		}
+		return m, cmd
+	}
+
+	switch m.stage {
+	case mergeConfirming:
+		switch keyMsg.String() {
+		case "y", "Y", "enter":
+			m.stage = mergeSending
+			req := m.request()
+			pr, info := m.pr, m.info
+			return m, func() tea.Msg { return MergeSubmitMsg{PR: pr, Info: info, Request: req} }
+		case "n", "N", "esc":
+			m.stage = mergeEditing
+			return m, m.setFocus(m.focus)
+		}
+		return m, nil
+	case mergeEditing:
+		return m.updateEditing(keyMsg)
+	}
+	return m, nil
+}
+
+// updateEditing handles keys while the form is active
+func (m *MergeModel) updateEditing(msg tea.KeyMsg) (View, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		return m, func() tea.Msg { return MergeCancelMsg{} }
+	case "ctrl+s":
+		if m.hasMessage() && strings.TrimSpace(m.title.Value()) == "" {
+			m.problem = "Commit title cannot be empty"
+			return m, nil
+		}
+		m.problem = ""
+		m.stage = mergeConfirming
+		m.title.Blur()
+		m.body.Blur()
+		return m, nil
+	case "tab":
+		return m, m.moveFocus(1)
+	case "shift+tab":
+		return m, m.moveFocus(-1)
+	}
+
+	var cmd tea.Cmd
+	switch m.focus {
+	case mergeFieldMethod:
+		switch msg.String() {
+		case "left", "h":
+			m.cycleMethod(-1)
+		case "right", "l", " ":
+			m.cycleMethod(1)
+		}
+	case mergeFieldDelete:
+		if msg.String() == " " {
+			m.deleteBranch = !m.deleteBranch
+		}
+	case mergeFieldTitle:
+		m.title, cmd = m.title.Update(msg)
+	case mergeFieldBody:
+		m.body, cmd = m.body.Update(msg)
+	}
+	return m, cmd
+}
+
+// View renders the merge dialog
+func (m *MergeModel) View() string {
+	if m.width == 0 {
+		return "Loading..."
+	}
+	if m.pr == nil {
+		return ""
+	}
+
+	margin := m.theme.Styles.Margin
+	var b strings.Builder
+	b.WriteString(margin.Copy().Bold(true).Render(fmt.Sprintf("Merge #%d %s", m.pr.Number, m.pr.Title)) + "\n")
+	if m.pr.HeadRef != "" && m.pr.BaseRef != "" {
+		b.WriteString(margin.Render(fmt.Sprintf("%s → %s", m.pr.HeadRef, m.pr.BaseRef)) + "\n")
+	}
+	b.WriteString("\n")
+
+	switch m.stage {
+	case mergeLoading:
+		b.WriteString(margin.Render(constants.HelpLoading) + "\n")
+		return b.String()
+	case mergeBlocked:
+		if m.problem != "" {
+			b.WriteString(m.theme.Styles.Error.Render(m.problem) + "\n")
+			return b.String()
+		}
+		b.WriteString(m.theme.Styles.Error.Render("This pull request can't be merged:") + "\n")
+		for _, reason := range m.readiness.Blockers {
+			b.WriteString(margin.Render("• "+reason) + "\n")
+		}
+		return b.String()
+	}
+
+	selected := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(m.theme.Colors.Primary))
+	label := func(field int, text string) string {
+		if m.focus == field && m.stage == mergeEditing {
+			return selected.Render("> " + text)
+		}
+		return "  " + text
+	}
+
+	var methods []string
+	for i, method := range m.info.Methods {
+		name := models.MergeMethodLabel(method)
+		if i == m.method {
+			name = selected.Render("[" + name + "]")
+		}
+		methods = append(methods, name)
+	}
+	b.WriteString(margin.Render(label(mergeFieldMethod, "Method: ")+strings.Join(methods, "  ")) + "\n\n")
+
+	if m.hasMessage() {
+		b.WriteString(margin.Render(label(mergeFieldTitle, "Commit title")) + "\n")
+		b.WriteString(margin.Render("  "+m.title.View()) + "\n")
+		b.WriteString(margin.Render(label(mergeFieldBody, "Commit message")) + "\n")
+		b.WriteString(margin.Render(m.body.View()) + "\n\n")
+	}
+
+	switch {
+	case m.canDeleteBranch():
+		box := "[ ]"
+		if m.deleteBranch {
+			box = "[x]"
+		}
+		b.WriteString(margin.Render(label(mergeFieldDelete, fmt.Sprintf("%s Delete branch %s", box, m.pr.HeadRef))) + "\n")
+	case m.info.DeleteBranchOnMerge && m.pr.IsSameRepository():
+		b.WriteString(margin.Render("  The repository deletes the head branch after merging") + "\n")
+	}
+
+	if m.readiness.CanAuto {
+		b.WriteString(m.theme.Styles.Info.Render("Checks are still running: auto-merge will merge this pull request once they pass.") + "\n")
+	}
+	if m.info.AutoMergeEnabled {
+		b.WriteString(m.theme.Styles.Info.Render("Auto-merge is already enabled for this pull request.") + "\n")
+	}
+	for _, warning := range m.readiness.Warnings {
+		b.WriteString(m.theme.Styles.Warning.Render("! "+warning) + "\n")
+	}
+
+	switch m.stage {
+	case mergeConfirming:
+		action := fmt.Sprintf("%s #%d", models.MergeMethodLabel(m.currentMethod()), m.pr.Number)
+		if m.readiness.CanAuto {
+			action = fmt.Sprintf("Enable auto-merge (%s) for #%d", strings.ToLower(models.MergeMethodLabel(m.currentMethod())), m.pr.Number)
+		}
+		b.WriteString("\n" + m.theme.Styles.Warning.Render(action+"? (y/n)") + "\n")
+	case mergeSending:
+		b.WriteString("\n" + m.theme.Styles.Info.Render("Merging...") + "\n")
+	}
+	if m.problem != "" {
+		b.WriteString(m.theme.Styles.Error.Render(m.problem) + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,109 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/will-wright-eng/gh-nav/internal/models"
+	"github.com/will-wright-eng/gh-nav/internal/ui/theme"
+)
+
+func newTestMerge(info *models.MergeInfo) *MergeModel {
+	merge := NewMerge(&theme.DefaultTheme)
+	merge.SetSize(100, 40)
+	merge.SetLoading(&models.PullRequest{Number: 3, RepoName: "acme/app", HeadRepo: "acme/app", HeadRef: "feature"})
+	merge.SetInfo(info)
+	return merge
+}
+
+func TestMergeCyclesAllowedMethods(t *testing.T) {
+	merge := newTestMerge(&models.MergeInfo{
+		Title: "Feature", State: "OPEN", Mergeable: "MERGEABLE", MergeStateStatus: "CLEAN",
+		Methods: []string{models.MergeMethodSquash, models.MergeMethodRebase},
+	})
+
+	if merge.currentMethod() != models.MergeMethodSquash || merge.title.Value() != "Feature (#3)" {
+		t.Fatalf("Expected squash pre-filled, got %s %q", merge.currentMethod(), merge.title.Value())
+	}
+
+	merge.Update(keyRunes("l"))
+	if merge.currentMethod() != models.MergeMethodRebase {
+		t.Errorf("Expected rebase after cycling, got %s", merge.currentMethod())
+	}
+	if merge.hasMessage() {
+		t.Error("Expected rebase to have no commit message")
+	}
+
+	merge.Update(keyRunes("l"))
+	if merge.currentMethod() != models.MergeMethodSquash {
+		t.Errorf("Expected cycling to wrap to squash, got %s", merge.currentMethod())
+	}
+}
+
+func TestMergeSubmitsAfterConfirmation(t *testing.T) {
+	merge := newTestMerge(&models.MergeInfo{
+		Title: "Feature", State: "OPEN", Mergeable: "MERGEABLE", MergeStateStatus: "CLEAN",
+		Methods: []string{models.MergeMethodMerge},
+	})
+
+	// Tab through to the delete-branch toggle and switch it on
+	for i := 0; i < 3; i++ {
+		merge.Update(tea.KeyMsg{Type: tea.KeyTab})
+	}
+	merge.Update(keyRunes(" "))
+
+	merge.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if !merge.IsConfirming() {
+		t.Fatal("Expected ctrl+s to ask for confirmation")
+	}
+	_, cmd := merge.Update(keyRunes("y"))
+	if cmd == nil {
+		t.Fatal("Expected y to submit the merge")
+	}
+	submit, ok := cmd().(MergeSubmitMsg)
+	if !ok {
+		t.Fatalf("Expected MergeSubmitMsg, got %T", cmd())
+	}
+	if submit.Request.Method != models.MergeMethodMerge || !submit.Request.DeleteBranch || submit.Request.Auto {
+		t.Errorf("Unexpected request %+v", submit.Request)
+	}
+}
+
+func TestMergeShowsBlockers(t *testing.T) {
+	merge := newTestMerge(&models.MergeInfo{
+		State: "OPEN", Mergeable: "MERGEABLE", MergeStateStatus: "BLOCKED", ReviewDecision: "REVIEW_REQUIRED",
+		Methods: []string{models.MergeMethodMerge},
+	})
+
+	if merge.CapturesInput() {
+		t.Error("Expected a blocked merge not to capture input")
+	}
+	if _, cmd := merge.Update(tea.KeyMsg{Type: tea.KeyCtrlS}); cmd != nil {
+		t.Error("Expected no merge to be possible while blocked")
+	}
+}
+
+func TestMergeKeepsBranchOfDeletedHeadRepository(t *testing.T) {
+	info := &models.MergeInfo{
+		Title: "Feature", State: "OPEN", Mergeable: "MERGEABLE", MergeStateStatus: "CLEAN",
+		Methods: []string{models.MergeMethodMerge},
+	}
+	merge := newTestMerge(info)
+	if !merge.canDeleteBranch() {
+		t.Fatal("Expected deletion offered for a branch in the base repository")
+	}
+
+	// The fork is gone: "feature" may name an unrelated branch of the base repository
+	merge.SetLoading(&models.PullRequest{Number: 3, RepoName: "acme/app", HeadRef: "feature"})
+	merge.SetInfo(info)
+	if merge.canDeleteBranch() || strings.Contains(merge.View(), "Delete branch") {
+		t.Error("Expected no branch deletion when the head repository was deleted")
+	}
+
+	info.DeleteBranchOnMerge = true
+	merge.SetInfo(info)
+	if strings.Contains(merge.View(), "deletes the head branch") {
+		t.Error("Expected no deleted-on-merge hint when the head repository was deleted")
+	}
+}
@@ -4,16 +4,21 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/will-wright-eng/gh-nav/internal/constants"
 )
 
-// OwnerListModel represents the owner selection view
+// OwnerListModel represents the owner selection view. Owners whose
+// repositories are still loading are listed with a spinner and grow live.
 type OwnerListModel struct {
 	BaseView
 	owners     []string
 	repoGroups map[string][]string
+	loading    map[string]bool
+	failed     map[string]bool
+	spinner    spinner.Model
 }
 
 // NewOwnerList creates a new owner list view
@@ -22,6 +27,9 @@ func NewOwnerList(pageSize int) *OwnerListModel {
 		BaseView:   NewBaseView(pageSize),
 		owners:     []string{},
 		repoGroups: make(map[string][]string),
+		loading:    make(map[string]bool),
+		failed:     make(map[string]bool),
+		spinner:    spinner.New(spinner.WithSpinner(spinner.Dot)),
 	}
 }
 
@@ -31,12 +39,47 @@ func (o *OwnerListModel) SetData(repoGroups map[string][]string) {
 	o.owners = o.getOwners()
 }
 
-// getOwners returns a sorted list of owners
+// StartLoading lists owners whose repositories are about to load, replacing
+// any earlier loading state, and starts the spinner
+func (o *OwnerListModel) StartLoading(owners []string) tea.Cmd {
+	o.loading = make(map[string]bool, len(owners))
+	o.failed = make(map[string]bool)
+	for _, owner := range owners {
+		o.loading[owner] = true
+	}
+	o.owners = o.getOwners()
+	return o.spinner.Tick
+}
+
+// FinishLoading marks an owner's repositories as loaded, or as failed to load
+func (o *OwnerListModel) FinishLoading(owner string, err error) {
+	delete(o.loading, owner)
+	if err != nil {
+		o.failed[owner] = true
+	}
+}
+
+// IsLoading reports whether any owner's repositories are still loading
+func (o *OwnerListModel) IsLoading() bool {
+	return len(o.loading) > 0
+}
+
+// IsOwnerLoading reports whether an owner's repositories are still loading
+func (o *OwnerListModel) IsOwnerLoading(owner string) bool {
+	return o.loading[owner]
+}
+
+// getOwners returns a sorted list of owners, including those still loading
 func (o *OwnerListModel) getOwners() []string {
 	var owners []string
 	for owner := range o.repoGroups {
 		owners = append(owners, owner)
 	}
+	for owner := range o.loading {
+		if _, listed := o.repoGroups[owner]; !listed {
+			owners = append(owners, owner)
+		}
+	}
 	sort.Strings(owners)
 	return owners
 }
@@ -59,6 +102,16 @@ func (o *OwnerListModel) GetSelectedOwner() string {
 	return ""
 }
 
+// SelectOwner moves the cursor to the given owner if it is in the list
+func (o *OwnerListModel) SelectOwner(owner string) {
+	for i, candidate := range o.owners {
+		if candidate == owner {
+			o.SelectIndex(i)
+			return
+		}
+	}
+}
+
 // GetRepoCount returns the number of repositories for an owner
 func (o *OwnerListModel) GetRepoCount(owner string) int {
 	if repos, exists := o.repoGroups[owner]; exists {
@@ -85,6 +138,13 @@ func (o *OwnerListModel) Update(msg tea.Msg) (View, tea.Cmd) {
 		case "G":
 			o.GoToLastPage(len(o.owners))
 		}
+	case spinner.TickMsg:
+		// Let the spinner stop once everything has loaded
+		if o.IsLoading() {
+			var cmd tea.Cmd
+			o.spinner, cmd = o.spinner.Update(msg)
+			return o, cmd
+		}
 	}
 	return o, nil
 }
@@ -97,6 +157,7 @@ func (o *OwnerListModel) View() string {
 
 	list := ""
 	visibleOwners := o.GetVisibleOwners()
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color(constants.ColorMuted))
 
 	for i, owner := range visibleOwners {
 		cursor := " "
@@ -110,7 +171,14 @@ func (o *OwnerListModel) View() string {
 		}
 
 		repoCount := o.GetRepoCount(owner)
-		list += style.Render(fmt.Sprintf("%s %s %s (%d repos)", cursor, constants.IconOrganization, owner, repoCount)) + "\n"
+		row := style.Render(fmt.Sprintf("%s %s %s (%d repos)", cursor, constants.IconOrganization, owner, repoCount))
+		switch {
+		case o.loading[owner]:
+			row += " " + o.spinner.View() + muted.Render("loading")
+		case o.failed[owner]:
+			row += muted.Render(" couldn't list all repositories")
+		}
+		list += row + "\n"
 	}
 
 	return list
@@ -0,0 +1,247 @@
+package views
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/will-wright-eng/gh-nav/internal/constants"
+	"github.com/will-wright-eng/gh-nav/internal/models"
+	"github.com/will-wright-eng/gh-nav/internal/ui/theme"
+)
+
+// PickerApplyMsg is sent when the user applies a new selection
+type PickerApplyMsg struct {
+	PR     *models.PullRequest
+	Field  string
+	Before []string
+	After  []string
+}
+
+// PickerCancelMsg is sent when the user leaves the picker without applying
+type PickerCancelMsg struct{}
+
+// PickerModel is a filterable multi-select list for a PR's labels, assignees or
+// reviewers
+type PickerModel struct {
+	BaseView
+	theme    *theme.Theme
+	pr       *models.PullRequest
+	field    string
+	options  []string
+	selected map[string]bool
+	filter   string
+	loading  bool
+}
+
+// NewPicker creates a new multi-select picker
+func NewPicker(pageSize int, t *theme.Theme) *PickerModel {
+	return &PickerModel{
+		BaseView: NewBaseView(pageSize),
+		theme:    t,
+		selected: make(map[string]bool),
+	}
+}
+
+// SetLoading opens the picker for a PR field while its options are fetched,
+// with the field's current values selected
+func (p *PickerModel) SetLoading(pr *models.PullRequest, field string) {
+	p.pr = pr
+	p.field = field
+	p.options = nil
+	p.filter = ""
+	p.loading = true
+	p.page = 0
+	p.cursor = 0
+	p.selected = make(map[string]bool)
+	for _, value := range pr.FieldValues(field) {
+		p.selected[value] = true
+	}
+}
+
+// SetOptions shows the loaded options; current values GitHub didn't list (e.g.
+// a reviewer who lost access) are kept so they can be removed
+func (p *PickerModel) SetOptions(options []string) {
+	seen := make(map[string]bool, len(options))
+	for _, option := range options {
+		seen[option] = true
+	}
+	for _, value := range p.pr.FieldValues(p.field) {
+		if !seen[value] {
+			options = append(options, value)
+		}
+	}
+	sort.SliceStable(options, func(i, j int) bool {
+		return strings.ToLower(options[i]) < strings.ToLower(options[j])
+	})
+	p.options = options
+	p.loading = false
+}
+
+// SetFailed stops the loading message after a failed fetch
+func (p *PickerModel) SetFailed() {
+	p.loading = false
+}
+
+// GetPR returns the pull request being edited
+func (p *PickerModel) GetPR() *models.PullRequest {
+	return p.pr
+}
+
+// GetField returns the field being edited
+func (p *PickerModel) GetField() string {
+	return p.field
+}
+
+// CapturesInput reports that the picker takes typed text as its filter
+func (p *PickerModel) CapturesInput() bool {
+	return p.pr != nil && !p.loading
+}
+
+// GetFilteredOptions returns the options matching the filter
+func (p *PickerModel) GetFilteredOptions() []string {
+	if p.filter == "" {
+		return p.options
+	}
+	filter := strings.ToLower(p.filter)
+	var matches []string
+	for _, option := range p.options {
+		if strings.Contains(strings.ToLower(option), filter) {
+			matches = append(matches, option)
+		}
+	}
+	return matches
+}
+
+// GetVisibleOptions returns the filtered options on the current page
+func (p *PickerModel) GetVisibleOptions() []string {
+	options := p.GetFilteredOptions()
+	start, end := p.GetVisibleRange(len(options))
+	if start >= len(options) {
+		return []string{}
+	}
+	return options[start:end]
+}
+
+// Selection returns the selected values: the field's current values in their
+// original order, followed by newly selected ones in option order
+func (p *PickerModel) Selection() []string {
+	var values []string
+	for _, value := range p.pr.FieldValues(p.field) {
+		if p.selected[value] {
+			values = append(values, value)
+		}
+	}
+	current := make(map[string]bool, len(values))
+	for _, value := range values {
+		current[value] = true
+	}
+	for _, option := range p.options {
+		if p.selected[option] && !current[option] {
+			values = append(values, option)
+		}
+	}
+	return values
+}
+
+// toggle selects or deselects the option under the cursor
+func (p *PickerModel) toggle() {
+	visible := p.GetVisibleOptions()
+	if p.cursor < len(visible) {
+		option := visible[p.cursor]
+		p.selected[option] = !p.selected[option]
+	}
+}
+
+// setFilter changes the filter and returns to the first match
+func (p *PickerModel) setFilter(filter string) {
+	p.filter = filter
+	p.page = 0
+	p.cursor = 0
+}
+
+// Update handles messages and updates the view
+func (p *PickerModel) Update(msg tea.Msg) (View, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || p.pr == nil {
+		return p, nil
+	}
+
+	options := p.GetFilteredOptions()
+	switch keyMsg.Type {
+	case tea.KeyUp:
+		p.MoveCursorUp(len(p.GetVisibleOptions()))
+	case tea.KeyDown:
+		p.MoveCursorDown(len(p.GetVisibleOptions()))
+	case tea.KeyLeft, tea.KeyPgUp:
+		p.PreviousPage()
+	case tea.KeyRight, tea.KeyPgDown:
+		p.NextPage(len(options))
+	case tea.KeySpace, tea.KeyTab:
+		p.toggle()
+	case tea.KeyEnter:
+		pr, field := p.pr, p.field
+		before := append([]string(nil), pr.FieldValues(field)...)
+		after := p.Selection()
+		return p, func() tea.Msg { return PickerApplyMsg{PR: pr, Field: field, Before: before, After: after} }
+	case tea.KeyEsc:
+		if p.filter != "" {
+			p.setFilter("")
+			return p, nil
+		}
+		return p, func() tea.Msg { return PickerCancelMsg{} }
+	case tea.KeyBackspace:
+		if p.filter != "" {
+			runes := []rune(p.filter)
+			p.setFilter(string(runes[:len(runes)-1]))
+		}
+	case tea.KeyRunes:
+		p.setFilter(p.filter + string(keyMsg.Runes))
+	}
+	return p, nil
+}
+
+// View renders the picker
+func (p *PickerModel) View() string {
+	if p.width == 0 {
+		return "Loading..."
+	}
+	if p.pr == nil {
+		return ""
+	}
+
+	margin := p.theme.Styles.Margin
+	var b strings.Builder
+	b.WriteString(margin.Copy().Bold(true).Render(fmt.Sprintf("Edit %s of #%d %s", p.field, p.pr.Number, p.pr.Title)) + "\n")
+	if p.loading {
+		b.WriteString(margin.Render(constants.HelpLoading) + "\n")
+		return b.String()
+	}
+
+	b.WriteString(margin.Render(fmt.Sprintf("Filter: %s▏ • %s • %d selected", p.filter, p.GetPageInfo(), len(p.Selection()))) + "\n\n")
+
+	visible := p.GetVisibleOptions()
+	if len(visible) == 0 {
+		b.WriteString(margin.Render(constants.HelpNoData) + "\n")
+	}
+	for i, option := range visible {
+		cursor := " "
+		style := p.theme.Styles.Unselected
+		if p.cursor == i {
+			cursor = ">"
+			style = p.theme.Styles.Selected
+		}
+		box := "[ ]"
+		if p.selected[option] {
+			box = "[x]"
+		}
+		b.WriteString(style.Render(fmt.Sprintf("%s %s %s", cursor, box, option)) + "\n")
+	}
+	return b.String()
+}
+
+// GetPageInfo returns pagination information
+func (p *PickerModel) GetPageInfo() string {
+	return p.BaseView.GetPageInfo(len(p.GetFilteredOptions()), p.field)
+}
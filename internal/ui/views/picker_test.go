@@ -0,0 +1,43 @@
+package views
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/will-wright-eng/gh-nav/internal/models"
+	"github.com/will-wright-eng/gh-nav/internal/ui/theme"
+)
+
+func TestPickerTogglesAndApplies(t *testing.T) {
+	pr := &models.PullRequest{Number: 9, Labels: []string{"bug", "stale"}}
+	picker := NewPicker(10, &theme.DefaultTheme)
+	picker.SetSize(80, 30)
+	picker.SetLoading(pr, models.EditLabels)
+	picker.SetOptions([]string{"bug", "deps", "ui"}) // "stale" was deleted from the repo
+
+	if !reflect.DeepEqual(picker.GetFilteredOptions(), []string{"bug", "deps", "stale", "ui"}) {
+		t.Fatalf("Expected current values kept among the options, got %v", picker.GetFilteredOptions())
+	}
+
+	// Filter to "de", select deps; clear the filter and drop bug
+	picker.Update(keyRunes("de"))
+	picker.Update(tea.KeyMsg{Type: tea.KeySpace})
+	picker.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	picker.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	_, cmd := picker.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected enter to apply the selection")
+	}
+	apply, ok := cmd().(PickerApplyMsg)
+	if !ok {
+		t.Fatalf("Expected PickerApplyMsg, got %T", cmd())
+	}
+	if !reflect.DeepEqual(apply.Before, []string{"bug", "stale"}) || !reflect.DeepEqual(apply.After, []string{"stale", "deps"}) {
+		t.Errorf("Unexpected change %v -> %v", apply.Before, apply.After)
+	}
+	if len(pr.Labels) != 2 {
+		t.Error("Expected the picker not to modify the PR itself")
+	}
+}
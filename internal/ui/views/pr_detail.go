@@ -0,0 +1,239 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/will-wright-eng/gh-nav/internal/constants"
+	"github.com/will-wright-eng/gh-nav/internal/models"
+)
+
+// PRDetailModel represents the pull request detail view: a summary of the PR
+// followed by a selectable list of its CI checks
+type PRDetailModel struct {
+	BaseView
+	pr            *models.PullRequest
+	checksLoading bool
+	now           func() time.Time
+}
+
+// NewPRDetail creates a new pull request detail view
+func NewPRDetail(pageSize int) *PRDetailModel {
+	return &PRDetailModel{
+		BaseView: NewBaseView(pageSize),
+		now:      time.Now,
+	}
+}
+
+// SetClock implements Clocked
+func (d *PRDetailModel) SetClock(now func() time.Time) {
+	d.now = now
+}
+
+// SetPR sets the pull request shown by the view; its checks are loaded separately
+func (d *PRDetailModel) SetPR(pr *models.PullRequest) {
+	d.pr = pr
+	d.checksLoading = pr != nil && pr.Checks == nil
+	d.page = 0
+	d.cursor = 0
+}
+
+// SetChecks stores the loaded checks on the pull request
+func (d *PRDetailModel) SetChecks(checks []*models.Check) {
+	d.checksLoading = false
+	if d.pr != nil {
+		d.pr.SetChecks(checks)
+	}
+}
+
+// SetChecksFailed stops the loading indicator after a failed check fetch
+func (d *PRDetailModel) SetChecksFailed() {
+	d.checksLoading = false
+}
+
+// GetPR returns the pull request shown by the view
+func (d *PRDetailModel) GetPR() *models.PullRequest {
+	return d.pr
+}
+
+// getChecks returns the checks of the current PR
+func (d *PRDetailModel) getChecks() []*models.Check {
+	if d.pr == nil {
+		return nil
+	}
+	return d.pr.Checks
+}
+
+// GetVisibleChecks returns the checks visible on the current page
+func (d *PRDetailModel) GetVisibleChecks() []*models.Check {
+	checks := d.getChecks()
+	start, end := d.GetVisibleRange(len(checks))
+	if start >= len(checks) {
+		return []*models.Check{}
+	}
+	return checks[start:end]
+}
+
+// GetSelectedCheck returns the check under the cursor
+func (d *PRDetailModel) GetSelectedCheck() *models.Check {
+	visible := d.GetVisibleChecks()
+	if d.cursor < len(visible) {
+		return visible[d.cursor]
+	}
+	return nil
+}
+
+// Update handles messages and updates the view
+func (d *PRDetailModel) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			d.MoveCursorUp(len(d.GetVisibleChecks()))
+		case "down", "j":
+			d.MoveCursorDown(len(d.GetVisibleChecks()))
+		case "left", "h":
+			d.PreviousPage()
+		case "right", "l":
+			d.NextPage(len(d.getChecks()))
+		case "g":
+			d.GoToFirstPage()
+		case "G":
+			d.GoToLastPage(len(d.getChecks()))
+		}
+	}
+	return d, nil
+}
+
+// View renders the pull request detail
+func (d *PRDetailModel) View() string {
+	if d.width == 0 {
+		return "Loading..."
+	}
+	if d.pr == nil {
+		return ""
+	}
+
+	pr := d.pr
+	margin := lipgloss.NewStyle().MarginLeft(2)
+	muted := margin.Copy().Foreground(lipgloss.Color(constants.ColorMuted))
+
+	var b strings.Builder
+	b.WriteString(margin.Copy().Bold(true).Render(fmt.Sprintf("#%d %s", pr.Number, pr.Title)) + "\n")
+
+	state := pr.State
+	if pr.IsDraft {
+		state = "draft"
+	}
+	b.WriteString(muted.Render(fmt.Sprintf("by %s • %s • %s → %s", pr.Author, state, pr.HeadRef, pr.BaseRef)) + "\n")
+	switch {
+	case pr.State == models.PRStateMerged && pr.MergedBy != "":
+		b.WriteString(muted.Render(fmt.Sprintf("Merged by %s on %s", pr.MergedBy, pr.MergedAt.Local().Format(constants.DateTimeFormat))) + "\n")
+	case pr.State == models.PRStateMerged:
+		b.WriteString(muted.Render("Merged on "+pr.MergedAt.Local().Format(constants.DateTimeFormat)) + "\n")
+	case pr.State == models.PRStateClosed && !pr.ClosedAt.IsZero():
+		b.WriteString(muted.Render("Closed on "+pr.ClosedAt.Local().Format(constants.DateTimeFormat)) + "\n")
+	}
+
+	if len(pr.Labels) > 0 {
+		b.WriteString(margin.Render("Labels: "+strings.Join(pr.Labels, ", ")) + "\n")
+	}
+	if len(pr.Assignees) > 0 {
+		b.WriteString(margin.Render("Assignees: "+strings.Join(pr.Assignees, ", ")) + "\n")
+	}
+	if len(pr.Reviewers) > 0 {
+		b.WriteString(margin.Render("Requested reviewers: "+strings.Join(pr.Reviewers, ", ")) + "\n")
+	}
+	b.WriteString(margin.Render(fmt.Sprintf("+%d -%d • %d comments • %d unresolved threads • %d commits",
+		pr.Additions, pr.Deletions, pr.Comments, pr.UnresolvedThreads, pr.Commits)) + "\n")
+	b.WriteString(margin.Render(fmt.Sprintf("Review: %s • CI: %s %s",
+		pr.ReviewStatus, CIIcon(pr.CIStatus), pr.CIStatus)) + "\n\n")
+
+	b.WriteString(d.viewChecks())
+	return b.String()
+}
+
+// viewChecks renders the selectable check list
+func (d *PRDetailModel) viewChecks() string {
+	header := lipgloss.NewStyle().MarginLeft(2).Bold(true)
+	if d.checksLoading {
+		return header.Render("Checks") + "\n" + lipgloss.NewStyle().MarginLeft(2).Render(constants.HelpLoading) + "\n"
+	}
+
+	checks := d.getChecks()
+	if len(checks) == 0 {
+		return header.Render("Checks") + "\n" + lipgloss.NewStyle().MarginLeft(2).Render("No checks reported") + "\n"
+	}
+
+	list := header.Render(fmt.Sprintf("Checks (%s)", d.GetPageInfo())) + "\n"
+	now := d.now()
+	for i, check := range d.GetVisibleChecks() {
+		cursor := " "
+		if d.cursor == i {
+			cursor = ">"
+		}
+
+		style := lipgloss.NewStyle().MarginLeft(2)
+		if d.cursor == i {
+			style = style.Foreground(lipgloss.Color("#00FF00"))
+		}
+
+		result := check.Conclusion
+		if check.Status != "completed" {
+			result = check.Status
+		}
+
+		list += style.Render(fmt.Sprintf("%s %s %-40s %-16s %s",
+			cursor, CIIcon(check.CIStatus()), truncate(check.Name, 40), result,
+			FormatDuration(check.Duration(now)))) + "\n"
+	}
+	return list
+}
+
+// GetPageInfo returns pagination information
+func (d *PRDetailModel) GetPageInfo() string {
+	return d.BaseView.GetPageInfo(len(d.getChecks()), "checks")
+}
+
+// CIIcon returns the icon for a CI status value
+func CIIcon(ciStatus string) string {
+	switch ciStatus {
+	case models.CIStatusSuccess:
+		return constants.IconCISuccess
+	case models.CIStatusFailure:
+		return constants.IconCIFailure
+	case models.CIStatusPending:
+		return constants.IconCIPending
+	case models.CIStatusNeutral:
+		return constants.IconCINeutral
+	case models.CIStatusNone:
+		return constants.IconCINone
+	}
+	return constants.IconCIUnknown // not loaded yet
+}
+
+// FormatDuration renders a check duration compactly (e.g. 4m02s)
+func FormatDuration(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	d = d.Round(time.Second)
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	if d < time.Hour {
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// truncate shortens s to max characters with an ellipsis
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max-3] + "..."
+}
@@ -2,6 +2,7 @@ package views
 
 import (
 	"fmt"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -14,14 +15,32 @@ type PRListModel struct {
 	BaseView
 	repoName string
 	prs      []*models.PullRequest
+	state    string // list state: open, closed, merged or all
+	hasMore  bool   // further pages can be fetched
+
+	// Multi-selection for bulk actions, keyed by prKey. While a range selection
+	// is active, the PRs between rangeAnchor and the cursor are selected too.
+	selected    map[string]bool
+	rangeAnchor int // absolute index, -1 when no range selection is active
+
+	// PRs that are new or changed since an earlier load of the list, with
+	// what changed, until they're opened. Keyed by prKey.
+	changes map[string]string
+
+	// Looks up when a PR was last read; nil when read state isn't tracked
+	seen func(*models.PullRequest) *models.Seen
 }
 
 // NewPRList creates a new pull request list view
 func NewPRList(pageSize int) *PRListModel {
 	return &PRListModel{
-		BaseView: NewBaseView(pageSize),
-		repoName: "",
-		prs:      []*models.PullRequest{},
+		BaseView:    NewBaseView(pageSize),
+		repoName:    "",
+		prs:         []*models.PullRequest{},
+		state:       models.PRStateOpen,
+		selected:    make(map[string]bool),
+		rangeAnchor: -1,
+		changes:     make(map[string]string),
 	}
 }
 
@@ -31,6 +50,243 @@ func (p *PRListModel) SetData(repoName string, prs []*models.PullRequest) {
 	p.prs = prs
 	p.page = 0
 	p.cursor = 0
+	p.hasMore = false
+	p.ClearSelection()
+	p.changes = make(map[string]string)
+}
+
+// AppendData adds a further page of pull requests, keeping the cursor and
+// selection. PRs already listed (e.g. after a refresh moved them up) are
+// updated in place rather than listed twice.
+func (p *PRListModel) AppendData(prs []*models.PullRequest) {
+	index := make(map[string]int, len(p.prs))
+	for i, pr := range p.prs {
+		index[prKey(pr)] = i
+	}
+	for _, pr := range prs {
+		if i, ok := index[prKey(pr)]; ok {
+			p.prs[i] = pr
+			continue
+		}
+		p.prs = append(p.prs, pr)
+	}
+}
+
+// Refresh replaces the start of the list with a freshly loaded first page,
+// keeping the PRs loaded further down, the cursor on the same PR and the
+// selection. PRs the page should have included but doesn't (e.g. closed or
+// merged since) are dropped, and all PRs missing from it when more is false,
+// i.e. it's the whole listing. PRs that are new or changed since they were
+// last listed are marked until opened. The CI status and activity of
+// unchanged PRs carry over until they're loaded again.
+func (p *PRListModel) Refresh(prs []*models.PullRequest, more bool) {
+	selected := p.GetSelectedPR()
+	previous := make(map[string]*models.PullRequest, len(p.prs))
+	for _, pr := range p.prs {
+		previous[prKey(pr)] = pr
+	}
+
+	refreshed := make([]*models.PullRequest, 0, len(p.prs))
+	fresh := make(map[string]bool, len(prs))
+	for _, pr := range prs {
+		key := prKey(pr)
+		fresh[key] = true
+		prev := previous[key]
+		if change := pr.ChangeSince(prev); change != "" {
+			p.changes[key] = change
+		}
+		if prev != nil {
+			pr.UnresolvedThreads, pr.Activity = prev.UnresolvedThreads, prev.Activity
+			if prev.HeadSHA == pr.HeadSHA {
+				pr.Checks, pr.CIStatus = prev.Checks, prev.CIStatus
+			}
+		}
+		refreshed = append(refreshed, pr)
+	}
+	// The page reaches as far down the list as the last PR they share
+	covered := len(p.prs) - 1
+	if more {
+		for covered >= 0 && !fresh[prKey(p.prs[covered])] {
+			covered--
+		}
+	}
+	for i, pr := range p.prs {
+		if !fresh[prKey(pr)] && i > covered {
+			refreshed = append(refreshed, pr)
+		}
+	}
+	p.prs = refreshed
+	p.rangeAnchor = -1
+
+	if selected != nil {
+		for i, pr := range p.prs {
+			if prKey(pr) == prKey(selected) {
+				p.SelectIndex(i)
+				return
+			}
+		}
+	}
+	p.SelectIndex(min(p.absoluteCursor(), len(p.prs)-1))
+}
+
+// MarkViewed clears the new or changed mark of a PR once it has been opened
+func (p *PRListModel) MarkViewed(pr *models.PullRequest) {
+	if pr != nil {
+		delete(p.changes, prKey(pr))
+	}
+}
+
+// GetChange returns what changed in a PR since it was last listed, or "" if
+// it's unchanged or has been opened since
+func (p *PRListModel) GetChange(pr *models.PullRequest) string {
+	return p.changes[prKey(pr)]
+}
+
+// SetSeen sets how to look up when a PR was last read, which the list uses to
+// mark unread PRs
+func (p *PRListModel) SetSeen(seen func(*models.PullRequest) *models.Seen) {
+	p.seen = seen
+}
+
+// GetUnread returns what happened on a PR since it was last read; nothing is
+// unread when read state isn't tracked
+func (p *PRListModel) GetUnread(pr *models.PullRequest) models.Unread {
+	if p.seen == nil {
+		return models.Unread{}
+	}
+	return pr.UnreadSince(p.seen(pr))
+}
+
+// UnreadCount returns how many of the loaded PRs are unread
+func (p *PRListModel) UnreadCount() int {
+	count := 0
+	for _, pr := range p.prs {
+		if p.GetUnread(pr).Unread {
+			count++
+		}
+	}
+	return count
+}
+
+// GetPRs returns every loaded pull request
+func (p *PRListModel) GetPRs() []*models.PullRequest {
+	return p.prs
+}
+
+// SetHasMore records whether further pages can be fetched
+func (p *PRListModel) SetHasMore(hasMore bool) {
+	p.hasMore = hasMore
+}
+
+// HasMore reports whether further pages can be fetched
+func (p *PRListModel) HasMore() bool {
+	return p.hasMore
+}
+
+// SetState sets the list state shown in the page info
+func (p *PRListModel) SetState(state string) {
+	p.state = state
+}
+
+// GetState returns the list state
+func (p *PRListModel) GetState() string {
+	return p.state
+}
+
+// IsNearEnd reports whether the last or second-to-last loaded page is shown,
+// i.e. it's time to fetch more before the user runs out of rows
+func (p *PRListModel) IsNearEnd() bool {
+	return p.page+1 >= (len(p.prs)-1)/p.pageSize
+}
+
+// prKey identifies a PR across repositories
+func prKey(pr *models.PullRequest) string {
+	return fmt.Sprintf("%s#%d", pr.RepoName, pr.Number)
+}
+
+// absoluteCursor returns the index of the cursor in the full list
+func (p *PRListModel) absoluteCursor() int {
+	return p.page*p.pageSize + p.cursor
+}
+
+// ToggleSelected adds or removes the PR under the cursor from the selection
+func (p *PRListModel) ToggleSelected() {
+	if pr := p.GetSelectedPR(); pr != nil {
+		key := prKey(pr)
+		p.selected[key] = !p.selected[key]
+		if !p.selected[key] {
+			delete(p.selected, key)
+		}
+	}
+}
+
+// ToggleRange starts a range selection at the cursor, or ends one by adding
+// every PR between its start and the cursor to the selection
+func (p *PRListModel) ToggleRange() {
+	if p.GetSelectedPR() == nil {
+		return
+	}
+	if p.rangeAnchor < 0 {
+		p.rangeAnchor = p.absoluteCursor()
+		return
+	}
+	for _, pr := range p.rangePRs() {
+		p.selected[prKey(pr)] = true
+	}
+	p.rangeAnchor = -1
+}
+
+// rangePRs returns the PRs covered by an active range selection
+func (p *PRListModel) rangePRs() []*models.PullRequest {
+	if p.rangeAnchor < 0 {
+		return nil
+	}
+	from, to := p.rangeAnchor, p.absoluteCursor()
+	if from > to {
+		from, to = to, from
+	}
+	if to >= len(p.prs) {
+		to = len(p.prs) - 1
+	}
+	if from > to {
+		return nil
+	}
+	return p.prs[from : to+1]
+}
+
+// IsSelected reports whether a PR is selected, including by an active range
+func (p *PRListModel) IsSelected(pr *models.PullRequest) bool {
+	if p.selected[prKey(pr)] {
+		return true
+	}
+	for _, inRange := range p.rangePRs() {
+		if inRange == pr {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRangeActive reports whether a range selection is in progress
+func (p *PRListModel) IsRangeActive() bool {
+	return p.rangeAnchor >= 0
+}
+
+// GetSelectedPRs returns the selected PRs in list order
+func (p *PRListModel) GetSelectedPRs() []*models.PullRequest {
+	var selected []*models.PullRequest
+	for _, pr := range p.prs {
+		if p.IsSelected(pr) {
+			selected = append(selected, pr)
+		}
+	}
+	return selected
+}
+
+// ClearSelection deselects all PRs and cancels any range selection
+func (p *PRListModel) ClearSelection() {
+	p.selected = make(map[string]bool)
+	p.rangeAnchor = -1
 }
 
 // GetVisiblePRs returns the pull requests visible on the current page
@@ -53,7 +309,11 @@ func (p *PRListModel) GetSelectedPR() *models.PullRequest {
 
 // GetStatusIcon returns the appropriate status icon for a PR
 func (p *PRListModel) GetStatusIcon(pr *models.PullRequest) string {
-	if pr.IsDraft {
+	if pr.State == models.PRStateMerged {
+		return constants.IconMerged
+	} else if pr.State == models.PRStateClosed {
+		return constants.IconClosed
+	} else if pr.IsDraft {
 		return constants.IconDraft // draft
 	} else if pr.ReviewStatus == "approved" {
 		return constants.IconApproved // approved
@@ -63,6 +323,66 @@ func (p *PRListModel) GetStatusIcon(pr *models.PullRequest) string {
 	return constants.IconOpen // open
 }
 
+// GetCIIcon returns the icon for a PR's rolled-up CI status
+func (p *PRListModel) GetCIIcon(pr *models.PullRequest) string {
+	return CIIcon(pr.CIStatus)
+}
+
+// SetChecks applies loaded checks to the listed PRs, keyed by PR number. A CI
+// status that differs from the one shown before marks the PR as changed.
+func (p *PRListModel) SetChecks(checks map[int][]*models.Check) {
+	for _, pr := range p.prs {
+		if prChecks, ok := checks[pr.Number]; ok {
+			before := pr.CIStatus
+			pr.SetChecks(prChecks)
+			if before != "" && pr.CIStatus != before {
+				if _, marked := p.changes[prKey(pr)]; !marked {
+					p.changes[prKey(pr)] = "CI " + pr.CIStatus
+				}
+			}
+		}
+	}
+}
+
+// SetActivity applies loaded comment and unresolved thread counts, keyed by PR
+// number
+func (p *PRListModel) SetActivity(activity map[int]*models.Activity) {
+	for _, pr := range p.prs {
+		if a, ok := activity[pr.Number]; ok {
+			pr.Activity = a
+			pr.UnresolvedThreads = a.UnresolvedThreads
+		}
+	}
+}
+
+// GetThreadBadge returns the unresolved thread indicator for a PR, or "" if none
+func (p *PRListModel) GetThreadBadge(pr *models.PullRequest) string {
+	if pr.UnresolvedThreads == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" %s%d", constants.IconThread, pr.UnresolvedThreads)
+}
+
+// GetLabelBadge returns the PR's labels for its row, or "" if it has none
+func (p *PRListModel) GetLabelBadge(pr *models.PullRequest) string {
+	if len(pr.Labels) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(pr.Labels, ", ") + "]"
+}
+
+// GetStateBadge returns when a merged or closed PR was merged or closed, or ""
+// for open PRs
+func (p *PRListModel) GetStateBadge(pr *models.PullRequest) string {
+	switch {
+	case pr.State == models.PRStateMerged && !pr.MergedAt.IsZero():
+		return " merged " + pr.MergedAt.Local().Format(constants.DateFormat)
+	case pr.State == models.PRStateClosed && !pr.ClosedAt.IsZero():
+		return " closed " + pr.ClosedAt.Local().Format(constants.DateFormat)
+	}
+	return ""
+}
+
 // TruncateTitle truncates the PR title if it's too long
 func (p *PRListModel) TruncateTitle(title string, maxLength int) string {
 	if len(title) <= maxLength {
@@ -88,6 +408,12 @@ func (p *PRListModel) Update(msg tea.Msg) (View, tea.Cmd) {
 			p.GoToFirstPage()
 		case "G":
 			p.GoToLastPage(len(p.prs))
+		case " ":
+			p.ToggleSelected()
+		case "V":
+			p.ToggleRange()
+		case "esc":
+			p.ClearSelection()
 		}
 	}
 	return p, nil
@@ -101,30 +427,73 @@ func (p *PRListModel) View() string {
 
 	list := ""
 	visiblePRs := p.GetVisiblePRs()
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color(constants.ColorMuted))
+	highlight := lipgloss.NewStyle().Foreground(lipgloss.Color(constants.ColorInfo))
+	fit := lipgloss.NewStyle().MaxWidth(p.width)
 
 	for i, pr := range visiblePRs {
 		cursor := " "
 		if p.cursor == i {
 			cursor = ">"
 		}
+		if p.IsSelected(pr) {
+			cursor += "✓"
+		} else {
+			cursor += " "
+		}
+		unread := p.GetUnread(pr)
+		if unread.Unread {
+			cursor += constants.IconUnread
+		} else {
+			cursor += " "
+		}
 
 		style := lipgloss.NewStyle().MarginLeft(2)
+		change := p.GetChange(pr)
 		if p.cursor == i {
 			style = style.Foreground(lipgloss.Color("#00FF00"))
+		} else if change != "" || unread.Unread {
+			style = style.Bold(true)
 		}
 
 		statusIcon := p.GetStatusIcon(pr)
+		ciIcon := p.GetCIIcon(pr)
 		title := p.TruncateTitle(pr.Title, constants.MaxTitleLength)
 
-		list += style.Render(fmt.Sprintf("%s %s #%d %s", cursor, statusIcon, pr.Number, title)) + "\n"
+		// What's new since the PR was last read says more than what changed
+		// since the last refresh, so it wins when there's both
+		if news := unread.Describe(); news != "" {
+			change = news
+		}
+		changeBadge := ""
+		if change != "" {
+			changeBadge = highlight.Render(" " + constants.IconChanged + " " + change)
+		}
+
+		// Badges are cut off rather than wrapping onto the next row
+		row := style.Render(fmt.Sprintf("%s %s %s #%d %s%s", cursor, statusIcon, ciIcon, pr.Number, title, p.GetThreadBadge(pr))) +
+			muted.Render(p.GetLabelBadge(pr)+p.GetStateBadge(pr)) + changeBadge
+		list += fit.Render(row) + "\n"
 	}
 
 	return list
 }
 
-// GetPageInfo returns pagination information
+// GetPageInfo returns pagination information, naming the list state and
+// noting unread PRs and when more pages can be fetched
 func (p *PRListModel) GetPageInfo() string {
-	return p.BaseView.GetPageInfo(len(p.prs), "pull requests")
+	itemType := "pull requests"
+	if p.state != models.PRStateAll {
+		itemType = p.state + " " + itemType
+	}
+	info := p.BaseView.GetPageInfo(len(p.prs), itemType)
+	if unread := p.UnreadCount(); unread > 0 {
+		info += fmt.Sprintf(" (%d unread)", unread)
+	}
+	if p.hasMore {
+		info += " (more available)"
+	}
+	return info
 }
 
 // GetRepoName returns the current repository name
@@ -0,0 +1,155 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/will-wright-eng/gh-nav/internal/models"
+)
+
+func testPRs(n int) []*models.PullRequest {
+	prs := make([]*models.PullRequest, n)
+	for i := range prs {
+		prs[i] = &models.PullRequest{Number: i + 1, RepoName: "org/repo"}
+	}
+	return prs
+}
+
+func selectedNumbers(prs []*models.PullRequest) []int {
+	numbers := make([]int, len(prs))
+	for i, pr := range prs {
+		numbers[i] = pr.Number
+	}
+	return numbers
+}
+
+func TestPRListToggleSelection(t *testing.T) {
+	list := NewPRList(10)
+	list.SetData("org/repo", testPRs(5))
+
+	list.Update(tea.KeyMsg{Type: tea.KeySpace})
+	list.Update(keyRunes("j"))
+	list.Update(keyRunes("j"))
+	list.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	if got := selectedNumbers(list.GetSelectedPRs()); len(got) != 2 || got[0] != 1 || got[1] != 3 {
+		t.Errorf("Expected #1 and #3 selected, got %v", got)
+	}
+
+	list.Update(tea.KeyMsg{Type: tea.KeySpace})
+	if got := selectedNumbers(list.GetSelectedPRs()); len(got) != 1 {
+		t.Errorf("Expected space to deselect #3, got %v", got)
+	}
+}
+
+func TestPRListRangeSelectionAcrossPages(t *testing.T) {
+	list := NewPRList(3)
+	list.SetData("org/repo", testPRs(8))
+
+	list.Update(keyRunes("j"))
+	list.Update(keyRunes("V")) // anchor on #2
+	list.Update(keyRunes("l")) // next page, cursor on #4
+	list.Update(keyRunes("j")) // #5
+	if !list.IsRangeActive() || len(list.GetSelectedPRs()) != 4 {
+		t.Fatalf("Expected #2-#5 in the active range, got %v", selectedNumbers(list.GetSelectedPRs()))
+	}
+
+	list.Update(keyRunes("V"))
+	if list.IsRangeActive() {
+		t.Error("Expected the second V to end the range")
+	}
+	if got := selectedNumbers(list.GetSelectedPRs()); len(got) != 4 || got[0] != 2 || got[3] != 5 {
+		t.Errorf("Expected #2-#5 selected, got %v", got)
+	}
+
+	list.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if len(list.GetSelectedPRs()) != 0 {
+		t.Error("Expected esc to clear the selection")
+	}
+}
+
+func TestPRListRefreshMarksChanges(t *testing.T) {
+	list := NewPRList(10)
+	prs := testPRs(12)
+	for _, pr := range prs {
+		pr.HeadSHA = "sha"
+		pr.CIStatus = models.CIStatusSuccess
+	}
+	list.SetData("org/repo", prs)
+	list.SetCursor(2) // #3
+
+	// #13 is new, #5 has new commits and #7 was closed; the rest of the first
+	// page is unchanged
+	fresh := append([]*models.PullRequest{{Number: 13, RepoName: "org/repo"}}, testPRs(10)...)
+	for _, pr := range fresh[1:] {
+		pr.HeadSHA = "sha"
+	}
+	fresh[5].HeadSHA = "new"
+	fresh = append(fresh[:7], fresh[8:]...)
+	list.Refresh(fresh, true)
+
+	if list.GetPRCount() != 12 {
+		t.Fatalf("Expected #7 dropped and the PRs loaded further down kept, got %d", list.GetPRCount())
+	}
+	for _, pr := range list.prs {
+		if pr.Number == 7 {
+			t.Error("Expected #7 to leave the list once it's no longer listed")
+		}
+	}
+	if selected := list.GetSelectedPR(); selected == nil || selected.Number != 3 {
+		t.Fatalf("Expected the cursor to stay on #3, got %v", selected)
+	}
+	if list.GetChange(fresh[0]) != "new" || list.GetChange(fresh[5]) != "new commits" || list.GetChange(fresh[3]) != "" {
+		t.Errorf("Unexpected changes %v", list.changes)
+	}
+	if fresh[3].CIStatus != models.CIStatusSuccess || fresh[5].CIStatus != "" {
+		t.Error("Expected the CI status to carry over only for unchanged commits")
+	}
+
+	// A CI status that changes when checks load again marks the row too
+	list.SetChecks(map[int][]*models.Check{3: {{Status: "completed", Conclusion: "failure"}}})
+	if list.GetChange(fresh[3]) != "CI "+models.CIStatusFailure {
+		t.Errorf("Expected the CI change marked, got %q", list.GetChange(fresh[3]))
+	}
+
+	list.MarkViewed(fresh[5])
+	if list.GetChange(fresh[5]) != "" {
+		t.Error("Expected opening a PR to clear its mark")
+	}
+
+	// A later page containing PRs already listed doesn't duplicate them
+	list.AppendData(testPRs(14)[10:])
+	if list.GetPRCount() != 13 {
+		t.Errorf("Expected only #14 appended, got %d PRs", list.GetPRCount())
+	}
+
+	// A refresh that returns the whole listing drops everything missing from it
+	list.Refresh(testPRs(3), false)
+	if list.GetPRCount() != 3 {
+		t.Errorf("Expected only the 3 listed PRs left, got %d", list.GetPRCount())
+	}
+}
+
+func TestPRListUnread(t *testing.T) {
+	list := NewPRList(10)
+	prs := testPRs(3)
+	list.SetData("org/repo", prs)
+	if list.GetUnread(prs[0]).Unread {
+		t.Fatal("Expected nothing unread without read state")
+	}
+
+	// #1 was read when it had no comments; the others never were
+	seen := map[int]*models.Seen{1: {}}
+	list.SetSeen(func(pr *models.PullRequest) *models.Seen { return seen[pr.Number] })
+	list.SetActivity(map[int]*models.Activity{1: {UnresolvedThreads: 1, Comments: 2}})
+	if prs[0].UnresolvedThreads != 1 {
+		t.Errorf("Expected the unresolved thread count applied, got %d", prs[0].UnresolvedThreads)
+	}
+	if got := list.GetUnread(prs[0]).Describe(); got != "2 new comments" {
+		t.Errorf("Expected 2 new comments on #1, got %q", got)
+	}
+	if list.UnreadCount() != 3 || !strings.Contains(list.GetPageInfo(), "(3 unread)") {
+		t.Errorf("Expected 3 unread PRs, got %q", list.GetPageInfo())
+	}
+}
@@ -51,6 +51,16 @@ func (r *RepoListModel) GetSelectedRepo() string {
 	return ""
 }
 
+// SelectRepo moves the cursor to the given repository if it is in the list
+func (r *RepoListModel) SelectRepo(fullName string) {
+	for i, candidate := range r.repos {
+		if candidate == fullName {
+			r.SelectIndex(i)
+			return
+		}
+	}
+}
+
 // GetRepoName extracts the repository name from the full name
 func (r *RepoListModel) GetRepoName(fullName string) string {
 	parts := strings.Split(fullName, "/")
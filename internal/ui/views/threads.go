@@ -0,0 +1,165 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/will-wright-eng/gh-nav/internal/constants"
+	"github.com/will-wright-eng/gh-nav/internal/models"
+	"github.com/will-wright-eng/gh-nav/internal/ui/components"
+	"github.com/will-wright-eng/gh-nav/internal/ui/theme"
+)
+
+// threadsPageSize is smaller than the list page size because expanded threads
+// take several lines each
+const threadsPageSize = 5
+
+// ThreadsModel shows a pull request's comments grouped into threads. Resolved
+// threads start collapsed.
+type ThreadsModel struct {
+	BaseView
+	theme    *theme.Theme
+	pr       *models.PullRequest
+	threads  []*models.Thread
+	expanded map[string]bool
+	loading  bool
+	now      func() time.Time
+}
+
+// NewThreads creates a new threads view
+func NewThreads(t *theme.Theme) *ThreadsModel {
+	return &ThreadsModel{
+		BaseView: NewBaseView(threadsPageSize),
+		theme:    t,
+		expanded: make(map[string]bool),
+		now:      time.Now,
+	}
+}
+
+// SetClock implements Clocked
+func (v *ThreadsModel) SetClock(now func() time.Time) {
+	v.now = now
+}
+
+// SetLoading clears the view while a PR's threads are fetched
+func (v *ThreadsModel) SetLoading(pr *models.PullRequest) {
+	v.pr = pr
+	v.threads = nil
+	v.expanded = make(map[string]bool)
+	v.loading = true
+	v.page = 0
+	v.cursor = 0
+}
+
+// SetThreads shows loaded threads, expanding the unresolved ones
+func (v *ThreadsModel) SetThreads(threads []*models.Thread) {
+	v.threads = threads
+	v.loading = false
+	for _, thread := range threads {
+		v.expanded[thread.ID] = !thread.Resolved
+	}
+}
+
+// SetFailed stops the loading message after a failed fetch
+func (v *ThreadsModel) SetFailed() {
+	v.loading = false
+}
+
+// GetPR returns the pull request whose threads are shown
+func (v *ThreadsModel) GetPR() *models.PullRequest {
+	return v.pr
+}
+
+// GetVisibleThreads returns the threads on the current page
+func (v *ThreadsModel) GetVisibleThreads() []*models.Thread {
+	start, end := v.GetVisibleRange(len(v.threads))
+	if start >= len(v.threads) {
+		return []*models.Thread{}
+	}
+	return v.threads[start:end]
+}
+
+// GetSelectedThread returns the thread under the cursor
+func (v *ThreadsModel) GetSelectedThread() *models.Thread {
+	visible := v.GetVisibleThreads()
+	if v.cursor < len(visible) {
+		return visible[v.cursor]
+	}
+	return nil
+}
+
+// ToggleSelected expands or collapses the selected thread
+func (v *ThreadsModel) ToggleSelected() {
+	if thread := v.GetSelectedThread(); thread != nil {
+		v.expanded[thread.ID] = !v.expanded[thread.ID]
+	}
+}
+
+// Update handles messages and updates the view
+func (v *ThreadsModel) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			v.MoveCursorUp(len(v.GetVisibleThreads()))
+		case "down", "j":
+			v.MoveCursorDown(len(v.GetVisibleThreads()))
+		case "left", "h":
+			v.PreviousPage()
+		case "right", "l":
+			v.NextPage(len(v.threads))
+		case "g":
+			v.GoToFirstPage()
+		case "G":
+			v.GoToLastPage(len(v.threads))
+		case " ":
+			v.ToggleSelected()
+		}
+	}
+	return v, nil
+}
+
+// View renders the threads on the current page
+func (v *ThreadsModel) View() string {
+	if v.width == 0 {
+		return "Loading..."
+	}
+
+	margin := lipgloss.NewStyle().MarginLeft(2)
+	var b strings.Builder
+	if v.pr != nil {
+		b.WriteString(margin.Copy().Bold(true).Render(fmt.Sprintf("#%d %s", v.pr.Number, v.pr.Title)) + "\n")
+	}
+
+	if v.loading {
+		b.WriteString(margin.Render(constants.HelpLoading) + "\n")
+		return b.String()
+	}
+	if len(v.threads) == 0 {
+		b.WriteString(margin.Render("No comments yet") + "\n")
+		return b.String()
+	}
+
+	unresolved := models.CountUnresolved(v.threads)
+	b.WriteString(margin.Copy().Foreground(lipgloss.Color(constants.ColorMuted)).Render(
+		fmt.Sprintf("%s • %d unresolved", v.GetPageInfo(), unresolved)) + "\n\n")
+
+	now := v.now()
+	for i, thread := range v.GetVisibleThreads() {
+		b.WriteString(components.RenderThread(v.theme, thread, components.ThreadOptions{
+			Selected: v.cursor == i,
+			Expanded: v.expanded[thread.ID],
+			Width:    v.width,
+			Now:      now,
+		}))
+	}
+	return b.String()
+}
+
+// GetPageInfo returns pagination information
+func (v *ThreadsModel) GetPageInfo() string {
+	return v.BaseView.GetPageInfo(len(v.threads), "threads")
+}
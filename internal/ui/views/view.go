@@ -2,6 +2,7 @@ package views
 
 import (
 	"fmt"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -30,6 +31,18 @@ type View interface {
 	SetPage(page int)
 }
 
+// InputCapturer is implemented by views that can take over the keyboard, e.g.
+// while typing a search query; the app then forwards every key to the view
+type InputCapturer interface {
+	CapturesInput() bool
+}
+
+// Clocked is implemented by views that show ages or durations, so they can be
+// rendered at a fixed time (e.g. in snapshot tests)
+type Clocked interface {
+	SetClock(now func() time.Time)
+}
+
 // BaseView provides common functionality for all views
 type BaseView struct {
 	width    int
@@ -133,6 +146,15 @@ func (b *BaseView) GoToLastPage(maxItems int) {
 	}
 }
 
+// SelectIndex moves the page and cursor so that the item at index is selected
+func (b *BaseView) SelectIndex(index int) {
+	if index < 0 {
+		return
+	}
+	b.page = index / b.pageSize
+	b.cursor = index % b.pageSize
+}
+
 // GetVisibleRange returns the visible range for pagination
 func (b *BaseView) GetVisibleRange(maxItems int) (start, end int) {
 	start = b.page * b.pageSize
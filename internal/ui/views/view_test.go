@@ -1,6 +1,9 @@
 package views
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestBaseView(t *testing.T) {
 	view := NewBaseView(5)
@@ -103,3 +106,35 @@ func TestBaseViewEdgeCases(t *testing.T) {
 		t.Errorf("Expected cursor to be 1 when moving down from 0, got %d", view.GetCursor())
 	}
 }
+
+func TestSelectIndex(t *testing.T) {
+	view := NewBaseView(5)
+
+	view.SelectIndex(12)
+	if view.GetPage() != 2 || view.GetCursor() != 2 {
+		t.Errorf("Expected page 2 cursor 2, got page %d cursor %d", view.GetPage(), view.GetCursor())
+	}
+
+	view.SelectIndex(-1)
+	if view.GetPage() != 2 || view.GetCursor() != 2 {
+		t.Error("Expected negative index to be ignored")
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		input    time.Duration
+		expected string
+	}{
+		{0, "-"},
+		{42 * time.Second, "42s"},
+		{4*time.Minute + 2*time.Second, "4m02s"},
+		{time.Hour + 5*time.Minute, "1h05m"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatDuration(tt.input); got != tt.expected {
+			t.Errorf("FormatDuration(%s) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ghHostConfig is one host entry of gh's hosts.yml
+type ghHostConfig struct {
+	User       string `yaml:"user"`
+	OAuthToken string `yaml:"oauth_token"`
+}
+
+// ghSettings are the top-level gh config.yml settings gh-nav honors
+type ghSettings struct {
+	Pager   string `yaml:"pager"`
+	Browser string `yaml:"browser"`
+	Editor  string `yaml:"editor"`
+}
+
+// DefaultHostFromEnv returns GH_HOST when set, otherwise github.com
+func DefaultHostFromEnv() string {
+	if host := os.Getenv("GH_HOST"); host != "" {
+		return strings.ToLower(host)
+	}
+	return DefaultHost
+}
+
+// KnownHosts returns the hosts known to serve GitHub: github.com, GH_HOST and
+// the hosts gh is logged in to
+func KnownHosts() []string {
+	hosts := []string{DefaultHost}
+	if host := os.Getenv("GH_HOST"); host != "" {
+		hosts = append(hosts, strings.ToLower(host))
+	}
+	if entries, err := readGHHosts(); err == nil {
+		for host := range entries {
+			hosts = append(hosts, strings.ToLower(host))
+		}
+	}
+	return hosts
+}
+
+// TokenForHost resolves an API token for host using gh's precedence: environment
+// variables first, then gh's hosts.yml, then `gh auth token` (which also covers
+// tokens gh keeps in the system keyring). The source is returned for diagnostics.
+func TokenForHost(host string) (token, source string) {
+	envVars := []string{"GH_TOKEN", "GITHUB_TOKEN"}
+	if host != DefaultHost {
+		envVars = []string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"}
+	}
+	for _, name := range envVars {
+		if value := os.Getenv(name); value != "" {
+			return value, name
+		}
+	}
+
+	if hosts, err := readGHHosts(); err == nil {
+		if entry, ok := hosts[host]; ok && entry.OAuthToken != "" {
+			return entry.OAuthToken, "hosts.yml"
+		}
+	}
+
+	if ghToken, err := getGitHubCLIToken("--hostname", host); err == nil {
+		return ghToken, "gh auth token"
+	}
+
+	return "", ""
+}
+
+// MaskToken hides all but the ends of a token, for showing which one is in use
+func MaskToken(token string) string {
+	if len(token) <= 8 {
+		return "***"
+	}
+	return token[:4] + "..." + token[len(token)-4:]
+}
+
+// ghConfigDir returns gh's configuration directory
+func ghConfigDir() string {
+	if dir := os.Getenv("GH_CONFIG_DIR"); dir != "" {
+		return dir
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh")
+	}
+	if runtime.GOOS == "windows" {
+		if dir := os.Getenv("AppData"); dir != "" {
+			return filepath.Join(dir, "GitHub CLI")
+		}
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "gh")
+}
+
+// readGHHosts parses gh's hosts.yml
+func readGHHosts() (map[string]ghHostConfig, error) {
+	data, err := os.ReadFile(filepath.Join(ghConfigDir(), "hosts.yml"))
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := make(map[string]ghHostConfig)
+	if err := yaml.Unmarshal(data, &hosts); err != nil {
+		return nil, fmt.Errorf("failed to parse gh hosts.yml: %w", err)
+	}
+	return hosts, nil
+}
+
+// readGHSettings parses the top-level settings of gh's config.yml
+func readGHSettings() ghSettings {
+	var settings ghSettings
+	if data, err := os.ReadFile(filepath.Join(ghConfigDir(), "config.yml")); err == nil {
+		yaml.Unmarshal(data, &settings)
+	}
+	return settings
+}
+
+// externalFromEnv resolves pager, browser and editor with gh's precedence:
+// GH_* variable, then gh's config.yml, then the generic environment variable
+func externalFromEnv() ExternalConfig {
+	settings := readGHSettings()
+	return ExternalConfig{
+		Pager:   firstNonEmpty(os.Getenv("GH_PAGER"), settings.Pager, os.Getenv("PAGER")),
+		Browser: firstNonEmpty(os.Getenv("GH_BROWSER"), settings.Browser, os.Getenv("BROWSER")),
+		Editor:  firstNonEmpty(os.Getenv("GH_EDITOR"), settings.Editor, os.Getenv("VISUAL"), os.Getenv("EDITOR")),
+	}
+}
+
+// firstNonEmpty returns the first non-empty string
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// getGitHubCLIToken retrieves the GitHub token from the gh CLI
+func getGitHubCLIToken(args ...string) (string, error) {
+	// Execute gh auth token command
+	cmd := exec.Command("gh", append([]string{"auth", "token"}, args...)...)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get gh token: %w", err)
+	}
+
+	// Trim whitespace and newlines
+	token := strings.TrimSpace(string(output))
+	if token == "" {
+		return "", fmt.Errorf("gh token is empty")
+	}
+
+	return token, nil
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTokenForHost(t *testing.T) {
+	dir := t.TempDir()
+	hosts := `github.com:
+    user: octocat
+    oauth_token: hosts-file-token
+ghe.example.com:
+    user: octocat
+    oauth_token: enterprise-file-token
+`
+	if err := os.WriteFile(filepath.Join(dir, "hosts.yml"), []byte(hosts), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("GH_CONFIG_DIR", dir)
+	t.Setenv("GH_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_ENTERPRISE_TOKEN", "")
+	t.Setenv("GITHUB_ENTERPRISE_TOKEN", "")
+
+	if token, source := TokenForHost("github.com"); token != "hosts-file-token" || source != "hosts.yml" {
+		t.Errorf("Expected token from hosts.yml, got %q from %q", token, source)
+	}
+	if token, _ := TokenForHost("ghe.example.com"); token != "enterprise-file-token" {
+		t.Errorf("Expected enterprise token from hosts.yml, got %q", token)
+	}
+
+	// Environment variables take precedence, per host kind
+	t.Setenv("GITHUB_TOKEN", "github-env-token")
+	t.Setenv("GH_TOKEN", "gh-env-token")
+	t.Setenv("GH_ENTERPRISE_TOKEN", "enterprise-env-token")
+
+	if token, source := TokenForHost("github.com"); token != "gh-env-token" || source != "GH_TOKEN" {
+		t.Errorf("Expected GH_TOKEN to win, got %q from %q", token, source)
+	}
+	if token, _ := TokenForHost("ghe.example.com"); token != "enterprise-env-token" {
+		t.Errorf("Expected GH_ENTERPRISE_TOKEN for enterprise host, got %q", token)
+	}
+}
+
+func TestMaskToken(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", "***"},
+		{"short", "***"},
+		{"gho_1234567890abcdef", "gho_...cdef"},
+		{"gho_abcdef1234567890", "gho_...7890"},
+	}
+
+	for _, test := range tests {
+		result := MaskToken(test.input)
+		if result != test.expected {
+			t.Errorf("MaskToken(%q) = %q, expected %q", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestKnownHosts(t *testing.T) {
+	dir := t.TempDir()
+	hosts := "ghe.example.com:\n    user: octocat\n"
+	if err := os.WriteFile(filepath.Join(dir, "hosts.yml"), []byte(hosts), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GH_CONFIG_DIR", dir)
+	t.Setenv("GH_HOST", "GitHub.Corp.example")
+
+	known := make(map[string]bool)
+	for _, host := range KnownHosts() {
+		known[host] = true
+	}
+	for _, host := range []string{"github.com", "github.corp.example", "ghe.example.com"} {
+		if !known[host] {
+			t.Errorf("Expected %s to be known, got %v", host, KnownHosts())
+		}
+	}
+	if known["gitlab.com"] {
+		t.Error("Expected gitlab.com to be unknown")
+	}
+}
+
+func TestExternalFromEnv(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "config.yml"), []byte("pager: less -R\nbrowser: gh-browser\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("GH_CONFIG_DIR", dir)
+	t.Setenv("GH_PAGER", "")
+	t.Setenv("PAGER", "more")
+	t.Setenv("GH_BROWSER", "env-browser")
+	t.Setenv("BROWSER", "")
+
+	external := externalFromEnv()
+	if external.Pager != "less -R" {
+		t.Errorf("Expected gh config pager over PAGER, got %q", external.Pager)
+	}
+	if external.Browser != "env-browser" {
+		t.Errorf("Expected GH_BROWSER over gh config, got %q", external.Browser)
+	}
+}
@@ -1,70 +1,216 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
-	"os/exec"
+	"path/filepath"
 	"strings"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Config holds the application configuration
 type Config struct {
-	GitHub GitHubConfig `yaml:"github"`
-	UI     UIConfig     `yaml:"ui"`
+	GitHub   GitHubConfig   `yaml:"github"`
+	UI       UIConfig       `yaml:"ui"`
+	Git      GitConfig      `yaml:"git"`
+	Cache    CacheConfig    `yaml:"cache"`
+	State    StateConfig    `yaml:"state"`
+	Debug    DebugConfig    `yaml:"debug"`
+	External ExternalConfig `yaml:"external"`
 }
 
+// DefaultHost is the public GitHub host
+const DefaultHost = "github.com"
+
 // GitHubConfig holds GitHub API configuration
 type GitHubConfig struct {
-	Token   string `yaml:"token"`
-	BaseURL string `yaml:"base_url"`
+	Token       string `yaml:"token"`
+	TokenSource string `yaml:"-"` // where Token came from, for diagnostics
+	Host        string `yaml:"host"`
+	BaseURL     string `yaml:"base_url"`
 }
 
 // UIConfig holds UI-specific configuration
 type UIConfig struct {
 	Theme       string        `yaml:"theme"`
-	RefreshRate time.Duration `yaml:"refresh_rate"`
+	RefreshRate time.Duration `yaml:"refresh_rate"` // how often the screen's clock ticks
+
+	// AutoRefresh is how often the visible PR list is reloaded from GitHub;
+	// zero turns automatic refreshes off
+	AutoRefresh time.Duration `yaml:"auto_refresh"`
+}
+
+// GitConfig holds local git integration settings
+type GitConfig struct {
+	// WorktreeDir, when set, makes PR checkouts create a dedicated worktree per PR
+	// under this directory instead of switching branches in the current clone
+	WorktreeDir string `yaml:"worktree_dir"`
+}
+
+// CacheConfig holds API response cache settings
+type CacheConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Dir     string `yaml:"dir"`
+
+	// Offline answers every read from the cache without trying GitHub (--offline)
+	Offline bool `yaml:"-"`
+}
+
+// StateConfig holds where gh-nav remembers things between runs: read pull
+// requests and changes queued while offline. Unlike the cache, it isn't safe to
+// throw away, and --no-cache leaves it alone.
+type StateConfig struct {
+	Dir string `yaml:"dir"` // "" keeps state in memory only
+}
+
+// ExternalConfig holds the external programs gh-nav hands off to. Defaults follow
+// gh's settings (GH_PAGER, GH_BROWSER, GH_EDITOR and gh's config.yml).
+type ExternalConfig struct {
+	Pager   string `yaml:"pager"`
+	Browser string `yaml:"browser"`
+	Editor  string `yaml:"editor"`
+}
+
+// DebugConfig holds diagnostics settings
+type DebugConfig struct {
+	// LogFile receives debug logging (API calls, warnings) when set
+	LogFile string `yaml:"log_file"`
+
+	// RecordFile receives a sanitized recording of API traffic when set (--record)
+	RecordFile string `yaml:"-"`
+
+	// ReplayFile answers API requests from a recording instead of GitHub (--replay)
+	ReplayFile string `yaml:"-"`
+}
+
+// DefaultPath returns the default configuration file location
+func DefaultPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "gh-nav", "config.yml")
 }
 
 // Load loads configuration from environment and defaults
 func Load() (*Config, error) {
-	// Try to get token from gh CLI first, fallback to environment variable
-	token := os.Getenv("GITHUB_TOKEN")
-	if token == "" {
-		// Try to get token from gh CLI
-		if ghToken, err := getGitHubCLIToken(); err == nil {
-			token = ghToken
-		}
-	}
+	// Resolve host and token the way gh does (GH_HOST, GH_TOKEN, hosts.yml, ...)
+	host := DefaultHostFromEnv()
+	token, source := TokenForHost(host)
 
 	cfg := &Config{
 		GitHub: GitHubConfig{
-			Token:   token,
-			BaseURL: "https://api.github.com",
+			Token:       token,
+			TokenSource: source,
+			Host:        host,
+			BaseURL:     APIBaseURL(host),
 		},
 		UI: UIConfig{
 			Theme:       "dark",
 			RefreshRate: time.Second,
+			AutoRefresh: 2 * time.Minute,
+		},
+		Git: GitConfig{
+			WorktreeDir: os.Getenv("GH_NAV_WORKTREE_DIR"),
 		},
+		Cache: CacheConfig{
+			Enabled: true,
+			Dir:     defaultCacheDir(),
+		},
+		State: StateConfig{
+			Dir: defaultStateDir(),
+		},
+		External: externalFromEnv(),
+	}
+
+	return cfg, nil
+}
+
+// LoadFile loads configuration from defaults and the environment, then applies the
+// YAML file at path on top. Environment variables in the file (e.g. ${GITHUB_TOKEN})
+// are expanded. An empty path uses DefaultPath and tolerates it not existing.
+func LoadFile(path string) (*Config, error) {
+	cfg, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	optional := path == ""
+	if optional {
+		path = DefaultPath()
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if optional && errors.Is(err, os.ErrNotExist) {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	prev := cfg.GitHub
+	if err := yaml.Unmarshal([]byte(os.ExpandEnv(string(data))), cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	// A host set in the file implies its API URL and token unless those are set too
+	if cfg.GitHub.Host != prev.Host {
+		file := cfg.GitHub
+		cfg.GitHub = prev
+		cfg.SetHost(file.Host)
+		if file.BaseURL != prev.BaseURL {
+			cfg.GitHub.BaseURL = file.BaseURL
+		}
+		if file.Token != prev.Token {
+			cfg.GitHub.Token = file.Token
+		}
+	}
+	if cfg.GitHub.Token != prev.Token {
+		cfg.GitHub.TokenSource = "config file"
 	}
 
 	return cfg, nil
 }
 
-// getGitHubCLIToken retrieves the GitHub token from the gh CLI
-func getGitHubCLIToken() (string, error) {
-	// Execute gh auth token command
-	cmd := exec.Command("gh", "auth", "token")
-	output, err := cmd.Output()
+// defaultStateDir returns the per-user state directory for gh-nav, next to its
+// configuration file as gh keeps its own state
+func defaultStateDir() string {
+	dir, err := os.UserConfigDir()
 	if err != nil {
-		return "", fmt.Errorf("failed to get gh token: %w", err)
+		return ""
 	}
+	return filepath.Join(dir, "gh-nav")
+}
 
-	// Trim whitespace and newlines
-	token := strings.TrimSpace(string(output))
-	if token == "" {
-		return "", fmt.Errorf("gh token is empty")
+// defaultCacheDir returns the per-user cache directory for gh-nav
+func defaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "gh-nav")
+}
+
+// SetHost points the configuration at another GitHub host (e.g. a GitHub Enterprise
+// Server instance), updating the API base URL and the token for that host
+func (c *Config) SetHost(host string) {
+	host = strings.ToLower(host)
+	if host == "" || host == c.GitHub.Host {
+		return
 	}
 
-	return token, nil
+	c.GitHub.Host = host
+	c.GitHub.BaseURL = APIBaseURL(host)
+	c.GitHub.Token, c.GitHub.TokenSource = TokenForHost(host)
+}
+
+// APIBaseURL returns the REST API base URL for a GitHub host
+func APIBaseURL(host string) string {
+	if host == "" || host == DefaultHost {
+		return "https://api.github.com"
+	}
+	return fmt.Sprintf("https://%s/api/v3", host)
 }
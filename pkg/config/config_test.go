@@ -2,7 +2,9 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestLoad(t *testing.T) {
@@ -56,3 +58,86 @@ func TestLoadWithEnvVar(t *testing.T) {
 		t.Errorf("Expected token to be %s, got %s", testToken, cfg.GitHub.Token)
 	}
 }
+
+func TestSetHost(t *testing.T) {
+	cfg := &Config{
+		GitHub: GitHubConfig{
+			Token:   "github-token",
+			Host:    DefaultHost,
+			BaseURL: APIBaseURL(DefaultHost),
+		},
+	}
+
+	cfg.SetHost("GHE.example.com")
+
+	if cfg.GitHub.Host != "ghe.example.com" {
+		t.Errorf("Expected host ghe.example.com, got %s", cfg.GitHub.Host)
+	}
+	if cfg.GitHub.BaseURL != "https://ghe.example.com/api/v3" {
+		t.Errorf("Expected enterprise base URL, got %s", cfg.GitHub.BaseURL)
+	}
+	if cfg.GitHub.Token == "github-token" {
+		t.Error("Expected github.com token not to be sent to an enterprise host")
+	}
+}
+
+func TestAPIBaseURL(t *testing.T) {
+	if url := APIBaseURL(DefaultHost); url != "https://api.github.com" {
+		t.Errorf("Expected https://api.github.com, got %s", url)
+	}
+	if url := APIBaseURL(""); url != "https://api.github.com" {
+		t.Errorf("Expected https://api.github.com for empty host, got %s", url)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	os.Setenv("GH_NAV_TEST_TOKEN", "file-token")
+	defer os.Unsetenv("GH_NAV_TEST_TOKEN")
+
+	content := `github:
+  token: ${GH_NAV_TEST_TOKEN}
+  host: ghe.example.com
+ui:
+  refresh_rate: 5s
+  auto_refresh: 0s
+git:
+  worktree_dir: /tmp/worktrees
+cache:
+  enabled: false
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to load config file: %v", err)
+	}
+
+	if cfg.GitHub.Token != "file-token" {
+		t.Errorf("Expected expanded token from file, got %q", cfg.GitHub.Token)
+	}
+	if cfg.GitHub.BaseURL != "https://ghe.example.com/api/v3" {
+		t.Errorf("Expected base URL derived from host, got %s", cfg.GitHub.BaseURL)
+	}
+	if cfg.UI.RefreshRate != 5*time.Second {
+		t.Errorf("Expected refresh rate 5s, got %v", cfg.UI.RefreshRate)
+	}
+	if cfg.UI.AutoRefresh != 0 {
+		t.Errorf("Expected auto-refresh turned off, got %v", cfg.UI.AutoRefresh)
+	}
+	if cfg.UI.Theme != "dark" {
+		t.Errorf("Expected default theme to survive, got %s", cfg.UI.Theme)
+	}
+	if cfg.Git.WorktreeDir != "/tmp/worktrees" {
+		t.Errorf("Expected worktree dir from file, got %s", cfg.Git.WorktreeDir)
+	}
+	if cfg.Cache.Enabled {
+		t.Error("Expected cache to be disabled by file")
+	}
+
+	if _, err := LoadFile(filepath.Join(t.TempDir(), "missing.yml")); err == nil {
+		t.Error("Expected error for an explicit missing config file")
+	}
+}
@@ -0,0 +1,30 @@
+#!/usr/bin/env bash
+# Cross-compiles gh-nav into dist/ using the file names `gh extension install`
+# expects from precompiled releases: gh-nav-<os>-<arch>[.exe]
+set -euo pipefail
+
+version="${1:-${GITHUB_REF_NAME:-dev}}"
+platforms=(
+  darwin-amd64
+  darwin-arm64
+  linux-386
+  linux-amd64
+  linux-arm
+  linux-arm64
+  windows-386
+  windows-amd64
+  windows-arm64
+)
+
+mkdir -p dist
+for platform in "${platforms[@]}"; do
+  goos="${platform%-*}"
+  goarch="${platform#*-}"
+  ext=""
+  if [ "$goos" = "windows" ]; then
+    ext=".exe"
+  fi
+  GOOS="$goos" GOARCH="$goarch" CGO_ENABLED=0 \
+    go build -trimpath -ldflags="-s -w -X main.version=${version}" \
+    -o "dist/gh-nav-${platform}${ext}" ./cmd
+done